@@ -20,6 +20,7 @@ func Convert_v1alpha2_ConfigurationSpec_To_v1alpha1_ConfigurationSpec(in *v1alph
 	out.Parallel = in.Execution.Parallel
 	out.RepeatCount = in.Execution.RepeatCount
 	out.ForceTerminationGracePeriod = in.Execution.ForceTerminationGracePeriod
+	out.Watch = in.Execution.Watch
 	out.Namespace = in.Namespace.Name
 	out.NamespaceTemplate = in.Namespace.Template
 	out.NamespaceTemplateCompiler = in.Namespace.Compiler
@@ -58,6 +59,7 @@ func Convert_v1alpha1_ConfigurationSpec_To_v1alpha2_ConfigurationSpec(in *v1alph
 		Parallel:                    in.Parallel,
 		RepeatCount:                 in.RepeatCount,
 		ForceTerminationGracePeriod: in.ForceTerminationGracePeriod,
+		Watch:                       in.Watch,
 	}
 	out.Namespace = v1alpha2.NamespaceOptions{
 		Name:       in.Namespace,
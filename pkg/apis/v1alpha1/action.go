@@ -44,6 +44,14 @@ type ActionClusters struct {
 	Clusters Clusters `json:"clusters,omitempty"`
 }
 
+// ActionConsistently contains consistency options for an action.
+type ActionConsistently struct {
+	// Consistently requires the check to hold on every evaluation for the given duration instead of succeeding on the first match.
+	// The operation fails as soon as an evaluation doesn't hold, the operation timeout applies on top of this duration.
+	// +optional
+	Consistently *metav1.Duration `json:"consistently,omitempty"`
+}
+
 // ActionDryRun contains dry run options for an action.
 type ActionDryRun struct {
 	// DryRun determines whether the file should be applied in dry run mode.
@@ -87,6 +95,13 @@ type ActionInlineResource struct {
 	Resource *unstructured.Unstructured `json:"resource,omitempty"`
 }
 
+// ActionMatchMode contains candidates matching options for an action.
+type ActionMatchMode struct {
+	// MatchMode determines how many candidates must match the expectation, at least one candidate must match by default.
+	// +optional
+	MatchMode *MatchMode `json:"matchMode,omitempty"`
+}
+
 // ActionObject contains object selector options for an action.
 type ActionObject struct {
 	ObjectType           `json:",inline"`
@@ -133,6 +148,21 @@ type ActionResourceRef struct {
 	Template *bool `json:"template,omitempty"`
 }
 
+// ActionServerSide contains server-side apply options for an action.
+type ActionServerSide struct {
+	// ServerSide determines whether the resource should be applied using server-side apply.
+	// +optional
+	ServerSide *bool `json:"serverSide,omitempty"`
+
+	// FieldManager is the name of the field manager used with server-side apply (defaults to chainsaw).
+	// +optional
+	FieldManager string `json:"fieldManager,omitempty"`
+
+	// Force determines whether field manager conflicts are resolved by taking ownership of the conflicting fields.
+	// +optional
+	Force *bool `json:"force,omitempty"`
+}
+
 // ActionTimeout contains timeout options for an action.
 type ActionTimeout struct {
 	// Timeout for the operation. Overrides the global timeout set in the Configuration.
@@ -149,16 +179,19 @@ type Apply struct {
 	ActionExpectations `json:",inline"`
 	ActionOutputs      `json:",inline"`
 	ActionResourceRef  `json:",inline"`
+	ActionServerSide   `json:",inline"`
 	ActionTimeout      `json:",inline"`
 }
 
 // Assert represents a test condition that is expected to hold true
 // during the testing process.
 type Assert struct {
-	ActionBindings `json:",inline"`
-	ActionCheckRef `json:",inline"`
-	ActionClusters `json:",inline"`
-	ActionTimeout  `json:",inline"`
+	ActionBindings     `json:",inline"`
+	ActionCheckRef     `json:",inline"`
+	ActionClusters     `json:",inline"`
+	ActionConsistently `json:",inline"`
+	ActionMatchMode    `json:",inline"`
+	ActionTimeout      `json:",inline"`
 }
 
 // Command describes a command to run as a part of a test step.
@@ -180,6 +213,11 @@ type Command struct {
 	// WorkDir is the working directory for command.
 	// +optional
 	WorkDir *string `json:"workDir,omitempty"`
+
+	// Background determines whether the command runs in the background.
+	// The process handle is available in the $handle binding, it can be passed to a process operation.
+	// +optional
+	Background bool `json:"background,omitempty"`
 }
 
 // Create represents a set of resources that should be created.
@@ -238,10 +276,12 @@ type Describe struct {
 // Error represents an anticipated error condition that may arise during testing.
 // Instead of treating such an error as a test failure, it acknowledges it as expected.
 type Error struct {
-	ActionBindings `json:",inline"`
-	ActionCheckRef `json:",inline"`
-	ActionClusters `json:",inline"`
-	ActionTimeout  `json:",inline"`
+	ActionBindings     `json:",inline"`
+	ActionCheckRef     `json:",inline"`
+	ActionClusters     `json:",inline"`
+	ActionConsistently `json:",inline"`
+	ActionMatchMode    `json:",inline"`
+	ActionTimeout      `json:",inline"`
 }
 
 // Events defines how to collect events.
@@ -252,6 +292,31 @@ type Events struct {
 	ActionTimeout        `json:",inline"`
 }
 
+// Exec describes a command to run inside a pod container.
+type Exec struct {
+	ActionBindings       `json:",inline"`
+	ActionCheck          `json:",inline"`
+	ActionClusters       `json:",inline"`
+	ActionObjectSelector `json:",inline"`
+	ActionOutputs        `json:",inline"`
+	ActionTimeout        `json:",inline"`
+
+	// Container defines the container to run the command in, the default container of the pod is used if not specified.
+	// +optional
+	Container Expression `json:"container,omitempty"`
+
+	// Command defines the command and its arguments.
+	Command []string `json:"command"`
+
+	// SkipLogOutput removes the output from the command. Useful for sensitive logs or to reduce noise.
+	// +optional
+	SkipLogOutput bool `json:"skipLogOutput,omitempty"`
+
+	// SkipCommandOutput removes the command from the output logs.
+	// +optional
+	SkipCommandOutput bool `json:"skipCommandOutput,omitempty"`
+}
+
 // Get defines how to get resources.
 type Get struct {
 	ActionClusters `json:",inline"`
@@ -260,6 +325,66 @@ type Get struct {
 	ActionTimeout  `json:",inline"`
 }
 
+// HTTP defines an HTTP request to run.
+// +kubebuilder:not:={required:{url,target}}
+type HTTP struct {
+	ActionBindings `json:",inline"`
+	ActionCheck    `json:",inline"`
+	ActionClusters `json:",inline"`
+	ActionOutputs  `json:",inline"`
+	ActionTimeout  `json:",inline"`
+
+	// Method defines the HTTP method of the request (defaults to GET).
+	// +optional
+	// +kubebuilder:validation:Enum:=GET;HEAD;POST;PUT;PATCH;DELETE;OPTIONS
+	Method string `json:"method,omitempty"`
+
+	// URL defines the URL the request is sent to.
+	// +optional
+	URL Expression `json:"url,omitempty"`
+
+	// Target defines a service or pod the request is sent to, through the API server proxy.
+	// +optional
+	Target *HTTPTarget `json:"target,omitempty"`
+
+	// Headers defines the request headers.
+	// +optional
+	Headers map[string]Expression `json:"headers,omitempty"`
+
+	// Body defines the request body, non string values are sent as JSON.
+	// +optional
+	Body *Projection `json:"body,omitempty"`
+
+	// TLS defines the TLS options used when sending the request to an URL.
+	// +optional
+	TLS *HTTPTLS `json:"tls,omitempty"`
+}
+
+// HTTPTarget defines a service or pod to send an HTTP request to, through the API server proxy.
+type HTTPTarget struct {
+	ObjectName `json:",inline"`
+	ObjectType `json:",inline"`
+
+	// Port defines the target port (name or number, prefixed with `https:` to use TLS).
+	// +optional
+	Port Expression `json:"port,omitempty"`
+
+	// Path defines the target path.
+	// +optional
+	Path Expression `json:"path,omitempty"`
+}
+
+// HTTPTLS defines TLS options for an HTTP request.
+type HTTPTLS struct {
+	// InsecureSkipVerify disables the verification of the server certificate.
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+
+	// CAFile defines the path to a file containing the CA certificates used to verify the server certificate.
+	// +optional
+	CAFile string `json:"caFile,omitempty"`
+}
+
 // Patch represents a set of resources that should be patched.
 // If a resource doesn't exist yet in the cluster it will fail.
 type Patch struct {
@@ -273,6 +398,43 @@ type Patch struct {
 
 	// Subresource specifies the name of the subresource that the patch should target.
 	Subresource string `json:"subresource,omitempty"`
+
+	// Type determines the patch type (merge, strategic or json), defaults to merge.
+	// +optional
+	// +kubebuilder:validation:Enum:=merge;strategic;json
+	Type PatchType `json:"type,omitempty"`
+
+	// JSONPatch defines the RFC 6902 operations sent with the json patch type.
+	// The resource is only used to identify the object to be patched.
+	// +optional
+	JSONPatch []JSONPatchOperation `json:"jsonPatch,omitempty"`
+}
+
+// PatchType defines the type of patch sent by a patch operation.
+type PatchType string
+
+const (
+	MergePatchType          PatchType = "merge"
+	StrategicMergePatchType PatchType = "strategic"
+	JSONPatchType           PatchType = "json"
+)
+
+// JSONPatchOperation represents an RFC 6902 JSON patch operation.
+type JSONPatchOperation struct {
+	// Op is the operation to perform.
+	// +kubebuilder:validation:Enum:=add;remove;replace;move;copy;test
+	Op string `json:"op"`
+
+	// Path is the JSON pointer to the target location.
+	Path string `json:"path"`
+
+	// From is the JSON pointer to the source location, used by move and copy operations.
+	// +optional
+	From string `json:"from,omitempty"`
+
+	// Value is the value used by add, replace and test operations.
+	// +optional
+	Value *Projection `json:"value,omitempty"`
 }
 
 // PodLogs defines how to collect pod logs.
@@ -292,6 +454,42 @@ type PodLogs struct {
 	Tail *int `json:"tail,omitempty"`
 }
 
+// PortForward forwards a local port to a pod or service for the remainder of the test.
+type PortForward struct {
+	ActionBindings `json:",inline"`
+	ActionClusters `json:",inline"`
+	ActionOutputs  `json:",inline"`
+	ActionTimeout  `json:",inline"`
+	ObjectName     `json:",inline"`
+	ObjectType     `json:",inline"`
+
+	// Port defines the target port (number or name) of the pod or service.
+	Port Expression `json:"port"`
+
+	// LocalPort defines the local port to listen on, a random port is used if not specified.
+	// +optional
+	LocalPort Expression `json:"localPort,omitempty"`
+
+	// Address defines the local address to listen on, defaults to 127.0.0.1.
+	// +optional
+	Address Expression `json:"address,omitempty"`
+}
+
+// Process waits for or stops a command or script running in the background.
+type Process struct {
+	ActionBindings `json:",inline"`
+	ActionCheck    `json:",inline"`
+	ActionOutputs  `json:",inline"`
+	ActionTimeout  `json:",inline"`
+
+	// Handle is the handle of the background process.
+	Handle Expression `json:"handle"`
+
+	// Stop determines whether the process is stopped instead of waiting for it to complete.
+	// +optional
+	Stop bool `json:"stop,omitempty"`
+}
+
 // Proxy defines how to get resources.
 type Proxy struct {
 	ActionClusters `json:",inline"`
@@ -331,6 +529,11 @@ type Script struct {
 	// WorkDir is the working directory for script.
 	// +optional
 	WorkDir *string `json:"workDir,omitempty"`
+
+	// Background determines whether the script runs in the background.
+	// The process handle is available in the $handle binding, it can be passed to a process operation.
+	// +optional
+	Background bool `json:"background,omitempty"`
 }
 
 // Sleep represents a duration while nothing happens.
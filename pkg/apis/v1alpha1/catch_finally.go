@@ -6,6 +6,7 @@ package v1alpha1
 // +kubebuilder:oneOf:={required:{describe}}
 // +kubebuilder:oneOf:={required:{events}}
 // +kubebuilder:oneOf:={required:{get}}
+// +kubebuilder:oneOf:={required:{parallel}}
 // +kubebuilder:oneOf:={required:{podLogs}}
 // +kubebuilder:oneOf:={required:{script}}
 // +kubebuilder:oneOf:={required:{sleep}}
@@ -15,6 +16,10 @@ type CatchFinally struct {
 	// +optional
 	Description string `json:"description,omitempty"`
 
+	// If is a condition evaluated before running the operation, the operation is skipped when it doesn't evaluate to true.
+	// +optional
+	If Condition `json:"if,omitempty"`
+
 	// Compiler defines the default compiler to use when evaluating expressions.
 	// +optional
 	Compiler *Compiler `json:"compiler,omitempty"`
@@ -54,6 +59,14 @@ type CatchFinally struct {
 	// Sleep defines zzzz.
 	// +optional
 	Sleep *Sleep `json:"sleep,omitempty"`
+
+	// Parallel defines a group of operations running concurrently.
+	// Outputs produced by the operations of the group are available once all operations completed.
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=array
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Parallel []CatchFinally `json:"parallel,omitempty"`
 }
 
 func (f *CatchFinally) Bindings() []Binding {
@@ -68,6 +81,8 @@ func (f *CatchFinally) Bindings() []Binding {
 		return nil
 	case f.Get != nil:
 		return nil
+	case len(f.Parallel) != 0:
+		return nil
 	case f.PodLogs != nil:
 		return nil
 	case f.Script != nil:
@@ -92,6 +107,12 @@ func (f *CatchFinally) Outputs() []Output {
 		return nil
 	case f.Get != nil:
 		return nil
+	case len(f.Parallel) != 0:
+		var outputs []Output
+		for i := range f.Parallel {
+			outputs = append(outputs, f.Parallel[i].Outputs()...)
+		}
+		return outputs
 	case f.PodLogs != nil:
 		return nil
 	case f.Script != nil:
@@ -17,6 +17,7 @@ func TestFinally_Bindings(t *testing.T) {
 		Command  *Command
 		Script   *Script
 		Sleep    *Sleep
+		Parallel []CatchFinally
 	}
 	tests := []struct {
 		name   string
@@ -48,6 +49,14 @@ func TestFinally_Bindings(t *testing.T) {
 		fields: fields{
 			Get: &Get{},
 		},
+	}, {
+		fields: fields{
+			Parallel: []CatchFinally{{
+				Script: &Script{
+					ActionBindings: ActionBindings{Bindings: []Binding{{Name: "foo", Value: NewProjection("bar")}}},
+				},
+			}},
+		},
 	}, {
 		fields: fields{
 			PodLogs: &PodLogs{},
@@ -80,6 +89,7 @@ func TestFinally_Bindings(t *testing.T) {
 				Command:  tt.fields.Command,
 				Script:   tt.fields.Script,
 				Sleep:    tt.fields.Sleep,
+				Parallel: tt.fields.Parallel,
 			}
 			got := c.Bindings()
 			assert.Equal(t, tt.want, len(got))
@@ -99,6 +109,7 @@ func TestFinally_Outputs(t *testing.T) {
 		Command  *Command
 		Script   *Script
 		Sleep    *Sleep
+		Parallel []CatchFinally
 	}
 	tests := []struct {
 		name   string
@@ -127,6 +138,19 @@ func TestFinally_Outputs(t *testing.T) {
 		fields: fields{
 			Get: &Get{},
 		},
+	}, {
+		fields: fields{
+			Parallel: []CatchFinally{{
+				Script: &Script{
+					ActionOutputs: ActionOutputs{Outputs: []Output{{Binding: Binding{Name: "foo", Value: NewProjection("bar")}}}},
+				},
+			}, {
+				Command: &Command{
+					ActionOutputs: ActionOutputs{Outputs: []Output{{Binding: Binding{Name: "bar", Value: NewProjection("baz")}}}},
+				},
+			}},
+		},
+		want: 2,
 	}, {
 		fields: fields{
 			PodLogs: &PodLogs{},
@@ -159,6 +183,7 @@ func TestFinally_Outputs(t *testing.T) {
 				Command:  tt.fields.Command,
 				Script:   tt.fields.Script,
 				Sleep:    tt.fields.Sleep,
+				Parallel: tt.fields.Parallel,
 			}
 			got := c.Outputs()
 			assert.Equal(t, tt.want, len(got))
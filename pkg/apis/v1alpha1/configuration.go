@@ -58,10 +58,10 @@ type ConfigurationSpec struct {
 	// +kubebuilder:default:=Background
 	DeletionPropagationPolicy metav1.DeletionPropagation `json:"deletionPropagationPolicy,omitempty"`
 
-	// ReportFormat determines test report format (JSON, XML, JUNIT-TEST, JUNIT-STEP, JUNIT-OPERATION, nil) nil == no report.
+	// ReportFormat determines test report format (JSON, XML, JUNIT-TEST, JUNIT-STEP, JUNIT-OPERATION, HTML, nil) nil == no report.
 	// maps to report.Type, however we don't want generated.deepcopy to have reference to it.
 	// +optional
-	// +kubebuilder:validation:Enum:=JSON;XML;JUNIT-TEST;JUNIT-STEP;JUNIT-OPERATION;
+	// +kubebuilder:validation:Enum:=JSON;XML;JUNIT-TEST;JUNIT-STEP;JUNIT-OPERATION;HTML;
 	ReportFormat ReportFormatType `json:"reportFormat,omitempty"`
 
 	// ReportPath defines the path.
@@ -119,6 +119,11 @@ type ConfigurationSpec struct {
 	// +optional
 	ForceTerminationGracePeriod *metav1.Duration `json:"forceTerminationGracePeriod,omitempty"`
 
+	// Watch determines whether assert and error operations are evaluated when resources change, using shared informers.
+	// Polling is used when resources can't be watched.
+	// +optional
+	Watch bool `json:"watch,omitempty"`
+
 	// DelayBeforeCleanup adds a delay between the time a test ends and the time cleanup starts.
 	// +optional
 	DelayBeforeCleanup *metav1.Duration `json:"delayBeforeCleanup,omitempty"`
@@ -141,5 +146,6 @@ const (
 	JUnitTestFormat      ReportFormatType = "JUNIT-TEST"
 	JUnitStepFormat      ReportFormatType = "JUNIT-STEP"
 	JUnitOperationFormat ReportFormatType = "JUNIT-OPERATION"
+	HTMLFormat           ReportFormatType = "HTML"
 	NoReport             ReportFormatType = ""
 )
@@ -6,11 +6,24 @@ type OperationBase struct {
 	// +optional
 	Description string `json:"description,omitempty"`
 
+	// If is a condition evaluated before running the operation, the operation is skipped when it doesn't evaluate to true.
+	// +optional
+	If Condition `json:"if,omitempty"`
+
+	// ForEach runs the operation once per item.
+	// +optional
+	ForEach *ForEach `json:"forEach,omitempty"`
+
 	// ContinueOnError determines whether a test should continue or not in case the operation was not successful.
 	// Even if the test continues executing, it will still be reported as failed.
 	// +optional
 	ContinueOnError *bool `json:"continueOnError,omitempty"`
 
+	// Retry determines how the operation is retried when it fails.
+	// Overrides the retry policy set in the TestStep.
+	// +optional
+	Retry *Retry `json:"retry,omitempty"`
+
 	// Compiler defines the default compiler to use when evaluating expressions.
 	// +optional
 	Compiler *Compiler `json:"compiler,omitempty"`
@@ -25,8 +38,13 @@ type OperationBase struct {
 // +kubebuilder:oneOf:={required:{describe}}
 // +kubebuilder:oneOf:={required:{error}}
 // +kubebuilder:oneOf:={required:{events}}
+// +kubebuilder:oneOf:={required:{exec}}
+// +kubebuilder:oneOf:={required:{http}}
+// +kubebuilder:oneOf:={required:{parallel}}
 // +kubebuilder:oneOf:={required:{patch}}
 // +kubebuilder:oneOf:={required:{podLogs}}
+// +kubebuilder:oneOf:={required:{portForward}}
+// +kubebuilder:oneOf:={required:{process}}
 // +kubebuilder:oneOf:={required:{proxy}}
 // +kubebuilder:oneOf:={required:{script}}
 // +kubebuilder:oneOf:={required:{sleep}}
@@ -71,10 +89,26 @@ type Operation struct {
 	// +optional
 	Events *Events `json:"events,omitempty"`
 
+	// Exec defines a command to run inside a pod container.
+	// +optional
+	Exec *Exec `json:"exec,omitempty"`
+
 	// Get determines the resource get collector to execute.
 	// +optional
 	Get *Get `json:"get,omitempty"`
 
+	// HTTP runs an HTTP request.
+	// +optional
+	HTTP *HTTP `json:"http,omitempty"`
+
+	// Parallel defines a group of operations running concurrently.
+	// Outputs produced by the operations of the group are available once all operations completed.
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=array
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Parallel []Operation `json:"parallel,omitempty"`
+
 	// Patch represents a patch operation.
 	// +optional
 	Patch *Patch `json:"patch,omitempty"`
@@ -83,6 +117,14 @@ type Operation struct {
 	// +optional
 	PodLogs *PodLogs `json:"podLogs,omitempty"`
 
+	// PortForward forwards a local port to a pod or service.
+	// +optional
+	PortForward *PortForward `json:"portForward,omitempty"`
+
+	// Process waits for or stops a background process.
+	// +optional
+	Process *Process `json:"process,omitempty"`
+
 	// Proxy runs a proxy request.
 	// +optional
 	Proxy *Proxy `json:"proxy,omitempty"`
@@ -122,12 +164,22 @@ func (o *Operation) Bindings() []Binding {
 		return o.Error.Bindings
 	case o.Events != nil:
 		return nil
+	case o.Exec != nil:
+		return o.Exec.Bindings
 	case o.Get != nil:
 		return nil
+	case o.HTTP != nil:
+		return o.HTTP.Bindings
+	case len(o.Parallel) != 0:
+		return nil
 	case o.Patch != nil:
 		return o.Patch.Bindings
 	case o.PodLogs != nil:
 		return nil
+	case o.PortForward != nil:
+		return o.PortForward.Bindings
+	case o.Process != nil:
+		return o.Process.Bindings
 	case o.Proxy != nil:
 		return nil
 	case o.Script != nil:
@@ -160,12 +212,29 @@ func (o *Operation) Outputs() []Output {
 		return nil
 	case o.Events != nil:
 		return nil
+	case o.Exec != nil:
+		return o.Exec.Outputs
 	case o.Get != nil:
 		return nil
+	case o.HTTP != nil:
+		return o.HTTP.Outputs
+	case len(o.Parallel) != 0:
+		var outputs []Output
+		for i := range o.Parallel {
+			// outputs produced by loop iterations are not propagated
+			if o.Parallel[i].ForEach == nil {
+				outputs = append(outputs, o.Parallel[i].Outputs()...)
+			}
+		}
+		return outputs
 	case o.Patch != nil:
 		return o.Patch.Outputs
 	case o.PodLogs != nil:
 		return nil
+	case o.PortForward != nil:
+		return o.PortForward.Outputs
+	case o.Process != nil:
+		return o.Process.Outputs
 	case o.Proxy != nil:
 		return o.Proxy.Outputs
 	case o.Script != nil:
@@ -63,11 +63,25 @@ func TestOperation_Bindings(t *testing.T) {
 			Events: &Events{},
 		},
 		want: 0,
+	}, {
+		operation: Operation{
+			Exec: &Exec{
+				ActionBindings: ActionBindings{Bindings: []Binding{{Name: "foo", Value: NewProjection("bar")}}},
+			},
+		},
+		want: 1,
 	}, {
 		operation: Operation{
 			Get: &Get{},
 		},
 		want: 0,
+	}, {
+		operation: Operation{
+			HTTP: &HTTP{
+				ActionBindings: ActionBindings{Bindings: []Binding{{Name: "foo", Value: NewProjection("bar")}}},
+			},
+		},
+		want: 1,
 	}, {
 		operation: Operation{
 			Patch: &Patch{
@@ -80,6 +94,29 @@ func TestOperation_Bindings(t *testing.T) {
 			PodLogs: &PodLogs{},
 		},
 		want: 0,
+	}, {
+		operation: Operation{
+			Parallel: []Operation{{
+				Script: &Script{
+					ActionBindings: ActionBindings{Bindings: []Binding{{Name: "foo", Value: NewProjection("bar")}}},
+				},
+			}},
+		},
+		want: 0,
+	}, {
+		operation: Operation{
+			PortForward: &PortForward{
+				ActionBindings: ActionBindings{Bindings: []Binding{{Name: "foo", Value: NewProjection("bar")}}},
+			},
+		},
+		want: 1,
+	}, {
+		operation: Operation{
+			Process: &Process{
+				ActionBindings: ActionBindings{Bindings: []Binding{{Name: "foo", Value: NewProjection("bar")}}},
+			},
+		},
+		want: 1,
 	}, {
 		operation: Operation{
 			Proxy: &Proxy{},
@@ -163,10 +200,24 @@ func TestOperation_Outputs(t *testing.T) {
 		operation: Operation{
 			Events: &Events{},
 		},
+	}, {
+		operation: Operation{
+			Exec: &Exec{
+				ActionOutputs: ActionOutputs{Outputs: []Output{{Binding: Binding{Name: "foo", Value: NewProjection("bar")}}}},
+			},
+		},
+		want: 1,
 	}, {
 		operation: Operation{
 			Get: &Get{},
 		},
+	}, {
+		operation: Operation{
+			HTTP: &HTTP{
+				ActionOutputs: ActionOutputs{Outputs: []Output{{Binding: Binding{Name: "foo", Value: NewProjection("bar")}}}},
+			},
+		},
+		want: 1,
 	}, {
 		operation: Operation{
 			Patch: &Patch{
@@ -178,6 +229,40 @@ func TestOperation_Outputs(t *testing.T) {
 		operation: Operation{
 			PodLogs: &PodLogs{},
 		},
+	}, {
+		operation: Operation{
+			Parallel: []Operation{{
+				Script: &Script{
+					ActionOutputs: ActionOutputs{Outputs: []Output{{Binding: Binding{Name: "foo", Value: NewProjection("bar")}}}},
+				},
+			}, {
+				Command: &Command{
+					ActionOutputs: ActionOutputs{Outputs: []Output{{Binding: Binding{Name: "bar", Value: NewProjection("baz")}}}},
+				},
+			}, {
+				OperationBase: OperationBase{
+					ForEach: &ForEach{},
+				},
+				Script: &Script{
+					ActionOutputs: ActionOutputs{Outputs: []Output{{Binding: Binding{Name: "baz", Value: NewProjection("qux")}}}},
+				},
+			}},
+		},
+		want: 2,
+	}, {
+		operation: Operation{
+			PortForward: &PortForward{
+				ActionOutputs: ActionOutputs{Outputs: []Output{{Binding: Binding{Name: "foo", Value: NewProjection("bar")}}}},
+			},
+		},
+		want: 1,
+	}, {
+		operation: Operation{
+			Process: &Process{
+				ActionOutputs: ActionOutputs{Outputs: []Output{{Binding: Binding{Name: "foo", Value: NewProjection("bar")}}}},
+			},
+		},
+		want: 1,
 	}, {
 		operation: Operation{
 			Proxy: &Proxy{},
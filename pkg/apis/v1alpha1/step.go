@@ -55,6 +55,15 @@ type TestStep struct {
 	// Use defines a reference to a step template.
 	Use *Use `json:"use,omitempty"`
 
+	// If is a condition evaluated before running the step, the step is skipped when it doesn't evaluate to true.
+	// The condition is evaluated before the step bindings are set up, it can't use them.
+	// +optional
+	If Condition `json:"if,omitempty"`
+
+	// ForEach runs the step once per item.
+	// +optional
+	ForEach *ForEach `json:"forEach,omitempty"`
+
 	// TestStepSpec of the step.
 	TestStepSpec `json:",inline"`
 }
@@ -114,6 +123,11 @@ type TestStepSpec struct {
 	// +optional
 	Compiler *Compiler `json:"compiler,omitempty"`
 
+	// Retry determines how the step is retried when it fails.
+	// The try block is executed again once catch, finally and cleanup ran.
+	// +optional
+	Retry *Retry `json:"retry,omitempty"`
+
 	// Bindings defines additional binding key/values.
 	// +optional
 	Bindings []Binding `json:"bindings,omitempty"`
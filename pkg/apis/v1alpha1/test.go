@@ -52,6 +52,11 @@ type TestSpec struct {
 	// +optional
 	Concurrent *bool `json:"concurrent,omitempty"`
 
+	// DependsOn lists the names of the tests that must pass before this test runs.
+	// The test is skipped if one of them fails or doesn't run.
+	// +optional
+	DependsOn []string `json:"dependsOn,omitempty"`
+
 	// SkipDelete determines whether the resources created by the test should be deleted after the test is executed.
 	// +optional
 	SkipDelete *bool `json:"skipDelete,omitempty"`
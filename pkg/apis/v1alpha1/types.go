@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"strconv"
 
 	"github.com/jmespath-community/go-jmespath/pkg/parsing"
 	"github.com/kyverno/chainsaw/pkg/apis"
@@ -28,6 +29,35 @@ var (
 
 type Compiler = v1alpha1.Compiler
 
+// BackoffPolicy determines how the delay between retries evolves.
+type BackoffPolicy string
+
+const (
+	// BackoffPolicyConstant waits the same delay between retries.
+	BackoffPolicyConstant BackoffPolicy = "Constant"
+	// BackoffPolicyLinear increases the delay by the initial delay on every retry.
+	BackoffPolicyLinear BackoffPolicy = "Linear"
+	// BackoffPolicyExponential doubles the delay on every retry.
+	BackoffPolicyExponential BackoffPolicy = "Exponential"
+)
+
+// Backoff defines the delay between retries.
+type Backoff struct {
+	// Policy determines how the delay evolves between retries.
+	// +optional
+	// +kubebuilder:validation:Enum:=Constant;Linear;Exponential
+	// +kubebuilder:default:=Constant
+	Policy BackoffPolicy `json:"policy,omitempty"`
+
+	// Delay defines the delay before the first retry.
+	// +optional
+	Delay *metav1.Duration `json:"delay,omitempty"`
+
+	// MaxDelay defines the maximum delay between retries.
+	// +optional
+	MaxDelay *metav1.Duration `json:"maxDelay,omitempty"`
+}
+
 // Binding represents a key/value set as a binding in an executing test.
 type Binding struct {
 	// Name the name of the binding.
@@ -77,6 +107,36 @@ type Expectation struct {
 	Check Check `json:"check"`
 }
 
+// Condition defines a condition, it can be an expression or a boolean.
+// +kubebuilder:validation:XPreserveUnknownFields
+// +kubebuilder:validation:Type:=""
+type Condition string
+
+func (c *Condition) MarshalJSON() ([]byte, error) {
+	if c == nil {
+		return nil, nil
+	}
+	return json.Marshal(string(*c))
+}
+
+func (c *Condition) UnmarshalJSON(data []byte) error {
+	var value bool
+	if err := json.Unmarshal(data, &value); err == nil {
+		*c = Condition(strconv.FormatBool(value))
+		return nil
+	}
+	var expression Expression
+	if err := expression.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	*c = Condition(expression)
+	return nil
+}
+
+func (c Condition) Bool(ctx context.Context, compilers compilers.Compilers, bindings apis.Bindings) (bool, error) {
+	return Expression(c).Bool(ctx, compilers, bindings)
+}
+
 // Expression defines an expression to be used in string fields.
 type Expression string
 
@@ -112,6 +172,23 @@ func (e Expression) Value(ctx context.Context, compilers compilers.Compilers, bi
 	return expressions.String(ctx, compilers, string(e), bindings)
 }
 
+func (e Expression) Bool(ctx context.Context, compilers compilers.Compilers, bindings apis.Bindings) (bool, error) {
+	return expressions.Bool(ctx, compilers, string(e), bindings)
+}
+
+// ForEach defines a loop, the looped element is executed once per item with the $item and $index bindings.
+// +kubebuilder:oneOf:={required:{items}}
+// +kubebuilder:oneOf:={required:{matrix}}
+type ForEach struct {
+	// Items defines the list of items to iterate over (usually an expression producing a list).
+	// +optional
+	Items *Projection `json:"items,omitempty"`
+
+	// Matrix defines lists of values to combine, one item is produced for every combination of values.
+	// +optional
+	Matrix map[string]Projection `json:"matrix,omitempty"`
+}
+
 // Format determines the output format (json or yaml).
 // +kubebuilder:validation:Type:=string
 // +kubebuilder:validation:Pattern:=`^(?:json|yaml|\(.+\))$`
@@ -120,6 +197,34 @@ type Format Expression
 // Match represents a match condition against an evaluated object.
 type Match = v1alpha1.AssertionTree
 
+// MatchPolicy determines how many candidates must match an expectation.
+type MatchPolicy string
+
+const (
+	// MatchPolicyAny requires at least one candidate to match.
+	MatchPolicyAny MatchPolicy = "Any"
+	// MatchPolicyAll requires all candidates to match, and at least one candidate to exist.
+	MatchPolicyAll MatchPolicy = "All"
+	// MatchPolicyExactly requires exactly count candidates to match.
+	MatchPolicyExactly MatchPolicy = "Exactly"
+	// MatchPolicyAtLeast requires at least count candidates to match.
+	MatchPolicyAtLeast MatchPolicy = "AtLeast"
+)
+
+// MatchMode defines how many candidates must match an expectation.
+type MatchMode struct {
+	// Policy determines how many candidates must match.
+	// +optional
+	// +kubebuilder:validation:Enum:=Any;All;Exactly;AtLeast
+	// +kubebuilder:default:=Any
+	Policy MatchPolicy `json:"policy,omitempty"`
+
+	// Count is the number of candidates used by the Exactly and AtLeast policies.
+	// +optional
+	// +kubebuilder:validation:Minimum:=0
+	Count *int `json:"count,omitempty"`
+}
+
 // ObjectName represents an object namespace and name.
 type ObjectName struct {
 	// Namespace of the referent.
@@ -165,6 +270,22 @@ type Output struct {
 	Match *Match `json:"match,omitempty"`
 }
 
+// Retry defines how an operation or a step is retried when it fails.
+type Retry struct {
+	// Attempts is the maximum number of times the operation or step is executed, including the first attempt.
+	// +kubebuilder:validation:Minimum:=1
+	Attempts int `json:"attempts"`
+
+	// Backoff defines the delay between retries.
+	// +optional
+	Backoff *Backoff `json:"backoff,omitempty"`
+
+	// RetryOn is evaluated against the failed attempt (the error is available in the $error binding and, for operations, outputs in the $outputs binding).
+	// The operation or step is retried only when the check succeeds, if not set it is retried on any error.
+	// +optional
+	RetryOn *Check `json:"retryOn,omitempty"`
+}
+
 // DefaultTimeouts contains defautl timeouts per operation.
 type DefaultTimeouts struct {
 	// Apply defines the timeout for the apply operation
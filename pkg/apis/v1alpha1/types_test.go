@@ -55,3 +55,69 @@ func TestBinding_CheckName(t *testing.T) {
 		})
 	}
 }
+
+func TestCondition_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    Condition
+		wantErr bool
+	}{{
+		name: "expression",
+		data: `"($foo)"`,
+		want: "($foo)",
+	}, {
+		name: "true",
+		data: `true`,
+		want: "true",
+	}, {
+		name: "false",
+		data: `false`,
+		want: "false",
+	}, {
+		name:    "number",
+		data:    `1`,
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Condition
+			err := got.UnmarshalJSON([]byte(tt.data))
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestExpression_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    Expression
+		wantErr bool
+	}{{
+		name: "string",
+		data: `"($foo)"`,
+		want: "($foo)",
+	}, {
+		name:    "boolean",
+		data:    `true`,
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Expression
+			err := got.UnmarshalJSON([]byte(tt.data))
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -124,6 +124,27 @@ func (in *ActionClusters) DeepCopy() *ActionClusters {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActionConsistently) DeepCopyInto(out *ActionConsistently) {
+	*out = *in
+	if in.Consistently != nil {
+		in, out := &in.Consistently, &out.Consistently
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActionConsistently.
+func (in *ActionConsistently) DeepCopy() *ActionConsistently {
+	if in == nil {
+		return nil
+	}
+	out := new(ActionConsistently)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ActionDryRun) DeepCopyInto(out *ActionDryRun) {
 	*out = *in
@@ -227,6 +248,27 @@ func (in *ActionInlineResource) DeepCopy() *ActionInlineResource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActionMatchMode) DeepCopyInto(out *ActionMatchMode) {
+	*out = *in
+	if in.MatchMode != nil {
+		in, out := &in.MatchMode, &out.MatchMode
+		*out = new(MatchMode)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActionMatchMode.
+func (in *ActionMatchMode) DeepCopy() *ActionMatchMode {
+	if in == nil {
+		return nil
+	}
+	out := new(ActionMatchMode)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ActionObject) DeepCopyInto(out *ActionObject) {
 	*out = *in
@@ -311,6 +353,32 @@ func (in *ActionResourceRef) DeepCopy() *ActionResourceRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActionServerSide) DeepCopyInto(out *ActionServerSide) {
+	*out = *in
+	if in.ServerSide != nil {
+		in, out := &in.ServerSide, &out.ServerSide
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Force != nil {
+		in, out := &in.Force, &out.Force
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActionServerSide.
+func (in *ActionServerSide) DeepCopy() *ActionServerSide {
+	if in == nil {
+		return nil
+	}
+	out := new(ActionServerSide)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ActionTimeout) DeepCopyInto(out *ActionTimeout) {
 	*out = *in
@@ -341,6 +409,7 @@ func (in *Apply) DeepCopyInto(out *Apply) {
 	in.ActionExpectations.DeepCopyInto(&out.ActionExpectations)
 	in.ActionOutputs.DeepCopyInto(&out.ActionOutputs)
 	in.ActionResourceRef.DeepCopyInto(&out.ActionResourceRef)
+	in.ActionServerSide.DeepCopyInto(&out.ActionServerSide)
 	in.ActionTimeout.DeepCopyInto(&out.ActionTimeout)
 	return
 }
@@ -361,6 +430,8 @@ func (in *Assert) DeepCopyInto(out *Assert) {
 	in.ActionBindings.DeepCopyInto(&out.ActionBindings)
 	in.ActionCheckRef.DeepCopyInto(&out.ActionCheckRef)
 	in.ActionClusters.DeepCopyInto(&out.ActionClusters)
+	in.ActionConsistently.DeepCopyInto(&out.ActionConsistently)
+	in.ActionMatchMode.DeepCopyInto(&out.ActionMatchMode)
 	in.ActionTimeout.DeepCopyInto(&out.ActionTimeout)
 	return
 }
@@ -375,6 +446,32 @@ func (in *Assert) DeepCopy() *Assert {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Backoff) DeepCopyInto(out *Backoff) {
+	*out = *in
+	if in.Delay != nil {
+		in, out := &in.Delay, &out.Delay
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxDelay != nil {
+		in, out := &in.MaxDelay, &out.MaxDelay
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Backoff.
+func (in *Backoff) DeepCopy() *Backoff {
+	if in == nil {
+		return nil
+	}
+	out := new(Backoff)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Binding) DeepCopyInto(out *Binding) {
 	*out = *in
@@ -450,6 +547,13 @@ func (in *CatchFinally) DeepCopyInto(out *CatchFinally) {
 		*out = new(Sleep)
 		**out = **in
 	}
+	if in.Parallel != nil {
+		in, out := &in.Parallel, &out.Parallel
+		*out = make([]CatchFinally, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -735,6 +839,8 @@ func (in *Error) DeepCopyInto(out *Error) {
 	in.ActionBindings.DeepCopyInto(&out.ActionBindings)
 	in.ActionCheckRef.DeepCopyInto(&out.ActionCheckRef)
 	in.ActionClusters.DeepCopyInto(&out.ActionClusters)
+	in.ActionConsistently.DeepCopyInto(&out.ActionConsistently)
+	in.ActionMatchMode.DeepCopyInto(&out.ActionMatchMode)
 	in.ActionTimeout.DeepCopyInto(&out.ActionTimeout)
 	return
 }
@@ -769,6 +875,33 @@ func (in *Events) DeepCopy() *Events {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Exec) DeepCopyInto(out *Exec) {
+	*out = *in
+	in.ActionBindings.DeepCopyInto(&out.ActionBindings)
+	in.ActionCheck.DeepCopyInto(&out.ActionCheck)
+	in.ActionClusters.DeepCopyInto(&out.ActionClusters)
+	out.ActionObjectSelector = in.ActionObjectSelector
+	in.ActionOutputs.DeepCopyInto(&out.ActionOutputs)
+	in.ActionTimeout.DeepCopyInto(&out.ActionTimeout)
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Exec.
+func (in *Exec) DeepCopy() *Exec {
+	if in == nil {
+		return nil
+	}
+	out := new(Exec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Expectation) DeepCopyInto(out *Expectation) {
 	*out = *in
@@ -806,6 +939,33 @@ func (in *FileRef) DeepCopy() *FileRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ForEach) DeepCopyInto(out *ForEach) {
+	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = (*in).DeepCopy()
+	}
+	if in.Matrix != nil {
+		in, out := &in.Matrix, &out.Matrix
+		*out = make(map[string]Projection, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ForEach.
+func (in *ForEach) DeepCopy() *ForEach {
+	if in == nil {
+		return nil
+	}
+	out := new(ForEach)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Get) DeepCopyInto(out *Get) {
 	*out = *in
@@ -826,6 +986,123 @@ func (in *Get) DeepCopy() *Get {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTP) DeepCopyInto(out *HTTP) {
+	*out = *in
+	in.ActionBindings.DeepCopyInto(&out.ActionBindings)
+	in.ActionCheck.DeepCopyInto(&out.ActionCheck)
+	in.ActionClusters.DeepCopyInto(&out.ActionClusters)
+	in.ActionOutputs.DeepCopyInto(&out.ActionOutputs)
+	in.ActionTimeout.DeepCopyInto(&out.ActionTimeout)
+	if in.Target != nil {
+		in, out := &in.Target, &out.Target
+		*out = new(HTTPTarget)
+		**out = **in
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]Expression, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Body != nil {
+		in, out := &in.Body, &out.Body
+		*out = (*in).DeepCopy()
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(HTTPTLS)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTP.
+func (in *HTTP) DeepCopy() *HTTP {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPTLS) DeepCopyInto(out *HTTPTLS) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPTLS.
+func (in *HTTPTLS) DeepCopy() *HTTPTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPTarget) DeepCopyInto(out *HTTPTarget) {
+	*out = *in
+	out.ObjectName = in.ObjectName
+	out.ObjectType = in.ObjectType
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPTarget.
+func (in *HTTPTarget) DeepCopy() *HTTPTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JSONPatchOperation) DeepCopyInto(out *JSONPatchOperation) {
+	*out = *in
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JSONPatchOperation.
+func (in *JSONPatchOperation) DeepCopy() *JSONPatchOperation {
+	if in == nil {
+		return nil
+	}
+	out := new(JSONPatchOperation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MatchMode) DeepCopyInto(out *MatchMode) {
+	*out = *in
+	if in.Count != nil {
+		in, out := &in.Count, &out.Count
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MatchMode.
+func (in *MatchMode) DeepCopy() *MatchMode {
+	if in == nil {
+		return nil
+	}
+	out := new(MatchMode)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObjectName) DeepCopyInto(out *ObjectName) {
 	*out = *in
@@ -927,11 +1204,28 @@ func (in *Operation) DeepCopyInto(out *Operation) {
 		*out = new(Events)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Exec != nil {
+		in, out := &in.Exec, &out.Exec
+		*out = new(Exec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Get != nil {
 		in, out := &in.Get, &out.Get
 		*out = new(Get)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(HTTP)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Parallel != nil {
+		in, out := &in.Parallel, &out.Parallel
+		*out = make([]Operation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Patch != nil {
 		in, out := &in.Patch, &out.Patch
 		*out = new(Patch)
@@ -942,6 +1236,16 @@ func (in *Operation) DeepCopyInto(out *Operation) {
 		*out = new(PodLogs)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PortForward != nil {
+		in, out := &in.PortForward, &out.PortForward
+		*out = new(PortForward)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Process != nil {
+		in, out := &in.Process, &out.Process
+		*out = new(Process)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Proxy != nil {
 		in, out := &in.Proxy, &out.Proxy
 		*out = new(Proxy)
@@ -983,11 +1287,21 @@ func (in *Operation) DeepCopy() *Operation {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OperationBase) DeepCopyInto(out *OperationBase) {
 	*out = *in
+	if in.ForEach != nil {
+		in, out := &in.ForEach, &out.ForEach
+		*out = new(ForEach)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ContinueOnError != nil {
 		in, out := &in.ContinueOnError, &out.ContinueOnError
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(Retry)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Compiler != nil {
 		in, out := &in.Compiler, &out.Compiler
 		*out = new(policyv1alpha1.Compiler)
@@ -1037,6 +1351,13 @@ func (in *Patch) DeepCopyInto(out *Patch) {
 	in.ActionOutputs.DeepCopyInto(&out.ActionOutputs)
 	in.ActionResourceRef.DeepCopyInto(&out.ActionResourceRef)
 	in.ActionTimeout.DeepCopyInto(&out.ActionTimeout)
+	if in.JSONPatch != nil {
+		in, out := &in.JSONPatch, &out.JSONPatch
+		*out = make([]JSONPatchOperation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1074,6 +1395,48 @@ func (in *PodLogs) DeepCopy() *PodLogs {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PortForward) DeepCopyInto(out *PortForward) {
+	*out = *in
+	in.ActionBindings.DeepCopyInto(&out.ActionBindings)
+	in.ActionClusters.DeepCopyInto(&out.ActionClusters)
+	in.ActionOutputs.DeepCopyInto(&out.ActionOutputs)
+	in.ActionTimeout.DeepCopyInto(&out.ActionTimeout)
+	out.ObjectName = in.ObjectName
+	out.ObjectType = in.ObjectType
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PortForward.
+func (in *PortForward) DeepCopy() *PortForward {
+	if in == nil {
+		return nil
+	}
+	out := new(PortForward)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Process) DeepCopyInto(out *Process) {
+	*out = *in
+	in.ActionBindings.DeepCopyInto(&out.ActionBindings)
+	in.ActionCheck.DeepCopyInto(&out.ActionCheck)
+	in.ActionOutputs.DeepCopyInto(&out.ActionOutputs)
+	in.ActionTimeout.DeepCopyInto(&out.ActionTimeout)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Process.
+func (in *Process) DeepCopy() *Process {
+	if in == nil {
+		return nil
+	}
+	out := new(Process)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Proxy) DeepCopyInto(out *Proxy) {
 	*out = *in
@@ -1095,6 +1458,31 @@ func (in *Proxy) DeepCopy() *Proxy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Retry) DeepCopyInto(out *Retry) {
+	*out = *in
+	if in.Backoff != nil {
+		in, out := &in.Backoff, &out.Backoff
+		*out = new(Backoff)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RetryOn != nil {
+		in, out := &in.RetryOn, &out.RetryOn
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Retry.
+func (in *Retry) DeepCopy() *Retry {
+	if in == nil {
+		return nil
+	}
+	out := new(Retry)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Scenario) DeepCopyInto(out *Scenario) {
 	*out = *in
@@ -1312,6 +1700,11 @@ func (in *TestSpec) DeepCopyInto(out *TestSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.SkipDelete != nil {
 		in, out := &in.SkipDelete, &out.SkipDelete
 		*out = new(bool)
@@ -1405,6 +1798,11 @@ func (in *TestStep) DeepCopyInto(out *TestStep) {
 		*out = new(Use)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ForEach != nil {
+		in, out := &in.ForEach, &out.ForEach
+		*out = new(ForEach)
+		(*in).DeepCopyInto(*out)
+	}
 	in.TestStepSpec.DeepCopyInto(&out.TestStepSpec)
 	return
 }
@@ -1459,6 +1857,11 @@ func (in *TestStepSpec) DeepCopyInto(out *TestStepSpec) {
 		*out = new(policyv1alpha1.Compiler)
 		**out = **in
 	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(Retry)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Bindings != nil {
 		in, out := &in.Bindings, &out.Bindings
 		*out = make([]Binding, len(*in))
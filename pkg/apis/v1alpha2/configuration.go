@@ -1,6 +1,7 @@
 package v1alpha2
 
 import (
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -63,6 +64,15 @@ type ConfigurationSpec struct {
 	// +optional
 	Report *ReportOptions `json:"report,omitempty"`
 
+	// Setup defines operations executed once before all tests.
+	// Outputs produced by the try operations are available to all tests as bindings.
+	// +optional
+	Setup *v1alpha1.TestStepSpec `json:"setup,omitempty"`
+
+	// Teardown defines operations executed once after all tests.
+	// +optional
+	Teardown *v1alpha1.TestStepSpec `json:"teardown,omitempty"`
+
 	// Templating contains the templating config.
 	// +optional
 	// +kubebuilder:default:={}
@@ -76,6 +76,11 @@ type ExecutionOptions struct {
 	// ForceTerminationGracePeriod forces the termination grace period on pods, statefulsets, daemonsets and deployments.
 	// +optional
 	ForceTerminationGracePeriod *metav1.Duration `json:"forceTerminationGracePeriod,omitempty"`
+
+	// Watch determines whether assert and error operations are evaluated when resources change, using shared informers.
+	// Polling is used when resources can't be watched.
+	// +optional
+	Watch bool `json:"watch,omitempty"`
 }
 
 // NamespaceOptions contains the configuration used to allocate a namespace for each test.
@@ -107,13 +112,14 @@ const (
 	JUnitTestFormat      ReportFormatType = "JUNIT-TEST"
 	JUnitStepFormat      ReportFormatType = "JUNIT-STEP"
 	JUnitOperationFormat ReportFormatType = "JUNIT-OPERATION"
+	HTMLFormat           ReportFormatType = "HTML"
 )
 
 // ReportOptions contains the configuration used for reporting.
 type ReportOptions struct {
-	// ReportFormat determines test report format (JSON, XML, JUNIT-TEST, JUNIT-STEP, JUNIT-OPERATION).
+	// ReportFormat determines test report format (JSON, XML, JUNIT-TEST, JUNIT-STEP, JUNIT-OPERATION, HTML).
 	// +optional
-	// +kubebuilder:validation:Enum:=JSON;XML;JUNIT-TEST;JUNIT-STEP;JUNIT-OPERATION
+	// +kubebuilder:validation:Enum:=JSON;XML;JUNIT-TEST;JUNIT-STEP;JUNIT-OPERATION;HTML
 	// +kubebuilder:default:="JSON"
 	Format ReportFormatType `json:"format,omitempty"`
 
@@ -125,6 +131,11 @@ type ReportOptions struct {
 	// +optional
 	// +kubebuilder:default:="chainsaw-report"
 	Name string `json:"name,omitempty"`
+
+	// Diagnostics determines whether diagnostics are collected when a test fails.
+	// Diagnostics are written in a per test directory, under the report path.
+	// +optional
+	Diagnostics bool `json:"diagnostics,omitempty"`
 }
 
 // TemplatingOptions contains the templating configuration.
@@ -97,6 +97,16 @@ func (in *ConfigurationSpec) DeepCopyInto(out *ConfigurationSpec) {
 		*out = new(ReportOptions)
 		**out = **in
 	}
+	if in.Setup != nil {
+		in, out := &in.Setup, &out.Setup
+		*out = new(v1alpha1.TestStepSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Teardown != nil {
+		in, out := &in.Teardown, &out.Teardown
+		*out = new(v1alpha1.TestStepSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Templating.DeepCopyInto(&out.Templating)
 	out.Timeouts = in.Timeouts
 	return
@@ -2,6 +2,8 @@ package cleaner
 
 import (
 	"context"
+	"slices"
+	"sync"
 	"time"
 
 	"github.com/kyverno/chainsaw/pkg/client"
@@ -11,18 +13,24 @@ import (
 )
 
 type cleanupEntry struct {
-	client client.Client
-	object client.Object
+	client    client.Client
+	object    client.Object
+	operation model.OperationType
+	fn        func(context.Context) error
 }
 
 type CleanerCollector interface {
 	Empty() bool
 	Add(client.Client, client.Object)
+	// AddFunc registers a function releasing something other than a cluster object (a port forward for example).
+	AddFunc(model.OperationType, func(context.Context) error)
 }
 
 type Cleaner interface {
 	CleanerCollector
 	Run(ctx context.Context, stepReport *model.StepReport) []error
+	// Release runs and removes the functions registered for the given operation types, other entries are kept.
+	Release(ctx context.Context, stepReport *model.StepReport, operations ...model.OperationType) []error
 }
 
 func New(timeout time.Duration, waitForDeletion bool, delay *time.Duration, propagation metav1.DeletionPropagation) Cleaner {
@@ -39,17 +47,32 @@ type cleaner struct {
 	timeout         time.Duration
 	propagation     metav1.DeletionPropagation
 	waitForDeletion bool
-	entries         []cleanupEntry
+	// lock protects entries, operations running concurrently can share the same cleaner
+	lock    sync.Mutex
+	entries []cleanupEntry
 }
 
 func (c *cleaner) Add(client client.Client, object client.Object) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
 	c.entries = append(c.entries, cleanupEntry{
 		client: client,
 		object: object,
 	})
 }
 
+func (c *cleaner) AddFunc(operation model.OperationType, fn func(context.Context) error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.entries = append(c.entries, cleanupEntry{
+		operation: operation,
+		fn:        fn,
+	})
+}
+
 func (c *cleaner) Empty() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
 	return len(c.entries) == 0
 }
 
@@ -57,13 +80,39 @@ func (c *cleaner) Run(ctx context.Context, stepReport *model.StepReport) []error
 	if c.delay != nil {
 		time.Sleep(*c.delay)
 	}
+	c.lock.Lock()
+	entries := slices.Clone(c.entries)
+	c.lock.Unlock()
+	return c.run(ctx, stepReport, entries...)
+}
+
+func (c *cleaner) Release(ctx context.Context, stepReport *model.StepReport, operations ...model.OperationType) []error {
+	c.lock.Lock()
+	var released, kept []cleanupEntry
+	for _, entry := range c.entries {
+		if entry.fn != nil && slices.Contains(operations, entry.operation) {
+			released = append(released, entry)
+		} else {
+			kept = append(kept, entry)
+		}
+	}
+	c.entries = kept
+	c.lock.Unlock()
+	return c.run(ctx, stepReport, released...)
+}
+
+// run processes entries in reverse order.
+func (c *cleaner) run(ctx context.Context, stepReport *model.StepReport, entries ...cleanupEntry) []error {
 	var errs []error
-	for i := len(c.entries) - 1; i >= 0; i-- {
+	for i := len(entries) - 1; i >= 0; i-- {
 		report := model.OperationReport{
 			Type:      model.OperationTypeDelete,
 			StartTime: time.Now(),
 		}
-		if report.Err = c.delete(ctx, c.entries[i]); report.Err != nil {
+		if entries[i].fn != nil {
+			report.Type = entries[i].operation
+		}
+		if report.Err = c.delete(ctx, entries[i]); report.Err != nil {
 			errs = append(errs, report.Err)
 		}
 		report.EndTime = time.Now()
@@ -80,6 +129,9 @@ func (c *cleaner) delete(ctx context.Context, entry cleanupEntry) error {
 		defer cancel()
 		ctx = _ctx
 	}
+	if entry.fn != nil {
+		return entry.fn(ctx)
+	}
 	if err := entry.client.Delete(ctx, entry.object, client.PropagationPolicy(c.propagation)); err != nil {
 		if !kerrors.IsNotFound(err) {
 			return err
@@ -3,6 +3,7 @@ package cleaner
 import (
 	"context"
 	"errors"
+	"sync"
 	"testing"
 	"time"
 
@@ -126,6 +127,62 @@ func Test_cleaner_Add(t *testing.T) {
 	}
 }
 
+func Test_cleaner_Run_report(t *testing.T) {
+	c := &cleaner{}
+	c.Add(&tclient.FakeClient{
+		DeleteFn: func(ctx context.Context, call int, obj client.Object, opts ...client.DeleteOption) error {
+			return nil
+		},
+	}, &corev1.Namespace{})
+	c.AddFunc(model.OperationTypePortForward, func(context.Context) error { return nil })
+	report := &model.StepReport{}
+	errs := c.Run(context.TODO(), report)
+	assert.Nil(t, errs)
+	assert.Len(t, report.Operations, 2)
+	// entries run in reverse order
+	assert.Equal(t, model.OperationTypePortForward, report.Operations[0].Type)
+	assert.Equal(t, model.OperationTypeDelete, report.Operations[1].Type)
+}
+
+func Test_cleaner_AddFunc(t *testing.T) {
+	c := &cleaner{}
+	c.AddFunc(model.OperationTypePortForward, func(context.Context) error { return nil })
+	assert.Len(t, c.entries, 1)
+	assert.Equal(t, model.OperationTypePortForward, c.entries[0].operation)
+	assert.NotNil(t, c.entries[0].fn)
+	assert.Nil(t, c.entries[0].client)
+	assert.Nil(t, c.entries[0].object)
+}
+
+func Test_cleaner_concurrent(t *testing.T) {
+	c := &cleaner{}
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Go(func() {
+			c.AddFunc(model.OperationTypeCommand, func(context.Context) error { return nil })
+			c.Add(nil, &corev1.Namespace{})
+		})
+	}
+	wg.Wait()
+	assert.False(t, c.Empty())
+	assert.Len(t, c.entries, 20)
+}
+
+func Test_cleaner_Run_concurrent(t *testing.T) {
+	c := &cleaner{}
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Go(func() {
+			c.AddFunc(model.OperationTypeCommand, func(context.Context) error { return nil })
+		})
+		wg.Go(func() {
+			assert.Nil(t, c.Run(context.TODO(), nil))
+		})
+	}
+	wg.Wait()
+	assert.Len(t, c.entries, 10)
+}
+
 func Test_cleaner_Run(t *testing.T) {
 	obj := &corev1.Namespace{
 		ObjectMeta: metav1.ObjectMeta{
@@ -202,6 +259,24 @@ func Test_cleaner_Run(t *testing.T) {
 			},
 		}},
 		want: []error{context.DeadlineExceeded},
+	}, {
+		name: "func",
+		entries: []cleanupEntry{{
+			operation: model.OperationTypePortForward,
+			fn: func(context.Context) error {
+				return nil
+			},
+		}},
+		want: nil,
+	}, {
+		name: "func error",
+		entries: []cleanupEntry{{
+			operation: model.OperationTypePortForward,
+			fn: func(context.Context) error {
+				return errors.New("dummy")
+			},
+		}},
+		want: []error{errors.New("dummy")},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -216,3 +291,32 @@ func Test_cleaner_Run(t *testing.T) {
 		})
 	}
 }
+
+func Test_cleaner_Release(t *testing.T) {
+	c := &cleaner{}
+	var released []string
+	c.Add(nil, &corev1.Namespace{})
+	c.AddFunc(model.OperationTypeCommand, func(context.Context) error {
+		released = append(released, "command")
+		return nil
+	})
+	c.AddFunc(model.OperationTypePortForward, func(context.Context) error {
+		released = append(released, "port forward")
+		return nil
+	})
+	c.AddFunc(model.OperationTypeScript, func(context.Context) error {
+		released = append(released, "script")
+		return errors.New("failed")
+	})
+	report := &model.StepReport{}
+	errs := c.Release(context.TODO(), report, model.OperationTypeCommand, model.OperationTypeScript)
+	assert.Len(t, errs, 1)
+	// entries run in reverse order
+	assert.Equal(t, []string{"script", "command"}, released)
+	assert.Len(t, report.Operations, 2)
+	assert.Equal(t, model.OperationTypeScript, report.Operations[0].Type)
+	assert.Equal(t, model.OperationTypeCommand, report.Operations[1].Type)
+	// other entries are kept
+	assert.Len(t, c.entries, 2)
+	assert.Equal(t, model.OperationTypePortForward, c.entries[1].operation)
+}
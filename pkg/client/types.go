@@ -5,19 +5,25 @@ import (
 )
 
 type (
-	Object            = ctrlclient.Object
-	ObjectKey         = ctrlclient.ObjectKey
-	ObjectList        = ctrlclient.ObjectList
-	Patch             = ctrlclient.Patch
-	GetOption         = ctrlclient.GetOption
-	ListOption        = ctrlclient.ListOption
-	CreateOption      = ctrlclient.CreateOption
-	UpdateOption      = ctrlclient.UpdateOption
-	DeleteOption      = ctrlclient.DeleteOption
-	PatchOption       = ctrlclient.PatchOption
-	InNamespace       = ctrlclient.InNamespace
-	PropagationPolicy = ctrlclient.PropagationPolicy
-	MatchingLabels    = ctrlclient.MatchingLabels
+	Object                 = ctrlclient.Object
+	ObjectKey              = ctrlclient.ObjectKey
+	ObjectList             = ctrlclient.ObjectList
+	Patch                  = ctrlclient.Patch
+	GetOption              = ctrlclient.GetOption
+	ListOption             = ctrlclient.ListOption
+	CreateOption           = ctrlclient.CreateOption
+	UpdateOption           = ctrlclient.UpdateOption
+	DeleteOption           = ctrlclient.DeleteOption
+	PatchOption            = ctrlclient.PatchOption
+	InNamespace            = ctrlclient.InNamespace
+	PropagationPolicy      = ctrlclient.PropagationPolicy
+	MatchingLabels         = ctrlclient.MatchingLabels
+	MatchingLabelsSelector = ctrlclient.MatchingLabelsSelector
+	MatchingFields         = ctrlclient.MatchingFields
+	FieldOwner             = ctrlclient.FieldOwner
 )
 
-var RawPatch = ctrlclient.RawPatch
+var (
+	RawPatch       = ctrlclient.RawPatch
+	ForceOwnership = ctrlclient.ForceOwnership
+)
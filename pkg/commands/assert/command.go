@@ -144,7 +144,7 @@ func assert(opts options, client client.Client, resource unstructured.Unstructur
 		defer cancel()
 		ctx = _ctx
 	}
-	op := opassert.New(apis.DefaultCompilers, client, resource, namespacer, false)
+	op := opassert.New(apis.DefaultCompilers, client, resource, namespacer, false, nil, 0, nil)
 	_, err := op.Exec(ctx, nil)
 	return err
 }
@@ -0,0 +1,260 @@
+package eval
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/kyverno/chainsaw/pkg/expressions"
+	"github.com/kyverno/chainsaw/pkg/loaders/config"
+	"github.com/kyverno/chainsaw/pkg/loaders/resource"
+	"github.com/kyverno/chainsaw/pkg/loaders/values"
+	enginecontext "github.com/kyverno/chainsaw/pkg/runner/context"
+	restutils "github.com/kyverno/chainsaw/pkg/utils/rest"
+	"github.com/kyverno/kyverno-json/pkg/core/compilers"
+	"github.com/kyverno/kyverno-json/pkg/core/expression"
+	"github.com/spf13/cobra"
+	"helm.sh/helm/v4/pkg/strvals"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/yaml"
+)
+
+type options struct {
+	compiler            string
+	namespace           string
+	inputPath           string
+	output              string
+	values              []string
+	set                 []string
+	setString           []string
+	interactive         bool
+	useCluster          bool
+	kubeConfigOverrides clientcmd.ConfigOverrides
+}
+
+func Command() *cobra.Command {
+	var opts options
+	cmd := &cobra.Command{
+		Use:   "eval [flags] [EXPRESSION]",
+		Short: "Evaluate an expression",
+		Long: `Use chainsaw eval to evaluate a JMESPath or CEL expression the same way Chainsaw evaluates expressions when running tests.
+
+The expression can be given as a raw statement or wrapped in parentheses like in tests, (cel;...) and (jp;...) select the compiler.
+Bindings are built from values (available as $values), the namespace (available as $namespace) and, when using a cluster, $client and $config.
+The input resource, if any, is available as @ with JMESPath and object with CEL.
+
+When no expression is given, or with --interactive, expressions are read line by line from standard input.
+In interactive mode, the following commands are supported:
+  :compiler <jp|cel>        change the default compiler
+  :let <name> <expression>  evaluate an expression and register the result as $name
+  :quit                     exit`,
+		Example: `  # evaluate a JMESPath expression against an input resource
+  chainsaw eval --input deployment.yaml 'spec.replicas'
+
+  # evaluate a CEL expression using values
+  chainsaw eval --compiler cel --set foo=bar 'bindings.resolve("values").foo'
+
+  # call a function querying the cluster
+  chainsaw eval --use-cluster "x_k8s_list(\$client, 'v1', 'Namespace')"`,
+		Args:         cobra.MaximumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runE(opts, cmd, args)
+		},
+	}
+	cmd.Flags().StringVar(&opts.compiler, "compiler", compilers.CompilerJP, "Default compiler to use (jp or cel)")
+	cmd.Flags().StringVar(&opts.namespace, "namespace", "default", "Namespace available as $namespace")
+	cmd.Flags().StringVarP(&opts.inputPath, "input", "i", "", "Path to the file containing the input resource")
+	cmd.Flags().StringVarP(&opts.output, "output", "o", "yaml", "Output format (yaml or json)")
+	cmd.Flags().StringSliceVar(&opts.values, "values", nil, "Values available as $values")
+	cmd.Flags().StringArrayVar(&opts.set, "set", nil, "set values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
+	cmd.Flags().StringArrayVar(&opts.setString, "set-string", nil, "set STRING values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
+	cmd.Flags().BoolVar(&opts.interactive, "interactive", false, "Read expressions from standard input")
+	cmd.Flags().BoolVar(&opts.useCluster, "use-cluster", false, "Connect to the cluster and register $client and $config")
+	clientcmd.BindOverrideFlags(&opts.kubeConfigOverrides, cmd.Flags(), clientcmd.RecommendedConfigOverrideFlags("kube-"))
+	return cmd
+}
+
+func runE(opts options, cmd *cobra.Command, args []string) error {
+	if opts.output != "yaml" && opts.output != "json" {
+		return fmt.Errorf("invalid output format: %s", opts.output)
+	}
+	if opts.compiler != compilers.CompilerJP && opts.compiler != compilers.CompilerCEL {
+		return fmt.Errorf("invalid compiler: %s", opts.compiler)
+	}
+	if len(args) == 0 && !opts.interactive {
+		opts.interactive = true
+	} else if len(args) != 0 && opts.interactive {
+		return errors.New("an expression can not be provided in interactive mode")
+	}
+	e, err := newEvaluator(opts)
+	if err != nil {
+		return err
+	}
+	if opts.interactive {
+		return e.repl(cmd.InOrStdin(), cmd.OutOrStdout())
+	}
+	result, err := e.eval(args[0])
+	if err != nil {
+		return err
+	}
+	return e.print(cmd.OutOrStdout(), result)
+}
+
+type evaluator struct {
+	tc     enginecontext.TestContext
+	input  any
+	output string
+}
+
+func newEvaluator(opts options) (*evaluator, error) {
+	configuration, err := config.DefaultConfiguration()
+	if err != nil {
+		return nil, err
+	}
+	values, err := values.Load(opts.values...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load values (%w)", err)
+	}
+	for _, s := range opts.set {
+		if err := strvals.ParseInto(s, values); err != nil {
+			return nil, fmt.Errorf("failed parsing --set data: %w", err)
+		}
+	}
+	for _, s := range opts.setString {
+		if err := strvals.ParseIntoString(s, values); err != nil {
+			return nil, fmt.Errorf("failed parsing --set-string data: %w", err)
+		}
+	}
+	var input any
+	if opts.inputPath != "" {
+		resources, err := resource.Load(opts.inputPath, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load file '%s': %w", opts.inputPath, err)
+		}
+		// a single resource is used as is, several resources are made available as an array
+		if len(resources) == 1 {
+			input = resources[0].UnstructuredContent()
+		} else {
+			var items []any
+			for _, item := range resources {
+				items = append(items, item.UnstructuredContent())
+			}
+			input = items
+		}
+	}
+	var restConfig *rest.Config
+	if opts.useCluster {
+		cfg, err := restutils.DefaultConfig(opts.kubeConfigOverrides)
+		if err != nil {
+			return nil, err
+		}
+		restConfig = cfg
+	}
+	tc, err := enginecontext.InitContext(configuration.Spec, restConfig, values)
+	if err != nil {
+		return nil, err
+	}
+	tc = tc.WithDefaultCompiler(opts.compiler)
+	tc = tc.WithBinding("namespace", opts.namespace)
+	return &evaluator{
+		tc:     tc,
+		input:  input,
+		output: opts.output,
+	}, nil
+}
+
+func (e *evaluator) eval(in string) (any, error) {
+	statement, engine := in, expression.CompilerDefault
+	// expressions can be written the same way they are written in tests
+	if parsed := expressions.Parse(context.TODO(), in); parsed != nil && parsed.Engine != "" {
+		statement, engine = parsed.Statement, parsed.Engine
+	}
+	compiler := e.tc.Compilers().Compiler(engine)
+	if compiler == nil {
+		return nil, fmt.Errorf("unknown compiler: %s", engine)
+	}
+	return compilers.Execute(statement, e.input, e.tc.Bindings(), compiler)
+}
+
+func (e *evaluator) print(out io.Writer, result any) error {
+	var content []byte
+	var err error
+	if e.output == "json" {
+		content, err = json.MarshalIndent(result, "", "  ")
+		content = append(content, '\n')
+	} else {
+		content, err = yaml.Marshal(result)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to marshal result (%w)", err)
+	}
+	_, err = out.Write(content)
+	return err
+}
+
+func (e *evaluator) repl(in io.Reader, out io.Writer) error {
+	scanner := bufio.NewScanner(in)
+	for {
+		fmt.Fprint(out, "> ")
+		if !scanner.Scan() {
+			fmt.Fprintln(out)
+			return scanner.Err()
+		}
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if command, ok := strings.CutPrefix(line, ":"); ok {
+			quit, err := e.command(command)
+			if err != nil {
+				fmt.Fprintln(out, "Error:", err)
+			}
+			if quit {
+				return nil
+			}
+			continue
+		}
+		result, err := e.eval(line)
+		if err == nil {
+			err = e.print(out, result)
+		}
+		if err != nil {
+			fmt.Fprintln(out, "Error:", err)
+		}
+	}
+}
+
+func (e *evaluator) command(command string) (bool, error) {
+	name, arg, _ := strings.Cut(command, " ")
+	arg = strings.TrimSpace(arg)
+	switch name {
+	case "q", "quit":
+		return true, nil
+	case "compiler":
+		if arg != compilers.CompilerJP && arg != compilers.CompilerCEL {
+			return false, fmt.Errorf("invalid compiler: %s", arg)
+		}
+		e.tc = e.tc.WithDefaultCompiler(arg)
+		return false, nil
+	case "let":
+		name, expression, _ := strings.Cut(arg, " ")
+		name = strings.TrimPrefix(name, "$")
+		if name == "" || strings.TrimSpace(expression) == "" {
+			return false, errors.New("usage: :let <name> <expression>")
+		}
+		result, err := e.eval(strings.TrimSpace(expression))
+		if err != nil {
+			return false, err
+		}
+		e.tc = e.tc.WithBinding(name, result)
+		return false, nil
+	default:
+		return false, fmt.Errorf("unknown command: %s", name)
+	}
+}
@@ -0,0 +1,114 @@
+package eval
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommand(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	cmd.SetArgs([]string{"--help"})
+	out := bytes.NewBufferString("")
+	cmd.SetOut(out)
+	assert.NoError(t, cmd.Execute())
+	expected, err := os.ReadFile(filepath.Join("..", "..", "..", "testdata", "commands", "eval", "help.txt"))
+	assert.NoError(t, err)
+	assert.Equal(t, string(expected), out.String())
+}
+
+func TestCommand_eval(t *testing.T) {
+	basePath := filepath.Join("..", "..", "..", "testdata", "commands", "eval")
+	tests := []struct {
+		name    string
+		args    []string
+		want    string
+		wantErr bool
+	}{{
+		name: "input",
+		args: []string{"--input", filepath.Join(basePath, "input.yaml"), "metadata.name"},
+		want: "quick-start\n",
+	}, {
+		name: "json output",
+		args: []string{"--input", filepath.Join(basePath, "input.yaml"), "--output", "json", "data"},
+		want: "{\n  \"foo\": \"bar\"\n}\n",
+	}, {
+		name: "values",
+		args: []string{"--values", filepath.Join(basePath, "values.yaml"), "--set", "foo=42", "--set-string", "bar=42", "$values"},
+		want: "bar: \"42\"\nfoo: 42\nreplicas: 3\n",
+	}, {
+		name: "namespace",
+		args: []string{"--namespace", "foo", "$namespace"},
+		want: "foo\n",
+	}, {
+		name: "chainsaw functions",
+		args: []string{"trim_space(' foo ')"},
+		want: "foo\n",
+	}, {
+		name: "cel",
+		args: []string{"--compiler", "cel", "--input", filepath.Join(basePath, "input.yaml"), "object.data.foo + bindings.resolve('namespace')"},
+		want: "bardefault\n",
+	}, {
+		name: "compiler from expression",
+		args: []string{"(cel;[1, 2].map(x, x * 2))"},
+		want: "- 2\n- 4\n",
+	}, {
+		name:    "invalid expression",
+		args:    []string{"foo("},
+		wantErr: true,
+	}, {
+		name:    "invalid compiler",
+		args:    []string{"--compiler", "foo", "@"},
+		wantErr: true,
+	}, {
+		name:    "invalid output",
+		args:    []string{"--output", "foo", "@"},
+		wantErr: true,
+	}, {
+		name:    "missing input",
+		args:    []string{"--input", filepath.Join(basePath, "missing.yaml"), "@"},
+		wantErr: true,
+	}, {
+		name:    "expression in interactive mode",
+		args:    []string{"--interactive", "@"},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := Command()
+			cmd.SetArgs(tt.args)
+			out := bytes.NewBufferString("")
+			cmd.SetOut(out)
+			cmd.SetErr(bytes.NewBufferString(""))
+			err := cmd.Execute()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, out.String())
+			}
+		})
+	}
+}
+
+func TestCommand_interactive(t *testing.T) {
+	cmd := Command()
+	cmd.SetArgs([]string{"--input", filepath.Join("..", "..", "..", "testdata", "commands", "eval", "input.yaml")})
+	cmd.SetIn(bytes.NewBufferString("metadata.name\n\n:let foo data.foo\n$foo\n:compiler cel\nbindings.resolve('foo').size()\n:compiler foo\n:foo\nfoo(\n:quit\nignored\n"))
+	out := bytes.NewBufferString("")
+	cmd.SetOut(out)
+	assert.NoError(t, cmd.Execute())
+	assert.Equal(t, `> quick-start
+> > > bar
+> > 3
+> Error: invalid compiler: foo
+> Error: unknown command: foo
+> Error: ERROR: <input>:1:5: Syntax error: mismatched input '<EOF>' expecting {'[', '{', '(', ')', '.', '-', '!', 'true', 'false', 'null', NUM_FLOAT, NUM_INT, NUM_UINT, STRING, BYTES, IDENTIFIER}
+ | foo(
+ | ....^
+> `, out.String())
+}
@@ -0,0 +1,102 @@
+package format
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kyverno/chainsaw/pkg/formatter"
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	var check bool
+	cmd := &cobra.Command{
+		Use:          "fmt [flags]... [paths]...",
+		Short:        "Format tests, step templates and configuration files",
+		Long:         "Use chainsaw fmt to rewrite tests, step templates and configuration files in a canonical layout. Folders are processed recursively.",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				args = []string{"."}
+			}
+			return execute(cmd.OutOrStdout(), check, args...)
+		},
+	}
+	cmd.Flags().BoolVar(&check, "check", false, "If set, files are not written and the command fails when files are not formatted")
+	return cmd
+}
+
+func execute(out io.Writer, check bool, paths ...string) error {
+	files, err := discoverFiles(paths...)
+	if err != nil {
+		return err
+	}
+	unformatted := 0
+	for _, file := range files {
+		content, err := os.ReadFile(filepath.Clean(file))
+		if err != nil {
+			return err
+		}
+		formatted, err := formatter.Format(content)
+		if err != nil {
+			return fmt.Errorf("failed to format %s: %w", file, err)
+		}
+		if bytes.Equal(content, formatted) {
+			continue
+		}
+		unformatted++
+		fmt.Fprintln(out, file)
+		if !check {
+			info, err := os.Stat(file)
+			if err != nil {
+				return err
+			}
+			if err := os.WriteFile(file, formatted, info.Mode().Perm()); err != nil {
+				return err
+			}
+		}
+	}
+	if check && unformatted != 0 {
+		return errors.New("some files are not formatted")
+	}
+	return nil
+}
+
+// discoverFiles returns the yaml files designated by the paths, folders are walked recursively and hidden folders are skipped.
+func discoverFiles(paths ...string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		if err := filepath.WalkDir(path, func(file string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() {
+				if file != path && strings.HasPrefix(entry.Name(), ".") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if ext := filepath.Ext(file); ext == ".yaml" || ext == ".yml" {
+				files = append(files, file)
+			}
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,74 @@
+package format
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	unformatted = `kind: Test
+apiVersion: chainsaw.kyverno.io/v1alpha1
+metadata: {name: foo}
+`
+	formatted = `apiVersion: chainsaw.kyverno.io/v1alpha1
+kind: Test
+metadata:
+  name: foo
+`
+)
+
+func TestCommand(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	cmd.SetArgs([]string{"--help"})
+	out := bytes.NewBufferString("")
+	cmd.SetOut(out)
+	assert.NoError(t, cmd.Execute())
+	expected, err := os.ReadFile(filepath.Join("..", "..", "..", "testdata", "commands", "fmt", "help.txt"))
+	assert.NoError(t, err)
+	assert.Equal(t, string(expected), out.String())
+}
+
+func Test_execute(t *testing.T) {
+	dir := t.TempDir()
+	test := filepath.Join(dir, "tests", "chainsaw-test.yaml")
+	configMap := filepath.Join(dir, "tests", "configmap.yaml")
+	hidden := filepath.Join(dir, ".hidden", "chainsaw-test.yaml")
+	for _, file := range []string{test, hidden} {
+		require.NoError(t, os.MkdirAll(filepath.Dir(file), 0o755))
+		require.NoError(t, os.WriteFile(file, []byte(unformatted), 0o600))
+	}
+	require.NoError(t, os.WriteFile(configMap, []byte("kind: ConfigMap\napiVersion: v1\n"), 0o600))
+	read := func(file string) string {
+		content, err := os.ReadFile(file)
+		require.NoError(t, err)
+		return string(content)
+	}
+	// check mode reports the unformatted files without writing them
+	var out bytes.Buffer
+	assert.Error(t, execute(&out, true, dir))
+	assert.Equal(t, test+"\n", out.String())
+	assert.Equal(t, unformatted, read(test))
+	// files are written
+	out.Reset()
+	assert.NoError(t, execute(&out, false, dir))
+	assert.Equal(t, test+"\n", out.String())
+	assert.Equal(t, formatted, read(test))
+	assert.Equal(t, unformatted, read(hidden))
+	assert.Equal(t, "kind: ConfigMap\napiVersion: v1\n", read(configMap))
+	// formatted files pass the check
+	out.Reset()
+	assert.NoError(t, execute(&out, true, dir))
+	assert.Empty(t, out.String())
+	// files can be passed explicitly
+	out.Reset()
+	assert.Error(t, execute(&out, true, hidden))
+	assert.Equal(t, hidden+"\n", out.String())
+	// missing paths are reported
+	assert.Error(t, execute(&out, true, filepath.Join(dir, "missing")))
+}
@@ -7,13 +7,22 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/kyverno/chainsaw/pkg/linter"
+	restutils "github.com/kyverno/chainsaw/pkg/utils/rest"
+	"github.com/kyverno/chainsaw/pkg/validation"
 	"github.com/spf13/cobra"
 	"github.com/xeipuuv/gojsonschema"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
 )
 
 func Command() *cobra.Command {
 	var fileFlag string
+	var outputFormat string
+	var validateResources bool
+	var crdDirs []string
+	var useCluster bool
 	cmd := &cobra.Command{
 		Use:       "lint [test|configuration]",
 		Short:     "Lint a file or read from standard input",
@@ -21,69 +30,164 @@ func Command() *cobra.Command {
 		Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
 		ValidArgs: []string{"test", "configuration"},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			var validator *validation.Validator
+			if validateResources {
+				var config *rest.Config
+				if useCluster {
+					cfg, err := restutils.DefaultConfig(clientcmd.ConfigOverrides{})
+					if err != nil {
+						return err
+					}
+					config = cfg
+				}
+				client, err := validation.NewClient(config, crdDirs...)
+				if err != nil {
+					return err
+				}
+				validator = validation.New(client)
+			}
 			if fileFlag == "-" {
 				input, err := io.ReadAll(cmd.InOrStdin())
 				if err != nil {
 					return err
 				}
-				return lintInput(input, args[0], "", cmd.OutOrStdout())
+				return lintInput(input, args[0], "", "", linter.Format(outputFormat), validator, cmd.OutOrStdout())
 			} else if fileFlag != "" {
 				input, err := os.ReadFile(fileFlag)
 				if err != nil {
 					return err
 				}
-				return lintInput(input, args[0], filepath.Ext(fileFlag), cmd.OutOrStdout())
+				return lintInput(input, args[0], filepath.Ext(fileFlag), fileFlag, linter.Format(outputFormat), validator, cmd.OutOrStdout())
 			} else {
 				return fmt.Errorf("no file or standard input specified")
 			}
 		},
 	}
 	cmd.Flags().StringVarP(&fileFlag, "file", "f", "", "Specify the file to lint or '-' for standard input")
+	cmd.Flags().StringVarP(&outputFormat, "output-format", "o", string(linter.FormatText), "Output format (text, json, sarif)")
+	cmd.Flags().BoolVar(&validateResources, "validate-resources", false, "Validate resources referenced by tests against OpenAPI schemas")
+	cmd.Flags().StringSliceVar(&crdDirs, "crd-dir", nil, "Directories containing CRD files used to validate resources")
+	cmd.Flags().BoolVar(&useCluster, "use-cluster", false, "Discover OpenAPI schemas from the cluster instead of using embedded Kubernetes schemas")
 	return cmd
 }
 
-func lintInput(input []byte, kind string, format string, writer io.Writer) error {
+// lintInput validates the input against the JSON schema and runs the semantic analysis of tests.
+// The file is empty when reading from standard input, referenced files and step templates are not checked in this case.
+// Resources referenced by tests are validated against their OpenAPI schema when a validator is provided.
+func lintInput(input []byte, kind string, format string, file string, outputFormat linter.Format, validator *validation.Validator, writer io.Writer) error {
+	switch outputFormat {
+	case "", linter.FormatText:
+	case linter.FormatJSON, linter.FormatSARIF:
+		return lintInputMachine(input, kind, format, file, outputFormat, validator, writer)
+	default:
+		return fmt.Errorf("unsupported output format: %s", outputFormat)
+	}
 	fmt.Fprintln(writer, "Processing input...")
-	if err := lintSchema(input, kind, format, writer); err != nil {
+	schemaErrors, err := lintSchema(input, kind, format)
+	if err != nil {
+		return err
+	}
+	if len(schemaErrors) != 0 {
+		fmt.Fprintln(writer, "The schema is not valid. See errors:")
+		for _, desc := range schemaErrors {
+			fmt.Fprintf(writer, "- %s\n", desc)
+		}
+		return fmt.Errorf("document is not valid")
+	}
+	issues, err := lintSemantic(input, kind, file, validator)
+	if err != nil {
 		return err
 	}
+	if len(issues) != 0 {
+		fmt.Fprintln(writer, "Semantic issues found:")
+		if err := linter.Write(writer, linter.FormatText, issues); err != nil {
+			return err
+		}
+	}
+	if linter.HasErrors(issues) {
+		return fmt.Errorf("document is not valid")
+	}
 	fmt.Fprintln(writer, "The document is valid")
 	return nil
 }
 
-func lintSchema(input []byte, kind string, format string, writer io.Writer) error {
-	processor, err := getProcessor(format, input)
+func lintInputMachine(input []byte, kind string, format string, file string, outputFormat linter.Format, validator *validation.Validator, writer io.Writer) error {
+	schemaErrors, err := lintSchema(input, kind, format)
 	if err != nil {
 		return err
 	}
+	var issues []linter.Issue
+	for _, desc := range schemaErrors {
+		line, column := linter.Locate(input, desc.Field())
+		issues = append(issues, linter.Issue{
+			Rule:     linter.RuleSchema,
+			Severity: linter.SeverityError,
+			Message:  desc.String(),
+			File:     issueFile(file),
+			Line:     line,
+			Column:   column,
+		})
+	}
+	if len(schemaErrors) == 0 {
+		semanticIssues, err := lintSemantic(input, kind, file, validator)
+		if err != nil {
+			return err
+		}
+		issues = append(issues, semanticIssues...)
+	}
+	if err := linter.Write(writer, outputFormat, issues); err != nil {
+		return err
+	}
+	if linter.HasErrors(issues) {
+		return fmt.Errorf("document is not valid")
+	}
+	return nil
+}
+
+func lintSemantic(input []byte, kind string, file string, validator *validation.Validator) ([]linter.Issue, error) {
+	if kind != "test" {
+		return nil, nil
+	}
+	var basePath string
+	if file != "" {
+		basePath = filepath.Dir(file)
+	}
+	return linter.Lint(issueFile(file), basePath, input, validator)
+}
+
+func issueFile(file string) string {
+	if file == "" {
+		return "-"
+	}
+	return file
+}
+
+func lintSchema(input []byte, kind string, format string) ([]gojsonschema.ResultError, error) {
+	processor, err := getProcessor(format, input)
+	if err != nil {
+		return nil, err
+	}
 	jsonInput, err := processor.ToJSON(input)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	var unstructured map[string]any
 	if err := json.Unmarshal(jsonInput, &unstructured); err != nil {
-		return err
+		return nil, err
 	}
 	gv, err := schema.ParseGroupVersion(unstructured["apiVersion"].(string))
 	if err != nil {
-		return err
+		return nil, err
 	}
 	goschema, err := getScheme(kind, gv.Version)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	schemaLoader := gojsonschema.NewBytesLoader(goschema)
 	documentLoader := gojsonschema.NewBytesLoader(jsonInput)
 	result, err := gojsonschema.Validate(schemaLoader, documentLoader)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	if !result.Valid() {
-		fmt.Fprintln(writer, "The schema is not valid. See errors:")
-		for _, desc := range result.Errors() {
-			fmt.Fprintf(writer, "- %s\n", desc)
-		}
-		return fmt.Errorf("document is not valid")
-	}
-	return nil
+	return result.Errors(), nil
 }
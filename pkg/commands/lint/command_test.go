@@ -103,6 +103,81 @@ func Test_Execute(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Lint Test Semantic Issues",
+			args: []string{
+				"lint",
+				"test",
+				"--file",
+				filepath.Join(basePath, "test", "semantic.yaml"),
+			},
+			out:     filepath.Join(basePath, "test", "semantic.txt"),
+			wantErr: true,
+		},
+		{
+			name: "Lint Test Semantic Issues JSON Output",
+			args: []string{
+				"lint",
+				"test",
+				"--file",
+				filepath.Join(basePath, "test", "semantic.yaml"),
+				"--output-format",
+				"json",
+			},
+			out:     filepath.Join(basePath, "test", "semantic.json"),
+			wantErr: true,
+		},
+		{
+			name: "Lint Test SARIF Output",
+			args: []string{
+				"lint",
+				"test",
+				"--file",
+				filepath.Join(basePath, "test", "test.yaml"),
+				"--output-format",
+				"sarif",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Lint Test Unsupported Output",
+			args: []string{
+				"lint",
+				"test",
+				"--file",
+				filepath.Join(basePath, "test", "test.yaml"),
+				"--output-format",
+				"xml",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Lint Test Validate Resources",
+			args: []string{
+				"lint",
+				"test",
+				"--file",
+				filepath.Join("..", "..", "..", "testdata", "validation", "test", "chainsaw-test.yaml"),
+				"--validate-resources",
+				"--crd-dir",
+				filepath.Join("..", "..", "..", "testdata", "validation", "crds"),
+			},
+			out:     filepath.Join(basePath, "test", "resources.txt"),
+			wantErr: true,
+		},
+		{
+			name: "Lint Test Validate Resources Missing CRD Dir",
+			args: []string{
+				"lint",
+				"test",
+				"--file",
+				filepath.Join(basePath, "test", "test.yaml"),
+				"--validate-resources",
+				"--crd-dir",
+				filepath.Join(basePath, "not-found"),
+			},
+			wantErr: true,
+		},
 		{
 			name: "Lint Error Test Txt File",
 			args: []string{
@@ -0,0 +1,23 @@
+package lsp
+
+import (
+	"github.com/kyverno/chainsaw/pkg/lsp"
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	var stdio bool
+	cmd := &cobra.Command{
+		Use:          "lsp",
+		Short:        "Start a language server for Chainsaw files",
+		Long:         "Use chainsaw lsp to start a Language Server Protocol server providing completion, go-to-definition and diagnostics for tests, step templates and configuration files. The server communicates over standard input and output.",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return lsp.NewServer(cmd.InOrStdin(), cmd.OutOrStdout()).Serve()
+		},
+	}
+	// editors usually pass --stdio when starting a server communicating over standard input and output
+	cmd.Flags().BoolVar(&stdio, "stdio", true, "Communicate over standard input and output (the only supported transport)")
+	return cmd
+}
@@ -0,0 +1,33 @@
+package lsp
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommand(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	cmd.SetArgs([]string{"--help"})
+	out := bytes.NewBufferString("")
+	cmd.SetOut(out)
+	assert.NoError(t, cmd.Execute())
+	expected, err := os.ReadFile(filepath.Join("..", "..", "..", "testdata", "commands", "lsp", "help.txt"))
+	assert.NoError(t, err)
+	assert.Equal(t, string(expected), out.String())
+}
+
+func TestCommand_serve(t *testing.T) {
+	cmd := Command()
+	cmd.SetArgs([]string{"--stdio"})
+	in := bytes.NewBufferString("Content-Length: 44\r\n\r\n{\"jsonrpc\":\"2.0\",\"id\":1,\"method\":\"shutdown\"}")
+	out := bytes.NewBufferString("")
+	cmd.SetIn(in)
+	cmd.SetOut(out)
+	assert.NoError(t, cmd.Execute())
+	assert.Equal(t, "Content-Length: 38\r\n\r\n{\"jsonrpc\":\"2.0\",\"id\":1,\"result\":null}", out.String())
+}
@@ -5,8 +5,11 @@ import (
 	"github.com/kyverno/chainsaw/pkg/commands/build"
 	"github.com/kyverno/chainsaw/pkg/commands/create"
 	"github.com/kyverno/chainsaw/pkg/commands/docs"
+	"github.com/kyverno/chainsaw/pkg/commands/eval"
 	"github.com/kyverno/chainsaw/pkg/commands/export"
+	"github.com/kyverno/chainsaw/pkg/commands/format"
 	"github.com/kyverno/chainsaw/pkg/commands/lint"
+	"github.com/kyverno/chainsaw/pkg/commands/lsp"
 	"github.com/kyverno/chainsaw/pkg/commands/migrate"
 	"github.com/kyverno/chainsaw/pkg/commands/renovate"
 	"github.com/kyverno/chainsaw/pkg/commands/root"
@@ -22,8 +25,11 @@ func RootCommand() *cobra.Command {
 		build.Command(),
 		create.Command(),
 		docs.Command(),
+		eval.Command(),
 		export.Command(),
+		format.Command(),
 		lint.Command(),
+		lsp.Command(),
 		migrate.Command(),
 		renovate.Command(),
 		test.Command(),
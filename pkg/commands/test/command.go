@@ -7,6 +7,7 @@ import (
 	"io"
 	"os"
 	"strings"
+	"syscall"
 
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha2"
@@ -20,6 +21,7 @@ import (
 	flagutils "github.com/kyverno/chainsaw/pkg/utils/flag"
 	fsutils "github.com/kyverno/chainsaw/pkg/utils/fs"
 	restutils "github.com/kyverno/chainsaw/pkg/utils/rest"
+	"github.com/kyverno/chainsaw/pkg/validation"
 	"github.com/kyverno/chainsaw/pkg/version"
 	"github.com/kyverno/pkg/ext/output/color"
 	"github.com/spf13/cobra"
@@ -50,6 +52,7 @@ type options struct {
 	reportFormat                string
 	reportPath                  string
 	reportName                  string
+	reportDiagnostics           bool
 	namespace                   string
 	fastNamespaceDeletion       bool
 	deletionPropagationPolicy   string
@@ -60,6 +63,7 @@ type options struct {
 	kubeConfigOverrides         clientcmd.ConfigOverrides
 	forceTerminationGracePeriod metav1.Duration
 	delayBeforeCleanup          metav1.Duration
+	watch                       bool
 	selector                    []string
 	noCluster                   bool
 	pauseOnFailure              bool
@@ -71,6 +75,8 @@ type options struct {
 	shardIndex                  int
 	shardCount                  int
 	quiet                       bool
+	validateResources           bool
+	crdDirs                     []string
 }
 
 func Command() *cobra.Command {
@@ -188,6 +194,15 @@ func Command() *cobra.Command {
 				}
 				configuration.Spec.Report.Name = options.reportName
 			}
+			if flagutils.IsSet(flags, "report-diagnostics") {
+				if configuration.Spec.Report == nil {
+					configuration.Spec.Report = &v1alpha2.ReportOptions{
+						Format: v1alpha2.JSONFormat,
+						Name:   "chainsaw-report",
+					}
+				}
+				configuration.Spec.Report.Diagnostics = options.reportDiagnostics
+			}
 			if flagutils.IsSet(flags, "namespace") {
 				configuration.Spec.Namespace.Name = options.namespace
 			}
@@ -209,6 +224,9 @@ func Command() *cobra.Command {
 			if flagutils.IsSet(flags, "force-termination-grace-period") {
 				configuration.Spec.Execution.ForceTerminationGracePeriod = &options.forceTerminationGracePeriod
 			}
+			if flagutils.IsSet(flags, "watch") {
+				configuration.Spec.Execution.Watch = options.watch
+			}
 			if flagutils.IsSet(flags, "cleanup-delay") {
 				configuration.Spec.Cleanup.DelayBeforeCleanup = &options.delayBeforeCleanup
 			}
@@ -258,6 +276,9 @@ func Command() *cobra.Command {
 				if configuration.Spec.Report.Path != "" {
 					fprintfln(stdOut, "- ReportPath '%v'", configuration.Spec.Report.Path)
 				}
+				if configuration.Spec.Report.Diagnostics {
+					fprintfln(stdOut, "- ReportDiagnostics %v", configuration.Spec.Report.Diagnostics)
+				}
 			}
 			fprintfln(stdOut, "- Namespace '%v'", configuration.Spec.Namespace.Name)
 			fprintfln(stdOut, "- FastNamespaceDeletion %v", configuration.Spec.Namespace.FastDelete)
@@ -280,6 +301,9 @@ func Command() *cobra.Command {
 			if configuration.Spec.Execution.ForceTerminationGracePeriod != nil {
 				fprintfln(stdOut, "- ForceTerminationGracePeriod %v", configuration.Spec.Execution.ForceTerminationGracePeriod.Duration)
 			}
+			if configuration.Spec.Execution.Watch {
+				fprintfln(stdOut, "- Watch %v", configuration.Spec.Execution.Watch)
+			}
 			if configuration.Spec.Cleanup.DelayBeforeCleanup != nil {
 				fprintfln(stdOut, "- DelayBeforeCleanup %v", configuration.Spec.Cleanup.DelayBeforeCleanup.Duration)
 			}
@@ -299,6 +323,12 @@ func Command() *cobra.Command {
 			if options.remarshal {
 				fprintfln(stdOut, "- Remarshal %v", options.remarshal)
 			}
+			if options.validateResources {
+				fprintfln(stdOut, "- ValidateResources %v", options.validateResources)
+			}
+			if len(options.crdDirs) != 0 {
+				fprintfln(stdOut, "- CrdDirs %v", options.crdDirs)
+			}
 			fprintfln(stdOut, "- NoCluster %v", options.noCluster)
 			fprintfln(stdOut, "- PauseOnFailure %v", options.pauseOnFailure)
 			if options.shardCount > 0 {
@@ -340,6 +370,37 @@ func Command() *cobra.Command {
 					testToRun = append(testToRun, test)
 				}
 			}
+			// validate resources against OpenAPI schemas before running anything
+			if options.validateResources {
+				fprintln(stdOut, "Validating resources...")
+				var restConfig *rest.Config
+				if !options.noCluster {
+					cfg, err := restutils.DefaultConfig(options.kubeConfigOverrides)
+					if err != nil {
+						return err
+					}
+					restConfig = cfg
+				}
+				client, err := validation.NewClient(restConfig, options.crdDirs...)
+				if err != nil {
+					return err
+				}
+				validator := validation.New(client)
+				failed := false
+				for _, test := range testToRun {
+					failures, err := validation.ValidateTest(validator, test.BasePath, test.Test)
+					if err != nil {
+						return err
+					}
+					for _, failure := range failures {
+						fmt.Fprintf(stdErr, "- %s (%s) - %s\n", test.Test.Name, test.BasePath, failure)
+						failed = true
+					}
+				}
+				if failed {
+					return errors.New("resource validation failed")
+				}
+			}
 			// load values
 			fprintln(stdOut, "Loading values...")
 			values, err := values.Load(options.values...)
@@ -372,6 +433,9 @@ func Command() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			if informers := tc.Informers(); informers != nil {
+				defer informers.Shutdown()
+			}
 			tc = tc.WithQuiet(options.quiet)
 			// setup testing flags
 			if err := runnerflags.SetupFlags(configuration.Spec); err != nil {
@@ -385,8 +449,16 @@ func Command() *cobra.Command {
 					fmt.Fscanln(stdIn) //nolint:errcheck
 				}
 			}
+			hooks := runner.Hooks{
+				Setup:    configuration.Spec.Setup,
+				Teardown: configuration.Spec.Teardown,
+			}
+			// stop tests on interruption, cleanup still happens and the report is still written
+			ctx, stop := notifyContext(context.Background(), stdErr, os.Exit, os.Interrupt, syscall.SIGTERM)
+			defer stop()
 			runner := runner.New(clock, onFailure)
-			err = runner.Run(context.Background(), configuration.Spec.Namespace, tc, testToRun...)
+			err = runner.Run(ctx, configuration.Spec.Namespace, hooks, tc, testToRun...)
+			interrupted := ctx.Err() != nil
 			fmt.Fprintln(stdOut, "Tests Summary...")
 			fmt.Fprintf(stdOut, "- Passed  tests %d\n", tc.Passed())
 			fmt.Fprintf(stdOut, "- Failed  tests %d\n", tc.Failed())
@@ -402,6 +474,9 @@ func Command() *cobra.Command {
 			}
 			if err != nil {
 				fprintln(stdOut, "Done with error.")
+			} else if interrupted {
+				fprintln(stdOut, "Done, tests were interrupted.")
+				err = errors.New("tests interrupted")
 			} else if tc.Failed() > 0 {
 				fprintln(stdOut, "Done with failures.")
 				err = errors.New("some tests failed")
@@ -436,6 +511,7 @@ func Command() *cobra.Command {
 	cmd.Flags().IntVar(&options.parallel, "parallel", 0, "The maximum number of tests to run at once")
 	cmd.Flags().IntVar(&options.repeatCount, "repeat-count", 1, "Number of times to repeat each test")
 	cmd.Flags().DurationVar(&options.forceTerminationGracePeriod.Duration, "force-termination-grace-period", 0, "If specified, overrides termination grace periods in applicable resources")
+	cmd.Flags().BoolVar(&options.watch, "watch", false, "If set, assert and error operations are evaluated when resources change instead of polling")
 	// namespace options
 	cmd.Flags().StringVar(&options.namespace, "namespace", "", "Namespace to use for tests")
 	cmd.Flags().BoolVar(&options.fastNamespaceDeletion, "fast-namespace-deletion", false, "Skips waiting for namespace deletion")
@@ -449,15 +525,19 @@ func Command() *cobra.Command {
 	cmd.Flags().StringVar(&options.deletionPropagationPolicy, "deletion-propagation-policy", "Background", "The deletion propagation policy (Foreground|Background|Orphan)")
 	// error options
 	// reporting options
-	cmd.Flags().StringVar(&options.reportFormat, "report-format", "", "Test report format (JSON, XML, JUNIT-TEST, JUNIT-STEP, JUNIT-OPERATION)")
+	cmd.Flags().StringVar(&options.reportFormat, "report-format", "", "Test report format (JSON, XML, JUNIT-TEST, JUNIT-STEP, JUNIT-OPERATION, HTML)")
 	cmd.Flags().StringVar(&options.reportName, "report-name", "chainsaw-report", "The name of the report to create")
 	cmd.Flags().StringVar(&options.reportPath, "report-path", "", "The path of the report to create")
+	cmd.Flags().BoolVar(&options.reportDiagnostics, "report-diagnostics", false, "If set, collects diagnostics (objects, events, pod logs) when a test fails")
 	// multi-cluster options
 	cmd.Flags().StringSliceVar(&options.clusters, "cluster", nil, "Register cluster (format <cluster name>=<kubeconfig path>:[context name])")
 	// pause options
 	cmd.Flags().BoolVar(&options.pauseOnFailure, "pause-on-failure", false, "Pause test execution failure (implies no concurrency)")
 	// no cluster options
 	cmd.Flags().BoolVar(&options.noCluster, "no-cluster", false, "Runs without cluster")
+	// resource validation options
+	cmd.Flags().BoolVar(&options.validateResources, "validate-resources", false, "Validate resources referenced by tests against OpenAPI schemas before running tests")
+	cmd.Flags().StringSliceVar(&options.crdDirs, "crd-dir", nil, "Directories containing CRD files used to validate resources")
 	// label selectors
 	cmd.Flags().StringSliceVar(&options.selector, "selector", nil, "Selector (label query) to filter on")
 	// external values
@@ -124,6 +124,17 @@ func TestChainsawCommand(t *testing.T) {
 		},
 		wantErr: false,
 		out:     filepath.Join(basePath, "config_all_fields.txt"),
+	}, {
+		name: "validate resources",
+		args: []string{
+			"--no-cluster",
+			"--validate-resources",
+			"--crd-dir",
+			"../../../testdata/validation/crds",
+			"../../../testdata/validation/test",
+		},
+		wantErr: true,
+		err:     filepath.Join(basePath, "validate_resources_err.txt"),
 	}, {
 		name: "all flags",
 		args: []string{
@@ -148,6 +159,7 @@ func TestChainsawCommand(t *testing.T) {
 			"--include-test-regex=^.*$",
 			"--exclude-test-regex=^.*$",
 			"--force-termination-grace-period=5s",
+			"--watch",
 			"--set=env=prod",
 			"--set-string=image.tag=01",
 		},
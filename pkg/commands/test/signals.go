@@ -0,0 +1,50 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sync"
+)
+
+// notifyContext returns a context cancelled when the first of the given signals is received.
+// Cancelling the context stops running operations, cleanup still happens and the report is still written.
+// A second signal calls exit without waiting for cleanup.
+func notifyContext(ctx context.Context, out io.Writer, exit func(int), signals ...os.Signal) (context.Context, context.CancelFunc) {
+	ch := make(chan os.Signal, 2)
+	signal.Notify(ch, signals...)
+	ctx, cancel := handleSignals(ctx, ch, out, exit)
+	return ctx, func() {
+		signal.Stop(ch)
+		cancel()
+	}
+}
+
+func handleSignals(ctx context.Context, ch <-chan os.Signal, out io.Writer, exit func(int)) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		select {
+		case sig := <-ch:
+			fmt.Fprintf(out, "Received %s, stopping tests and cleaning up (send again to force exit)...\n", sig)
+			cancel()
+		case <-done:
+			return
+		}
+		select {
+		case sig := <-ch:
+			fmt.Fprintf(out, "Received %s, forcing exit without cleanup.\n", sig)
+			exit(130)
+		case <-done:
+		}
+	}()
+	var once sync.Once
+	return ctx, func() {
+		once.Do(func() {
+			close(done)
+			cancel()
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type syncBuffer struct {
+	lock sync.Mutex
+	buf  bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.buf.String()
+}
+
+func Test_handleSignals(t *testing.T) {
+	ch := make(chan os.Signal, 2)
+	var out syncBuffer
+	exited := make(chan int, 1)
+	ctx, cancel := handleSignals(context.Background(), ch, &out, func(code int) { exited <- code })
+	defer cancel()
+	assert.NoError(t, ctx.Err())
+	// first signal cancels the context
+	ch <- os.Interrupt
+	select {
+	case <-ctx.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("context was not cancelled")
+	}
+	assert.Empty(t, exited)
+	// second signal forces exit
+	ch <- os.Interrupt
+	select {
+	case code := <-exited:
+		assert.Equal(t, 130, code)
+	case <-time.After(5 * time.Second):
+		t.Fatal("exit was not called")
+	}
+	assert.Contains(t, out.String(), "stopping tests and cleaning up")
+	assert.Contains(t, out.String(), "forcing exit without cleanup")
+}
+
+func Test_handleSignals_stop(t *testing.T) {
+	ch := make(chan os.Signal, 2)
+	ctx, cancel := handleSignals(context.Background(), ch, &syncBuffer{}, func(int) { t.Fatal("unexpected exit") })
+	cancel()
+	cancel()
+	assert.Error(t, ctx.Err())
+	// signals received after stop are ignored
+	ch <- os.Interrupt
+	time.Sleep(100 * time.Millisecond)
+}
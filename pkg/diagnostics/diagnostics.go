@@ -0,0 +1,228 @@
+package diagnostics
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/kyverno/chainsaw/pkg/cleanup/cleaner"
+	"github.com/kyverno/chainsaw/pkg/client"
+	"github.com/kyverno/chainsaw/pkg/model"
+	"go.uber.org/multierr"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	ObjectsFile = "objects.yaml"
+	EventsFile  = "events.yaml"
+	FailureFile = "failure.txt"
+	LogsDir     = "logs"
+)
+
+type entry struct {
+	client client.Client
+	object client.Object
+}
+
+// Collector records the objects created by a test and the last assertion failure,
+// they are written to disk alongside events and pod logs when the test fails.
+type Collector struct {
+	lock    sync.Mutex
+	objects []entry
+	failure error
+}
+
+func NewCollector() *Collector {
+	return &Collector{}
+}
+
+// Track returns a cleaner collector recording objects in the diagnostics collector before forwarding them to the given cleaner collector (if any).
+func (c *Collector) Track(cleaner cleaner.CleanerCollector) cleaner.CleanerCollector {
+	return &tracker{
+		collector: c,
+		cleaner:   cleaner,
+	}
+}
+
+// AddFailure records an assertion failure, only the last one is kept.
+func (c *Collector) AddFailure(err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.failure = err
+}
+
+func (c *Collector) add(client client.Client, object client.Object) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.objects = append(c.objects, entry{
+		client: client,
+		object: object,
+	})
+}
+
+// Write collects diagnostics and writes them in the given directory.
+// Events and pod logs are collected from the given namespace, they are skipped when the namespace is empty.
+func (c *Collector) Write(ctx context.Context, dir string, cfg *rest.Config, cl client.Client, namespace string) error {
+	c.lock.Lock()
+	objects := c.objects
+	failure := c.failure
+	c.lock.Unlock()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	var errs []error
+	if err := writeObjects(ctx, filepath.Join(dir, ObjectsFile), objects); err != nil {
+		errs = append(errs, err)
+	}
+	if failure != nil {
+		if err := os.WriteFile(filepath.Join(dir, FailureFile), []byte(failure.Error()+"\n"), 0o600); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if namespace != "" {
+		if cl != nil {
+			if err := writeEvents(ctx, filepath.Join(dir, EventsFile), cl, namespace); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		if cfg != nil {
+			if err := writeLogs(ctx, filepath.Join(dir, LogsDir), cfg, namespace); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return multierr.Combine(errs...)
+}
+
+type tracker struct {
+	collector *Collector
+	cleaner   cleaner.CleanerCollector
+}
+
+func (t *tracker) Add(client client.Client, object client.Object) {
+	t.collector.add(client, object)
+	if t.cleaner != nil {
+		t.cleaner.Add(client, object)
+	}
+}
+
+func (t *tracker) AddFunc(operation model.OperationType, fn func(context.Context) error) {
+	if t.cleaner != nil {
+		t.cleaner.AddFunc(operation, fn)
+	}
+}
+
+func (t *tracker) Empty() bool {
+	return t.cleaner == nil || t.cleaner.Empty()
+}
+
+func writeObjects(ctx context.Context, file string, objects []entry) error {
+	var buf bytes.Buffer
+	for _, entry := range objects {
+		gvk := entry.object.GetObjectKind().GroupVersionKind()
+		var obj unstructured.Unstructured
+		obj.SetGroupVersionKind(gvk)
+		if buf.Len() != 0 {
+			buf.WriteString("---\n")
+		}
+		if err := entry.client.Get(ctx, client.Key(entry.object), &obj); err != nil {
+			if !kerrors.IsNotFound(err) {
+				return err
+			}
+			fmt.Fprintf(&buf, "# %s %s not found\n", gvk.Kind, client.Name(client.Key(entry.object)))
+			continue
+		}
+		unstructured.RemoveNestedField(obj.Object, "metadata", "managedFields")
+		data, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return err
+		}
+		buf.Write(data)
+	}
+	if buf.Len() == 0 {
+		return nil
+	}
+	return os.WriteFile(file, buf.Bytes(), 0o600)
+}
+
+func writeEvents(ctx context.Context, file string, cl client.Client, namespace string) error {
+	var list unstructured.UnstructuredList
+	list.SetAPIVersion("v1")
+	list.SetKind("EventList")
+	if err := cl.List(ctx, &list, ctrlclient.InNamespace(namespace)); err != nil {
+		return err
+	}
+	if len(list.Items) == 0 {
+		return nil
+	}
+	var items []any
+	for _, item := range list.Items {
+		unstructured.RemoveNestedField(item.Object, "metadata", "managedFields")
+		items = append(items, item.Object)
+	}
+	data, err := yaml.Marshal(items)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file, data, 0o600)
+}
+
+func writeLogs(ctx context.Context, dir string, cfg *rest.Config, namespace string) error {
+	clientset, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+	pods, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	if len(pods.Items) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	var errs []error
+	for _, pod := range pods.Items {
+		for _, container := range containers(pod) {
+			data, err := clientset.CoreV1().Pods(namespace).GetLogs(pod.Name, &corev1.PodLogOptions{Container: container}).DoRaw(ctx)
+			if err != nil {
+				// containers that didn't start have no logs
+				var status kerrors.APIStatus
+				if !errors.As(err, &status) {
+					errs = append(errs, err)
+				}
+				continue
+			}
+			if err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("%s.%s.log", pod.Name, container)), data, 0o600); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return multierr.Combine(errs...)
+}
+
+func containers(pod corev1.Pod) []string {
+	var names []string
+	for _, container := range pod.Spec.InitContainers {
+		names = append(names, container.Name)
+	}
+	for _, container := range pod.Spec.Containers {
+		names = append(names, container.Name)
+	}
+	for _, container := range pod.Spec.EphemeralContainers {
+		names = append(names, container.Name)
+	}
+	return names
+}
@@ -0,0 +1,112 @@
+package diagnostics
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kyverno/chainsaw/pkg/cleanup/cleaner"
+	"github.com/kyverno/chainsaw/pkg/client"
+	tclient "github.com/kyverno/chainsaw/pkg/client/testing"
+	"github.com/stretchr/testify/assert"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func configMap(name string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("v1")
+	obj.SetKind("ConfigMap")
+	obj.SetName(name)
+	obj.SetNamespace("foo")
+	return obj
+}
+
+func TestCollector_Track(t *testing.T) {
+	collector := NewCollector()
+	{
+		tracker := collector.Track(nil)
+		assert.True(t, tracker.Empty())
+		tracker.Add(nil, configMap("a"))
+	}
+	{
+		inner := cleaner.New(0, false, nil, "")
+		tracker := collector.Track(inner)
+		tracker.Add(nil, configMap("b"))
+		assert.False(t, tracker.Empty())
+		assert.False(t, inner.Empty())
+	}
+	assert.Len(t, collector.objects, 2)
+}
+
+func TestCollector_Write(t *testing.T) {
+	fake := &tclient.FakeClient{
+		GetFn: func(ctx context.Context, call int, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			if key.Name == "missing" {
+				return kerrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, key.Name)
+			}
+			obj.(*unstructured.Unstructured).SetName(key.Name)
+			obj.(*unstructured.Unstructured).SetNamespace(key.Namespace)
+			return nil
+		},
+		ListFn: func(ctx context.Context, call int, list client.ObjectList, opts ...client.ListOption) error {
+			event := unstructured.Unstructured{}
+			event.SetAPIVersion("v1")
+			event.SetKind("Event")
+			event.SetName("event")
+			list.(*unstructured.UnstructuredList).Items = append(list.(*unstructured.UnstructuredList).Items, event)
+			return nil
+		},
+	}
+	tests := []struct {
+		name      string
+		objects   []string
+		failure   error
+		namespace string
+		want      map[string]string
+	}{{
+		name: "empty",
+		want: map[string]string{},
+	}, {
+		name:    "objects and failure",
+		objects: []string{"a", "missing"},
+		failure: errors.New("v1/ConfigMap/foo/a - data.foo: Invalid value: \"bar\": Expected value: \"baz\""),
+		want: map[string]string{
+			ObjectsFile: "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a\n  namespace: foo\n---\n# ConfigMap foo/missing not found\n",
+			FailureFile: "v1/ConfigMap/foo/a - data.foo: Invalid value: \"bar\": Expected value: \"baz\"\n",
+		},
+	}, {
+		name:      "events",
+		namespace: "foo",
+		want: map[string]string{
+			EventsFile: "- apiVersion: v1\n  kind: Event\n  metadata:\n    name: event\n",
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := NewCollector()
+			tracker := collector.Track(nil)
+			for _, name := range tt.objects {
+				tracker.Add(fake, configMap(name))
+			}
+			if tt.failure != nil {
+				collector.AddFailure(errors.New("previous failure"))
+				collector.AddFailure(tt.failure)
+			}
+			dir := filepath.Join(t.TempDir(), "diagnostics")
+			assert.NoError(t, collector.Write(context.TODO(), dir, nil, fake, tt.namespace))
+			entries, err := os.ReadDir(dir)
+			assert.NoError(t, err)
+			got := map[string]string{}
+			for _, entry := range entries {
+				data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+				assert.NoError(t, err)
+				got[entry.Name()] = string(data)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
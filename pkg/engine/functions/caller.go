@@ -9,9 +9,14 @@ import (
 	"github.com/kyverno/kyverno-json/pkg/jp"
 )
 
-var Caller = sync.OnceValue(func() interpreter.FunctionCaller {
+// GetAllFunctions returns the functions available in JMESPath expressions, including the chainsaw specific ones.
+func GetAllFunctions() []jpfunctions.FunctionEntry {
 	var funcs []jpfunctions.FunctionEntry
 	funcs = append(funcs, jp.GetFunctions(context.Background())...)
 	funcs = append(funcs, GetFunctions()...)
-	return interpreter.NewFunctionCaller(funcs...)
+	return funcs
+}
+
+var Caller = sync.OnceValue(func() interpreter.FunctionCaller {
+	return interpreter.NewFunctionCaller(GetAllFunctions()...)
 })
@@ -0,0 +1,148 @@
+package informers
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/kyverno/chainsaw/pkg/client"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+)
+
+// Source reads objects from an informer cache and notifies subscribers when objects change.
+type Source interface {
+	// Read returns the cached objects matching the expected object name or labels.
+	// A not found error is returned when looking up an object by name that doesn't exist.
+	Read(expected client.Object) ([]unstructured.Unstructured, error)
+	// Subscribe returns a channel notified every time an object changes and a function to cancel the subscription.
+	Subscribe() (<-chan struct{}, func())
+}
+
+type informer struct {
+	resource    schema.GroupResource
+	informer    cache.SharedIndexInformer
+	stopCh      chan struct{}
+	stopOnce    sync.Once
+	synced      chan struct{}
+	syncedOnce  sync.Once
+	err         error
+	lock        sync.Mutex
+	subscribers map[chan struct{}]struct{}
+}
+
+func newInformer(client dynamic.Interface, mapping *meta.RESTMapping, namespace string) (*informer, error) {
+	i := &informer{
+		resource:    mapping.Resource.GroupResource(),
+		informer:    dynamicinformer.NewFilteredDynamicInformer(client, mapping.Resource, namespace, 0, cache.Indexers{}, nil).Informer(),
+		stopCh:      make(chan struct{}),
+		synced:      make(chan struct{}),
+		subscribers: map[chan struct{}]struct{}{},
+	}
+	// an error before the informer has synced means the resource can't be watched
+	if err := i.informer.SetWatchErrorHandlerWithContext(func(ctx context.Context, r *cache.Reflector, err error) {
+		if !i.informer.HasSynced() {
+			i.fail(err)
+		}
+		cache.DefaultWatchErrorHandler(ctx, r, err)
+	}); err != nil {
+		return nil, err
+	}
+	if _, err := i.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(any) { i.notify() },
+		UpdateFunc: func(any, any) { i.notify() },
+		DeleteFunc: func(any) { i.notify() },
+	}); err != nil {
+		return nil, err
+	}
+	go i.informer.Run(i.stopCh)
+	go func() {
+		if cache.WaitForCacheSync(i.stopCh, i.informer.HasSynced) {
+			i.syncedOnce.Do(func() { close(i.synced) })
+		}
+	}()
+	return i, nil
+}
+
+func (i *informer) fail(err error) {
+	i.syncedOnce.Do(func() {
+		i.err = err
+		close(i.synced)
+	})
+	i.stop()
+}
+
+func (i *informer) stop() {
+	i.stopOnce.Do(func() { close(i.stopCh) })
+}
+
+func (i *informer) waitForSync(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-i.synced:
+		return i.err
+	}
+}
+
+func (i *informer) notify() {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	for subscriber := range i.subscribers {
+		select {
+		case subscriber <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (i *informer) Subscribe() (<-chan struct{}, func()) {
+	subscriber := make(chan struct{}, 1)
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	i.subscribers[subscriber] = struct{}{}
+	return subscriber, func() {
+		i.lock.Lock()
+		defer i.lock.Unlock()
+		delete(i.subscribers, subscriber)
+	}
+}
+
+func (i *informer) Read(expected client.Object) ([]unstructured.Unstructured, error) {
+	store := i.informer.GetStore()
+	if expected.GetName() != "" {
+		item, exists, err := store.GetByKey(cache.NewObjectName(expected.GetNamespace(), expected.GetName()).String())
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, kerrors.NewNotFound(i.resource, expected.GetName())
+		}
+		return []unstructured.Unstructured{*item.(*unstructured.Unstructured).DeepCopy()}, nil
+	}
+	selector := labels.SelectorFromSet(expected.GetLabels())
+	var results []unstructured.Unstructured
+	for _, item := range store.List() {
+		obj := item.(*unstructured.Unstructured)
+		if expected.GetNamespace() != "" && obj.GetNamespace() != expected.GetNamespace() {
+			continue
+		}
+		if !selector.Matches(labels.Set(obj.GetLabels())) {
+			continue
+		}
+		results = append(results, *obj.DeepCopy())
+	}
+	sort.Slice(results, func(a, b int) bool {
+		if results[a].GetNamespace() != results[b].GetNamespace() {
+			return results[a].GetNamespace() < results[b].GetNamespace()
+		}
+		return results[a].GetName() < results[b].GetName()
+	})
+	return results, nil
+}
@@ -0,0 +1,181 @@
+package informers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+)
+
+// Factory shares informers across tests, there is at most one informer per cluster, resource and namespace.
+type Factory interface {
+	// For returns the informers for the cluster corresponding to the given config.
+	For(*rest.Config) Informers
+	// Acquire marks a namespace as used, the returned function must be called when it's not used anymore.
+	// Informers watching the namespace are stopped when the last user releases it.
+	Acquire(namespace string) func()
+	// Shutdown stops all informers started by the factory.
+	Shutdown()
+}
+
+// Informers provides watch based sources for a given cluster.
+type Informers interface {
+	// Source returns a source for objects of the given resource in the given namespace (all namespaces if empty).
+	// The call blocks until the underlying informer has synced, an error is returned if the resource can't be watched.
+	Source(ctx context.Context, mapping *meta.RESTMapping, namespace string) (Source, error)
+}
+
+type clientFactory = func(*rest.Config) (dynamic.Interface, error)
+
+type key struct {
+	cluster   string
+	resource  schema.GroupVersionResource
+	namespace string
+}
+
+type factory struct {
+	clientFactory clientFactory
+	lock          sync.Mutex
+	informers     map[key]*informer
+	users         map[string]int
+}
+
+func NewFactory() Factory {
+	return newFactory(func(config *rest.Config) (dynamic.Interface, error) {
+		return dynamic.NewForConfig(config)
+	})
+}
+
+func newFactory(clientFactory clientFactory) *factory {
+	return &factory{
+		clientFactory: clientFactory,
+		informers:     map[key]*informer{},
+		users:         map[string]int{},
+	}
+}
+
+func (f *factory) For(config *rest.Config) Informers {
+	if config == nil {
+		return nil
+	}
+	return &clusterInformers{
+		factory: f,
+		config:  config,
+		cluster: clusterKey(config),
+	}
+}
+
+func (f *factory) Acquire(namespace string) func() {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.users[namespace]++
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			f.lock.Lock()
+			defer f.lock.Unlock()
+			if f.users[namespace]--; f.users[namespace] > 0 {
+				return
+			}
+			delete(f.users, namespace)
+			for k, informer := range f.informers {
+				if k.namespace == namespace {
+					informer.stop()
+					delete(f.informers, k)
+				}
+			}
+		})
+	}
+}
+
+func (f *factory) Shutdown() {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	for _, informer := range f.informers {
+		informer.stop()
+	}
+	f.informers = map[key]*informer{}
+}
+
+func (f *factory) informer(k key, config *rest.Config, mapping *meta.RESTMapping) (*informer, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if informer, ok := f.informers[k]; ok {
+		return informer, nil
+	}
+	client, err := f.clientFactory(config)
+	if err != nil {
+		return nil, err
+	}
+	informer, err := newInformer(client, mapping, k.namespace)
+	if err != nil {
+		return nil, err
+	}
+	f.informers[k] = informer
+	return informer, nil
+}
+
+// evict removes a failed informer so that the next call starts a new one.
+func (f *factory) evict(k key, informer *informer) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.informers[k] == informer {
+		delete(f.informers, k)
+	}
+}
+
+type clusterInformers struct {
+	factory *factory
+	config  *rest.Config
+	cluster string
+}
+
+func (c *clusterInformers) Source(ctx context.Context, mapping *meta.RESTMapping, namespace string) (Source, error) {
+	if mapping.Scope.Name() == meta.RESTScopeNameRoot {
+		namespace = ""
+	}
+	k := key{
+		cluster:   c.cluster,
+		resource:  mapping.Resource,
+		namespace: namespace,
+	}
+	informer, err := c.factory.informer(k, c.config, mapping)
+	if err != nil {
+		return nil, err
+	}
+	if err := informer.waitForSync(ctx); err != nil {
+		// the informer failed to sync unless the context is done, other callers may still be waiting for it otherwise
+		if ctx.Err() == nil {
+			c.factory.evict(k, informer)
+		}
+		return nil, err
+	}
+	return informer, nil
+}
+
+// clusterKey identifies a cluster from the target host and credentials in a config.
+func clusterKey(config *rest.Config) string {
+	hash := sha256.New()
+	for _, value := range []string{
+		config.Host,
+		config.APIPath,
+		config.Username,
+		config.Password,
+		config.BearerToken,
+		config.BearerTokenFile,
+		config.Impersonate.UserName,
+		config.CertFile,
+		config.KeyFile,
+		string(config.CertData),
+		string(config.KeyData),
+	} {
+		hash.Write([]byte(value))
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
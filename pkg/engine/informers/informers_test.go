@@ -0,0 +1,173 @@
+package informers
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/rest"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+var (
+	configMapsGVR     = schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	configMapsMapping = &meta.RESTMapping{
+		Resource:         configMapsGVR,
+		GroupVersionKind: schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"},
+		Scope:            meta.RESTScopeNamespace,
+	}
+)
+
+func configMap(namespace, name string, labels map[string]string) *unstructured.Unstructured {
+	var obj unstructured.Unstructured
+	obj.SetAPIVersion("v1")
+	obj.SetKind("ConfigMap")
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	obj.SetLabels(labels)
+	return &obj
+}
+
+func newFakeClient(objects ...runtime.Object) *fake.FakeDynamicClient {
+	return fake.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{configMapsGVR: "ConfigMapList"},
+		objects...,
+	)
+}
+
+func TestFactory_For(t *testing.T) {
+	f := NewFactory()
+	defer f.Shutdown()
+	assert.Nil(t, f.For(nil))
+	assert.NotNil(t, f.For(&rest.Config{Host: "https://localhost"}))
+}
+
+func TestSource(t *testing.T) {
+	client := newFakeClient(
+		configMap("foo", "a", map[string]string{"app": "test"}),
+		configMap("foo", "b", nil),
+		configMap("bar", "c", map[string]string{"app": "test"}),
+	)
+	f := newFactory(func(*rest.Config) (dynamic.Interface, error) { return client, nil })
+	defer f.Shutdown()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	informers := f.For(&rest.Config{Host: "https://localhost"})
+	source, err := informers.Source(ctx, configMapsMapping, "foo")
+	assert.NoError(t, err)
+	// same cluster, resource and namespace share the same informer
+	other, err := f.For(&rest.Config{Host: "https://localhost"}).Source(ctx, configMapsMapping, "foo")
+	assert.NoError(t, err)
+	assert.Same(t, source, other)
+	// read by name
+	objs, err := source.Read(configMap("foo", "a", nil))
+	assert.NoError(t, err)
+	assert.Len(t, objs, 1)
+	assert.Equal(t, "a", objs[0].GetName())
+	// read missing object
+	_, err = source.Read(configMap("foo", "c", nil))
+	assert.True(t, kerrors.IsNotFound(err))
+	// read by labels
+	objs, err = source.Read(configMap("foo", "", map[string]string{"app": "test"}))
+	assert.NoError(t, err)
+	assert.Len(t, objs, 1)
+	// read all
+	objs, err = source.Read(configMap("foo", "", nil))
+	assert.NoError(t, err)
+	assert.Len(t, objs, 2)
+	assert.Equal(t, "a", objs[0].GetName())
+	assert.Equal(t, "b", objs[1].GetName())
+	// subscribers are notified of changes
+	changes, unsubscribe := source.Subscribe()
+	defer unsubscribe()
+	_, err = client.Resource(configMapsGVR).Namespace("foo").Create(ctx, configMap("foo", "d", nil), metav1.CreateOptions{})
+	assert.NoError(t, err)
+	select {
+	case <-changes:
+	case <-ctx.Done():
+		assert.Fail(t, "no change notification received")
+	}
+	assert.Eventually(t, func() bool {
+		objs, err := source.Read(configMap("foo", "d", nil))
+		return err == nil && len(objs) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestFactory_Acquire(t *testing.T) {
+	client := newFakeClient(configMap("foo", "a", nil))
+	f := newFactory(func(*rest.Config) (dynamic.Interface, error) { return client, nil })
+	defer f.Shutdown()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	informers := f.For(&rest.Config{Host: "https://localhost"})
+	first := f.Acquire("foo")
+	second := f.Acquire("foo")
+	source, err := informers.Source(ctx, configMapsMapping, "foo")
+	assert.NoError(t, err)
+	_, err = informers.Source(ctx, configMapsMapping, "bar")
+	assert.NoError(t, err)
+	assert.Len(t, f.informers, 2)
+	// the namespace is still used
+	first()
+	first()
+	assert.Len(t, f.informers, 2)
+	// the last user stops informers watching the namespace only
+	second()
+	assert.Len(t, f.informers, 1)
+	assert.Empty(t, f.users)
+	select {
+	case <-source.(*informer).stopCh:
+	default:
+		assert.Fail(t, "informer not stopped")
+	}
+	// a new informer is started when the namespace is used again
+	other, err := informers.Source(ctx, configMapsMapping, "foo")
+	assert.NoError(t, err)
+	assert.NotSame(t, source, other)
+}
+
+func TestSource_Forbidden(t *testing.T) {
+	client := newFakeClient()
+	var allowed atomic.Bool
+	client.PrependReactor("list", "configmaps", func(clienttesting.Action) (bool, runtime.Object, error) {
+		if allowed.Load() {
+			return false, nil, nil
+		}
+		return true, nil, kerrors.NewForbidden(configMapsGVR.GroupResource(), "", nil)
+	})
+	f := newFactory(func(*rest.Config) (dynamic.Interface, error) { return client, nil })
+	defer f.Shutdown()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	informers := f.For(&rest.Config{Host: "https://localhost"})
+	_, err := informers.Source(ctx, configMapsMapping, "foo")
+	assert.Error(t, err)
+	assert.True(t, kerrors.IsForbidden(err))
+	// the failed informer is evicted
+	assert.Empty(t, f.informers)
+	_, err = informers.Source(ctx, configMapsMapping, "foo")
+	assert.Error(t, err)
+	assert.Empty(t, f.informers)
+	// the next call retries
+	allowed.Store(true)
+	_, err = informers.Source(ctx, configMapsMapping, "foo")
+	assert.NoError(t, err)
+	assert.Len(t, f.informers, 1)
+}
+
+func Test_clusterKey(t *testing.T) {
+	assert.Equal(t, clusterKey(&rest.Config{Host: "https://a"}), clusterKey(&rest.Config{Host: "https://a"}))
+	assert.NotEqual(t, clusterKey(&rest.Config{Host: "https://a"}), clusterKey(&rest.Config{Host: "https://b"}))
+	assert.NotEqual(t, clusterKey(&rest.Config{Host: "https://a", BearerToken: "foo"}), clusterKey(&rest.Config{Host: "https://a"}))
+}
@@ -0,0 +1,39 @@
+package testing
+
+import (
+	"context"
+
+	"github.com/kyverno/chainsaw/pkg/client"
+	"github.com/kyverno/chainsaw/pkg/engine/informers"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type FakeInformers struct {
+	SourceFn func(ctx context.Context, call int, mapping *meta.RESTMapping, namespace string) (informers.Source, error)
+	numCalls int
+}
+
+func (i *FakeInformers) Source(ctx context.Context, mapping *meta.RESTMapping, namespace string) (informers.Source, error) {
+	defer func() { i.numCalls++ }()
+	return i.SourceFn(ctx, i.numCalls, mapping, namespace)
+}
+
+type FakeSource struct {
+	ReadFn   func(call int, expected client.Object) ([]unstructured.Unstructured, error)
+	Changes  chan struct{}
+	numCalls int
+}
+
+func (s *FakeSource) Read(expected client.Object) ([]unstructured.Unstructured, error) {
+	defer func() { s.numCalls++ }()
+	return s.ReadFn(s.numCalls, expected)
+}
+
+func (s *FakeSource) Subscribe() (<-chan struct{}, func()) {
+	return s.Changes, func() {}
+}
+
+func (s *FakeSource) NumCalls() int {
+	return s.numCalls
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/kyverno/chainsaw/pkg/engine/checks"
 	"github.com/kyverno/chainsaw/pkg/engine/namespacer"
 	"github.com/kyverno/chainsaw/pkg/engine/operations"
+	operrors "github.com/kyverno/chainsaw/pkg/engine/operations/errors"
 	"github.com/kyverno/chainsaw/pkg/engine/operations/internal"
 	"github.com/kyverno/chainsaw/pkg/engine/outputs"
 	"github.com/kyverno/chainsaw/pkg/engine/templating"
@@ -24,6 +25,9 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 )
 
+// DefaultFieldManager is the field manager used with server-side apply when none is configured.
+const DefaultFieldManager = "chainsaw"
+
 type operation struct {
 	compilers  compilers.Compilers
 	client     client.Client
@@ -31,6 +35,7 @@ type operation struct {
 	namespacer namespacer.Namespacer
 	cleaner    cleaner.CleanerCollector
 	template   bool
+	serverSide v1alpha1.ActionServerSide
 	expect     []v1alpha1.Expectation
 	outputs    []v1alpha1.Output
 }
@@ -42,6 +47,7 @@ func New(
 	namespacer namespacer.Namespacer,
 	cleaner cleaner.CleanerCollector,
 	template bool,
+	serverSide v1alpha1.ActionServerSide,
 	expect []v1alpha1.Expectation,
 	outputs []v1alpha1.Output,
 ) operations.Operation {
@@ -52,6 +58,7 @@ func New(
 		namespacer: namespacer,
 		cleaner:    cleaner,
 		template:   template,
+		serverSide: serverSide,
 		expect:     expect,
 		outputs:    outputs,
 	}
@@ -105,14 +112,43 @@ func (o *operation) tryApplyResource(ctx context.Context, tc apis.Bindings, obj
 	actual.SetGroupVersionKind(obj.GetObjectKind().GroupVersionKind())
 	err := o.client.Get(ctx, client.Key(&obj), &actual)
 	if err == nil {
+		if o.serverSide.ServerSide != nil && *o.serverSide.ServerSide {
+			return o.serverSideApplyResource(ctx, tc, obj, false)
+		}
 		return o.updateResource(ctx, tc, &actual, obj)
 	}
 	if kerrors.IsNotFound(err) {
+		if o.serverSide.ServerSide != nil && *o.serverSide.ServerSide {
+			return o.serverSideApplyResource(ctx, tc, obj, true)
+		}
 		return o.createResource(ctx, tc, obj)
 	}
 	return nil, err
 }
 
+func (o *operation) serverSideApplyResource(ctx context.Context, tc apis.Bindings, obj unstructured.Unstructured, create bool) (outputs.Outputs, error) {
+	// managed fields must not be sent with an apply patch
+	applied := *obj.DeepCopy()
+	applied.SetManagedFields(nil)
+	bytes, err := json.Marshal(&applied)
+	if err != nil {
+		return nil, err
+	}
+	fieldManager := o.serverSide.FieldManager
+	if fieldManager == "" {
+		fieldManager = DefaultFieldManager
+	}
+	opts := []client.PatchOption{client.FieldOwner(fieldManager)}
+	if o.serverSide.Force != nil && *o.serverSide.Force {
+		opts = append(opts, client.ForceOwnership)
+	}
+	err = o.client.Patch(ctx, &applied, client.RawPatch(types.ApplyPatchType, bytes), opts...)
+	if err == nil && create && o.cleaner != nil {
+		o.cleaner.Add(o.client, &applied)
+	}
+	return o.handleCheck(ctx, tc, applied, operrors.ConflictError(err))
+}
+
 func (o *operation) updateResource(ctx context.Context, tc apis.Bindings, actual *unstructured.Unstructured, obj unstructured.Unstructured) (outputs.Outputs, error) {
 	patched, err := client.PatchObject(actual, &obj)
 	if err != nil {
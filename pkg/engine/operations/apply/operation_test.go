@@ -14,7 +14,9 @@ import (
 	"github.com/kyverno/chainsaw/pkg/mocks"
 	"github.com/stretchr/testify/assert"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 func Test_apply(t *testing.T) {
@@ -56,6 +58,7 @@ func Test_apply(t *testing.T) {
 		name        string
 		object      unstructured.Unstructured
 		client      *tclient.FakeClient
+		serverSide  v1alpha1.ActionServerSide
 		expect      []v1alpha1.Expectation
 		expectedErr error
 	}{{
@@ -282,6 +285,103 @@ func Test_apply(t *testing.T) {
 		},
 		expect:      nil,
 		expectedErr: nil,
+	}, {
+		name:   "Server side apply existing resource",
+		object: podv2,
+		client: &tclient.FakeClient{
+			GetFn: func(ctx context.Context, _ int, _ client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+				*obj.(*unstructured.Unstructured) = podv1
+				return nil
+			},
+			PatchFn: func(_ context.Context, _ int, _ client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				if patch.Type() != types.ApplyPatchType {
+					return errors.New("unexpected patch type")
+				}
+				if !assert.Equal(t, []client.PatchOption{client.FieldOwner("test"), client.ForceOwnership}, opts) {
+					return errors.New("unexpected patch options")
+				}
+				return nil
+			},
+		},
+		serverSide: v1alpha1.ActionServerSide{
+			ServerSide:   new(true),
+			FieldManager: "test",
+			Force:        new(true),
+		},
+		expectedErr: nil,
+	}, {
+		name:   "Server side apply new resource",
+		object: podv1,
+		client: &tclient.FakeClient{
+			GetFn: func(ctx context.Context, _ int, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				return kerrors.NewNotFound(obj.GetObjectKind().GroupVersionKind().GroupVersion().WithResource("pods").GroupResource(), key.Name)
+			},
+			PatchFn: func(_ context.Context, _ int, _ client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				if patch.Type() != types.ApplyPatchType {
+					return errors.New("unexpected patch type")
+				}
+				if !assert.Equal(t, []client.PatchOption{client.FieldOwner(DefaultFieldManager)}, opts) {
+					return errors.New("unexpected patch options")
+				}
+				return nil
+			},
+		},
+		serverSide: v1alpha1.ActionServerSide{
+			ServerSide: new(true),
+		},
+		expectedErr: nil,
+	}, {
+		name:   "Server side apply checks the applied resource",
+		object: podv1,
+		client: &tclient.FakeClient{
+			GetFn: func(ctx context.Context, _ int, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				return kerrors.NewNotFound(obj.GetObjectKind().GroupVersionKind().GroupVersion().WithResource("pods").GroupResource(), key.Name)
+			},
+			PatchFn: func(_ context.Context, _ int, obj client.Object, _ client.Patch, _ ...client.PatchOption) error {
+				obj.SetUID("test-uid")
+				return nil
+			},
+		},
+		serverSide: v1alpha1.ActionServerSide{
+			ServerSide: new(true),
+		},
+		expect: []v1alpha1.Expectation{{
+			Check: v1alpha1.NewCheck(
+				map[string]any{
+					"metadata": map[string]any{
+						"uid": "test-uid",
+					},
+				},
+			),
+		}},
+		expectedErr: nil,
+	}, {
+		name:   "Server side apply conflict",
+		object: podv2,
+		client: &tclient.FakeClient{
+			GetFn: func(ctx context.Context, _ int, _ client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+				*obj.(*unstructured.Unstructured) = podv1
+				return nil
+			},
+			PatchFn: func(_ context.Context, _ int, _ client.Object, _ client.Patch, _ ...client.PatchOption) error {
+				return kerrors.NewApplyConflict([]metav1.StatusCause{{
+					Type:    metav1.CauseTypeFieldManagerConflict,
+					Message: `conflict with "kubectl"`,
+					Field:   ".spec.containers[name=\"test-container\"].image",
+				}}, `Apply failed with 1 conflict: conflict with "kubectl": .spec.containers[name="test-container"].image`)
+			},
+		},
+		serverSide: v1alpha1.ActionServerSide{
+			ServerSide: new(true),
+		},
+		expect: []v1alpha1.Expectation{{
+			Check: v1alpha1.NewCheck(
+				map[string]any{
+					"($error)": "server-side apply failed with 1 conflict(s), set force to take ownership of the conflicting fields\n* .spec.containers[name=\"test-container\"].image: conflict with \"kubectl\"",
+				},
+			),
+		}},
+		expectedErr: nil,
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -297,6 +397,7 @@ func Test_apply(t *testing.T) {
 				nil,
 				nil,
 				false,
+				tt.serverSide,
 				tt.expect,
 				nil,
 			)
@@ -3,11 +3,13 @@ package assert
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/kyverno/chainsaw/pkg/apis"
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
 	"github.com/kyverno/chainsaw/pkg/client"
 	"github.com/kyverno/chainsaw/pkg/engine/checks"
+	"github.com/kyverno/chainsaw/pkg/engine/informers"
 	"github.com/kyverno/chainsaw/pkg/engine/namespacer"
 	"github.com/kyverno/chainsaw/pkg/engine/operations"
 	operrors "github.com/kyverno/chainsaw/pkg/engine/operations/errors"
@@ -19,15 +21,17 @@ import (
 	"go.uber.org/multierr"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/apimachinery/pkg/util/wait"
 )
 
 type operation struct {
-	compilers  compilers.Compilers
-	client     client.Client
-	base       unstructured.Unstructured
-	namespacer namespacer.Namespacer
-	template   bool
+	compilers    compilers.Compilers
+	client       client.Client
+	base         unstructured.Unstructured
+	namespacer   namespacer.Namespacer
+	template     bool
+	informers    informers.Informers
+	consistently time.Duration
+	matchMode    *v1alpha1.MatchMode
 }
 
 func New(
@@ -36,13 +40,19 @@ func New(
 	expected unstructured.Unstructured,
 	namespacer namespacer.Namespacer,
 	template bool,
+	informers informers.Informers,
+	consistently time.Duration,
+	matchMode *v1alpha1.MatchMode,
 ) operations.Operation {
 	return &operation{
-		compilers:  compilers,
-		client:     client,
-		base:       expected,
-		namespacer: namespacer,
-		template:   template,
+		compilers:    compilers,
+		client:       client,
+		base:         expected,
+		namespacer:   namespacer,
+		template:     template,
+		informers:    informers,
+		consistently: consistently,
+		matchMode:    matchMode,
 	}
 }
 
@@ -67,13 +77,21 @@ func (o *operation) Exec(ctx context.Context, bindings apis.Bindings) (_ outputs
 			return nil, err
 		}
 	}
-	internal.LogStart(ctx, logging.Assert, &obj)
+	if err := internal.ValidateMatchMode(o.matchMode); err != nil {
+		return nil, err
+	}
+	if o.consistently != 0 {
+		internal.LogStart(ctx, logging.Assert, &obj, logging.Section("CONSISTENTLY", o.consistently.String()))
+	} else {
+		internal.LogStart(ctx, logging.Assert, &obj)
+	}
 	return nil, o.execute(ctx, bindings, obj)
 }
 
 func (o *operation) execute(ctx context.Context, bindings apis.Bindings, obj unstructured.Unstructured) error {
+	source := internal.WatchSource(ctx, logging.Assert, o.client, o.informers, &obj)
 	var lastErrs []error
-	err := wait.PollUntilContextCancel(ctx, client.PollInterval, false, func(ctx context.Context) (_ bool, err error) {
+	condition := func(ctx context.Context) (_ bool, err error) {
 		var errs []error
 		defer func() {
 			// record last errors only if there was no real error
@@ -86,41 +104,65 @@ func (o *operation) execute(ctx context.Context, bindings apis.Bindings, obj uns
 			if err != nil {
 				return false, err
 			}
-			if len(_errs) != 0 {
-				for _, _err := range _errs {
-					errs = append(errs, _err)
-				}
-			} else {
-				// at least one match found
+			matched := 0
+			if len(_errs) == 0 {
+				matched = 1
+			}
+			if err := internal.CheckMatchMode(o.matchMode, matched, 1); err == nil {
 				return true, nil
+			} else if !internal.IsAnyMatchMode(o.matchMode) {
+				errs = append(errs, err)
+			}
+			for _, _err := range _errs {
+				errs = append(errs, _err)
 			}
 		} else {
-			if candidates, err := internal.Read(ctx, &obj, o.client); err != nil {
-				if kerrors.IsNotFound(err) {
-					errs = append(errs, errors.New("actual resource not found"))
-					return false, nil
-				}
+			candidates, err := internal.ReadFrom(ctx, source, &obj, o.client)
+			notFound := kerrors.IsNotFound(err)
+			if err != nil && !notFound {
 				return false, err
+			}
+			matched := 0
+			var candidateErrs []error
+			for i := range candidates {
+				candidate := candidates[i]
+				_errs, err := checks.Check(ctx, o.compilers, candidate.UnstructuredContent(), bindings, new(v1alpha1.NewCheck(obj.UnstructuredContent())))
+				if err != nil {
+					return false, err
+				}
+				if len(_errs) != 0 {
+					candidateErrs = append(candidateErrs, operrors.ResourceError(o.compilers, obj, candidate, o.template, bindings, _errs))
+					continue
+				}
+				matched++
+				// at least one match found
+				if internal.IsAnyMatchMode(o.matchMode) {
+					return true, nil
+				}
+			}
+			if err := internal.CheckMatchMode(o.matchMode, matched, len(candidates)); err == nil {
+				return true, nil
+			} else if !internal.IsAnyMatchMode(o.matchMode) {
+				errs = append(errs, err)
+			}
+			if notFound {
+				errs = append(errs, errors.New("actual resource not found"))
 			} else if len(candidates) == 0 {
 				errs = append(errs, errors.New("no actual resource found"))
-			} else {
-				for i := range candidates {
-					candidate := candidates[i]
-					_errs, err := checks.Check(ctx, o.compilers, candidate.UnstructuredContent(), bindings, new(v1alpha1.NewCheck(obj.UnstructuredContent())))
-					if err != nil {
-						return false, err
-					}
-					if len(_errs) != 0 {
-						errs = append(errs, operrors.ResourceError(o.compilers, obj, candidate, o.template, bindings, _errs))
-					} else {
-						// at least one match found
-						return true, nil
-					}
-				}
 			}
+			errs = append(errs, candidateErrs...)
 		}
 		return false, nil
-	})
+	}
+	if o.consistently != 0 {
+		err := internal.Consistently(ctx, source, o.consistently, condition)
+		// report the evaluation that didn't hold
+		if errors.Is(err, internal.ErrInconsistent) && len(lastErrs) != 0 {
+			return multierr.Combine(lastErrs...)
+		}
+		return err
+	}
+	err := internal.Evaluate(ctx, source, condition)
 	// if no error, return success
 	if err == nil {
 		return nil
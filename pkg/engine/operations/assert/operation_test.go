@@ -7,14 +7,18 @@ import (
 	"time"
 
 	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
 	"github.com/kyverno/chainsaw/pkg/client"
 	tclient "github.com/kyverno/chainsaw/pkg/client/testing"
+	"github.com/kyverno/chainsaw/pkg/engine/informers"
+	tinformers "github.com/kyverno/chainsaw/pkg/engine/informers/testing"
 	"github.com/kyverno/chainsaw/pkg/engine/namespacer"
 	tnamespacer "github.com/kyverno/chainsaw/pkg/engine/namespacer/testing"
 	"github.com/kyverno/chainsaw/pkg/logging"
 	"github.com/kyverno/chainsaw/pkg/mocks"
 	"github.com/stretchr/testify/assert"
 	kerror "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -347,6 +351,9 @@ func Test_operationAssert(t *testing.T) {
 				tt.expected,
 				nspacer,
 				false,
+				nil,
+				0,
+				nil,
 			)
 			logger := &mocks.Logger{}
 			outputs, err := operation.Exec(logging.WithLogger(ctx, logger), nil)
@@ -360,3 +367,316 @@ func Test_operationAssert(t *testing.T) {
 		})
 	}
 }
+
+func Test_operationAssert_watch(t *testing.T) {
+	expected := unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata": map[string]any{
+				"name": "test-pod",
+			},
+			"status": map[string]any{
+				"phase": "Running",
+			},
+		},
+	}
+	pod := func(phase string) unstructured.Unstructured {
+		return unstructured.Unstructured{
+			Object: map[string]any{
+				"apiVersion": "v1",
+				"kind":       "Pod",
+				"metadata": map[string]any{
+					"name": "test-pod",
+				},
+				"status": map[string]any{
+					"phase": phase,
+				},
+			},
+		}
+	}
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, meta.RESTScopeNamespace)
+	tests := []struct {
+		name         string
+		client       *tclient.FakeClient
+		source       *tinformers.FakeSource
+		sourceErr    error
+		expectedLogs []string
+		expectErr    bool
+	}{{
+		name: "evaluated on change",
+		client: &tclient.FakeClient{
+			RESTMapperFn: func(int) meta.RESTMapper { return mapper },
+		},
+		source: &tinformers.FakeSource{
+			ReadFn: func(call int, _ client.Object) ([]unstructured.Unstructured, error) {
+				if call == 0 {
+					return []unstructured.Unstructured{pod("Pending")}, nil
+				}
+				return []unstructured.Unstructured{pod("Running")}, nil
+			},
+			Changes: make(chan struct{}, 1),
+		},
+		expectedLogs: []string{"ASSERT: RUN - []", "ASSERT: DONE - []"},
+	}, {
+		name: "no change",
+		client: &tclient.FakeClient{
+			RESTMapperFn: func(int) meta.RESTMapper { return mapper },
+		},
+		source: &tinformers.FakeSource{
+			ReadFn: func(int, client.Object) ([]unstructured.Unstructured, error) {
+				return nil, kerror.NewNotFound(schema.GroupResource{Resource: "pods"}, "test-pod")
+			},
+		},
+		expectErr:    true,
+		expectedLogs: []string{"ASSERT: RUN - []", "ASSERT: ERROR - [=== ERROR\nactual resource not found]"},
+	}, {
+		name: "fallback to polling",
+		client: &tclient.FakeClient{
+			RESTMapperFn: func(int) meta.RESTMapper { return mapper },
+			GetFn: func(_ context.Context, _ int, _ client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+				*obj.(*unstructured.Unstructured) = pod("Running")
+				return nil
+			},
+		},
+		sourceErr:    errors.New("forbidden"),
+		expectedLogs: []string{"ASSERT: RUN - []", "ASSERT: WARN - [=== WATCH\nfalling back to polling: forbidden]", "ASSERT: DONE - []"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			if tt.source != nil && tt.source.Changes != nil {
+				tt.source.Changes <- struct{}{}
+			}
+			informers := &tinformers.FakeInformers{
+				SourceFn: func(_ context.Context, _ int, mapping *meta.RESTMapping, namespace string) (informers.Source, error) {
+					assert.Equal(t, "pods", mapping.Resource.Resource)
+					assert.Equal(t, "", namespace)
+					if tt.sourceErr != nil {
+						return nil, tt.sourceErr
+					}
+					return tt.source, nil
+				},
+			}
+			operation := New(apis.DefaultCompilers, tt.client, expected, nil, false, informers, 0, nil)
+			logger := &mocks.Logger{}
+			outputs, err := operation.Exec(logging.WithLogger(ctx, logger), nil)
+			assert.Nil(t, outputs)
+			if tt.expectErr {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tt.expectedLogs, logger.Logs)
+			if tt.source != nil && tt.source.Changes != nil {
+				assert.Equal(t, 2, tt.source.NumCalls())
+			}
+		})
+	}
+}
+
+func Test_operationAssert_consistently(t *testing.T) {
+	expected := unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata": map[string]any{
+				"name": "test-pod",
+			},
+			"status": map[string]any{
+				"phase": "Running",
+			},
+		},
+	}
+	pod := func(phase string) unstructured.Unstructured {
+		return unstructured.Unstructured{
+			Object: map[string]any{
+				"apiVersion": "v1",
+				"kind":       "Pod",
+				"metadata": map[string]any{
+					"name": "test-pod",
+				},
+				"status": map[string]any{
+					"phase": phase,
+				},
+			},
+		}
+	}
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, meta.RESTScopeNamespace)
+	tests := []struct {
+		name         string
+		client       *tclient.FakeClient
+		source       *tinformers.FakeSource
+		expectErr    string
+		expectedLogs []string
+	}{{
+		name: "holds",
+		client: &tclient.FakeClient{
+			GetFn: func(_ context.Context, _ int, _ client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+				*obj.(*unstructured.Unstructured) = pod("Running")
+				return nil
+			},
+		},
+		expectedLogs: []string{"ASSERT: RUN - [=== CONSISTENTLY\n300ms]", "ASSERT: DONE - []"},
+	}, {
+		name: "doesn't hold",
+		client: &tclient.FakeClient{
+			GetFn: func(_ context.Context, call int, _ client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+				if call < 2 {
+					*obj.(*unstructured.Unstructured) = pod("Running")
+				} else {
+					*obj.(*unstructured.Unstructured) = pod("Pending")
+				}
+				return nil
+			},
+		},
+		expectErr: "status.phase: Invalid value: \"Pending\": Expected value: \"Running\"",
+	}, {
+		name: "not found",
+		client: &tclient.FakeClient{
+			GetFn: func(_ context.Context, _ int, _ client.ObjectKey, _ client.Object, _ ...client.GetOption) error {
+				return kerror.NewNotFound(schema.GroupResource{Resource: "pods"}, "test-pod")
+			},
+		},
+		expectErr: "actual resource not found",
+	}, {
+		name: "evaluated on change",
+		client: &tclient.FakeClient{
+			RESTMapperFn: func(int) meta.RESTMapper { return mapper },
+		},
+		source: &tinformers.FakeSource{
+			ReadFn: func(call int, _ client.Object) ([]unstructured.Unstructured, error) {
+				if call == 0 {
+					return []unstructured.Unstructured{pod("Running")}, nil
+				}
+				return []unstructured.Unstructured{pod("Failed")}, nil
+			},
+			Changes: make(chan struct{}, 1),
+		},
+		expectErr: "status.phase: Invalid value: \"Failed\": Expected value: \"Running\"",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			var sources informers.Informers
+			if tt.source != nil {
+				tt.source.Changes <- struct{}{}
+				sources = &tinformers.FakeInformers{
+					SourceFn: func(context.Context, int, *meta.RESTMapping, string) (informers.Source, error) {
+						return tt.source, nil
+					},
+				}
+			}
+			operation := New(apis.DefaultCompilers, tt.client, expected, nil, false, sources, 300*time.Millisecond, nil)
+			logger := &mocks.Logger{}
+			start := time.Now()
+			outputs, err := operation.Exec(logging.WithLogger(ctx, logger), nil)
+			assert.Nil(t, outputs)
+			if tt.expectErr != "" {
+				assert.Error(t, err)
+				if err != nil {
+					assert.Contains(t, err.Error(), tt.expectErr)
+				}
+				// the operation fails as soon as the check doesn't hold
+				assert.Less(t, time.Since(start), 300*time.Millisecond)
+			} else {
+				assert.NoError(t, err)
+				assert.GreaterOrEqual(t, time.Since(start), 300*time.Millisecond)
+				assert.Equal(t, tt.expectedLogs, logger.Logs)
+			}
+		})
+	}
+}
+
+func Test_operationAssert_matchMode(t *testing.T) {
+	expected := unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata": map[string]any{
+				"namespace": "test-ns",
+			},
+			"status": map[string]any{
+				"phase": "Running",
+			},
+		},
+	}
+	pod := func(name, phase string) unstructured.Unstructured {
+		return unstructured.Unstructured{
+			Object: map[string]any{
+				"apiVersion": "v1",
+				"kind":       "Pod",
+				"metadata": map[string]any{
+					"name":      name,
+					"namespace": "test-ns",
+				},
+				"status": map[string]any{
+					"phase": phase,
+				},
+			},
+		}
+	}
+	fakeClient := &tclient.FakeClient{
+		ListFn: func(_ context.Context, _ int, list client.ObjectList, _ ...client.ListOption) error {
+			uList := list.(*unstructured.UnstructuredList)
+			uList.Items = append(uList.Items, pod("pod-a", "Running"), pod("pod-b", "Pending"), pod("pod-c", "Running"))
+			return nil
+		},
+	}
+	tests := []struct {
+		name      string
+		matchMode *v1alpha1.MatchMode
+		expectErr []string
+	}{{
+		name: "any",
+	}, {
+		name:      "all",
+		matchMode: &v1alpha1.MatchMode{Policy: v1alpha1.MatchPolicyAll},
+		expectErr: []string{
+			"2 of 3 candidates matched, expected all candidates to match",
+			"v1/Pod/test-ns/pod-b",
+			"status.phase: Invalid value: \"Pending\": Expected value: \"Running\"",
+		},
+	}, {
+		name:      "exactly",
+		matchMode: &v1alpha1.MatchMode{Policy: v1alpha1.MatchPolicyExactly, Count: new(2)},
+	}, {
+		name:      "exactly with too many matches",
+		matchMode: &v1alpha1.MatchMode{Policy: v1alpha1.MatchPolicyExactly, Count: new(1)},
+		expectErr: []string{"2 of 3 candidates matched, expected exactly 1"},
+	}, {
+		name:      "at least",
+		matchMode: &v1alpha1.MatchMode{Policy: v1alpha1.MatchPolicyAtLeast, Count: new(2)},
+	}, {
+		name:      "at least with too few matches",
+		matchMode: &v1alpha1.MatchMode{Policy: v1alpha1.MatchPolicyAtLeast, Count: new(3)},
+		expectErr: []string{"2 of 3 candidates matched, expected at least 3", "v1/Pod/test-ns/pod-b"},
+	}, {
+		name:      "invalid",
+		matchMode: &v1alpha1.MatchMode{Policy: v1alpha1.MatchPolicyExactly},
+		expectErr: []string{"a count must be specified with the Exactly match policy"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+			defer cancel()
+			operation := New(apis.DefaultCompilers, fakeClient, expected, nil, false, nil, 0, tt.matchMode)
+			outputs, err := operation.Exec(logging.WithLogger(ctx, &mocks.Logger{}), nil)
+			assert.Nil(t, outputs)
+			if len(tt.expectErr) != 0 {
+				assert.Error(t, err)
+				if err != nil {
+					for _, expectErr := range tt.expectErr {
+						assert.Contains(t, err.Error(), expectErr)
+					}
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package command
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -9,12 +10,15 @@ import (
 
 	"github.com/kyverno/chainsaw/pkg/apis"
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/cleanup/cleaner"
 	apibindings "github.com/kyverno/chainsaw/pkg/engine/bindings"
 	"github.com/kyverno/chainsaw/pkg/engine/checks"
 	"github.com/kyverno/chainsaw/pkg/engine/operations"
+	operrors "github.com/kyverno/chainsaw/pkg/engine/operations/errors"
 	"github.com/kyverno/chainsaw/pkg/engine/operations/internal"
 	"github.com/kyverno/chainsaw/pkg/engine/outputs"
 	"github.com/kyverno/chainsaw/pkg/logging"
+	"github.com/kyverno/chainsaw/pkg/model"
 	environment "github.com/kyverno/chainsaw/pkg/utils/env"
 	restutils "github.com/kyverno/chainsaw/pkg/utils/rest"
 	"github.com/kyverno/kyverno-json/pkg/core/compilers"
@@ -28,6 +32,7 @@ type operation struct {
 	basePath  string
 	namespace string
 	cfg       *rest.Config
+	cleaner   cleaner.CleanerCollector
 }
 
 func New(
@@ -36,6 +41,7 @@ func New(
 	basePath string,
 	namespace string,
 	cfg *rest.Config,
+	cleaner cleaner.CleanerCollector,
 ) operations.Operation {
 	return &operation{
 		compilers: compilers,
@@ -43,6 +49,7 @@ func New(
 		basePath:  basePath,
 		namespace: namespace,
 		cfg:       cfg,
+		cleaner:   cleaner,
 	}
 }
 
@@ -53,11 +60,19 @@ func (o *operation) Exec(ctx context.Context, bindings apis.Bindings) (_ outputs
 	defer func() {
 		internal.LogEnd(ctx, logging.Command, nil, _err)
 	}()
-	cmd, cancel, err := o.createCommand(ctx, bindings)
-	if cancel != nil {
-		defer cancel()
+	if o.command.Background {
+		if o.cleaner == nil {
+			return nil, errors.New("cleaner not set")
+		}
+		if o.command.Check != nil && !o.command.Check.IsNil() {
+			return nil, errors.New("check is not supported for background commands, use a process operation instead")
+		}
 	}
+	cmd, cancel, err := o.createCommand(ctx, bindings)
 	if err != nil {
+		if cancel != nil {
+			cancel()
+		}
 		return nil, err
 	}
 	var logOpts []fmt.Stringer
@@ -65,6 +80,12 @@ func (o *operation) Exec(ctx context.Context, bindings apis.Bindings) (_ outputs
 		logOpts = append(logOpts, logging.Section("COMMAND", cmd.String()))
 	}
 	internal.LogStart(ctx, logging.Command, nil, logOpts...)
+	if o.command.Background {
+		return o.start(ctx, bindings, cmd, cancel)
+	}
+	if cancel != nil {
+		defer cancel()
+	}
 	return o.execute(ctx, bindings, cmd)
 }
 
@@ -95,7 +116,12 @@ func (o *operation) createCommand(ctx context.Context, bindings apis.Bindings) (
 		env = append(env, fmt.Sprintf("KUBECONFIG=%s", path))
 	}
 	args := environment.Expand(maps, o.command.Args...)
-	cmd := exec.CommandContext(ctx, o.command.Entrypoint, args...) //nolint:gosec
+	cmdCtx := ctx
+	if o.command.Background {
+		// the process outlives the operation, it is stopped through its handle or by the cleaner
+		cmdCtx = context.WithoutCancel(ctx)
+	}
+	cmd := exec.CommandContext(cmdCtx, o.command.Entrypoint, args...) //nolint:gosec
 	cmd.Env = env
 	basePath := o.basePath
 	if o.command.WorkDir != nil {
@@ -109,6 +135,19 @@ func (o *operation) createCommand(ctx context.Context, bindings apis.Bindings) (
 	return cmd, cancel, nil
 }
 
+func (o *operation) start(ctx context.Context, bindings apis.Bindings, cmd *exec.Cmd, release func()) (outputs.Outputs, error) {
+	handle, _, err := internal.StartProcess(cmd, o.command.SkipLogOutput, release)
+	if err != nil {
+		return nil, err
+	}
+	o.cleaner.AddFunc(model.OperationTypeCommand, func(ctx context.Context) error {
+		return internal.ReleaseProcess(ctx, handle)
+	})
+	logging.Log(ctx, logging.Command, logging.LogStatus, nil, color.BoldFgCyan, logging.Section("HANDLE", handle))
+	bindings = apibindings.RegisterBinding(bindings, "handle", handle)
+	return outputs.Process(ctx, o.compilers, bindings, nil, o.command.Outputs...)
+}
+
 func (o *operation) execute(ctx context.Context, bindings apis.Bindings, cmd *exec.Cmd) (_outputs outputs.Outputs, _err error) {
 	var output internal.CommandOutput
 	if !o.command.SkipLogOutput {
@@ -139,11 +178,11 @@ func (o *operation) execute(ctx context.Context, bindings apis.Bindings, cmd *ex
 		}
 	}(bindings)
 	if o.command.Check == nil || o.command.Check.IsNil() {
-		return nil, err
+		return nil, operrors.CommandError(err, output.Out(), output.Err())
 	}
 	if errs, err := checks.Check(ctx, o.compilers, nil, bindings, o.command.Check); err != nil {
 		return nil, err
 	} else {
-		return nil, errs.ToAggregate()
+		return nil, operrors.CommandError(errs.ToAggregate(), output.Out(), output.Err())
 	}
 }
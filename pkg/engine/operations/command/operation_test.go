@@ -3,12 +3,16 @@ package command
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/kyverno/chainsaw/pkg/apis"
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/cleanup/cleaner"
+	"github.com/kyverno/chainsaw/pkg/engine/operations/internal"
 	"github.com/kyverno/chainsaw/pkg/logging"
 	"github.com/kyverno/chainsaw/pkg/mocks"
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func Test_operationCommand(t *testing.T) {
@@ -140,6 +144,7 @@ func Test_operationCommand(t *testing.T) {
 				tt.basePath,
 				tt.namespace,
 				nil,
+				nil,
 			)
 			_, err := operation.Exec(ctx, nil)
 			if tt.wantErr {
@@ -153,3 +158,90 @@ func Test_operationCommand(t *testing.T) {
 		})
 	}
 }
+
+func Test_operationCommand_background(t *testing.T) {
+	tests := []struct {
+		name       string
+		command    v1alpha1.Command
+		cleaner    bool
+		wantErrMsg string
+	}{{
+		name: "without cleaner",
+		command: v1alpha1.Command{
+			Entrypoint: "sleep",
+			Args:       []string{"30"},
+			Background: true,
+		},
+		wantErrMsg: "cleaner not set",
+	}, {
+		name: "with check",
+		command: v1alpha1.Command{
+			Entrypoint: "sleep",
+			Args:       []string{"30"},
+			Background: true,
+			ActionCheck: v1alpha1.ActionCheck{
+				Check: new(v1alpha1.NewCheck(map[string]any{
+					"($error)": nil,
+				})),
+			},
+		},
+		cleaner:    true,
+		wantErrMsg: "check is not supported for background commands",
+	}, {
+		name: "ok",
+		command: v1alpha1.Command{
+			Entrypoint: "sleep",
+			Args:       []string{"30"},
+			Background: true,
+			ActionOutputs: v1alpha1.ActionOutputs{
+				Outputs: []v1alpha1.Output{{
+					Binding: v1alpha1.Binding{
+						Name:  "handle",
+						Value: v1alpha1.NewProjection("($handle)"),
+					},
+				}},
+			},
+		},
+		cleaner: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := logging.WithLogger(context.TODO(), &mocks.Logger{})
+			var c cleaner.Cleaner
+			if tt.cleaner {
+				c = cleaner.New(time.Second, false, nil, metav1.DeletePropagationBackground)
+			}
+			operation := New(
+				apis.DefaultCompilers,
+				tt.command,
+				"",
+				"test-namespace",
+				nil,
+				c,
+			)
+			outputs, err := operation.Exec(ctx, nil)
+			if tt.wantErrMsg != "" {
+				assert.Error(t, err)
+				if err != nil {
+					assert.Contains(t, err.Error(), tt.wantErrMsg)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			handle, ok := outputs["handle"].(string)
+			assert.True(t, ok)
+			process, err := internal.GetProcess(handle)
+			assert.NoError(t, err)
+			// the process is still running after the operation completed
+			waitCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+			defer cancel()
+			assert.ErrorIs(t, process.Wait(waitCtx), context.DeadlineExceeded)
+			// the cleaner stops the process
+			assert.False(t, c.Empty())
+			assert.Nil(t, c.Run(ctx, nil))
+			assert.NoError(t, process.Wait(ctx))
+			_, err = internal.GetProcess(handle)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package describe
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+	"unicode"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+// skippedFields are metadata fields already printed in the header or not worth printing.
+var skippedFields = map[string]bool{
+	"name":          true,
+	"namespace":     true,
+	"labels":        true,
+	"annotations":   true,
+	"managedFields": true,
+}
+
+// acronyms are rendered upper case by the describer.
+var acronyms = map[string]string{
+	"api":  "API",
+	"cpu":  "CPU",
+	"dns":  "DNS",
+	"id":   "ID",
+	"ip":   "IP",
+	"ips":  "IPs",
+	"tls":  "TLS",
+	"uid":  "UID",
+	"uri":  "URI",
+	"url":  "URL",
+	"uuid": "UUID",
+}
+
+// describe writes a human readable description of an object, mimicking the kubectl generic describer.
+// It is used for kinds that have no dedicated kubectl describer.
+func describe(w io.Writer, obj unstructured.Unstructured, events []corev1.Event, showEvents bool, now time.Time) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(tw, "Name:\t%s\n", obj.GetName())
+	if obj.GetNamespace() != "" {
+		fmt.Fprintf(tw, "Namespace:\t%s\n", obj.GetNamespace())
+	}
+	printMap(tw, "Labels", obj.GetLabels())
+	printMap(tw, "Annotations", obj.GetAnnotations())
+	printContent(tw, 0, obj.UnstructuredContent(), true)
+	if showEvents {
+		printEvents(tw, events, now)
+	}
+	return tw.Flush()
+}
+
+func printMap(w io.Writer, title string, values map[string]string) {
+	if len(values) == 0 {
+		fmt.Fprintf(w, "%s:\t<none>\n", title)
+		return
+	}
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for i, key := range keys {
+		if i == 0 {
+			fmt.Fprintf(w, "%s:\t%s=%s\n", title, key, values[key])
+		} else {
+			fmt.Fprintf(w, "\t%s=%s\n", key, values[key])
+		}
+	}
+}
+
+func printContent(w io.Writer, level int, content map[string]any, root bool) {
+	keys := make([]string, 0, len(content))
+	for key := range content {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	indent := strings.Repeat("  ", level)
+	for _, key := range keys {
+		value := content[key]
+		if key == "metadata" && root {
+			if metadata, ok := value.(map[string]any); ok {
+				filtered := map[string]any{}
+				for k, v := range metadata {
+					if !skippedFields[k] {
+						filtered[k] = v
+					}
+				}
+				if len(filtered) != 0 {
+					fmt.Fprintf(w, "%sMetadata:\n", indent)
+					printContent(w, level+1, filtered, false)
+				}
+			}
+			continue
+		}
+		label := smartLabel(key)
+		switch typed := value.(type) {
+		case map[string]any:
+			fmt.Fprintf(w, "%s%s:\n", indent, label)
+			printContent(w, level+1, typed, false)
+		case []any:
+			fmt.Fprintf(w, "%s%s:\n", indent, label)
+			printSlice(w, level+1, typed)
+		default:
+			fmt.Fprintf(w, "%s%s:\t%v\n", indent, label, typed)
+		}
+	}
+}
+
+func printSlice(w io.Writer, level int, items []any) {
+	indent := strings.Repeat("  ", level)
+	for _, item := range items {
+		switch typed := item.(type) {
+		case map[string]any:
+			printContent(w, level, typed, false)
+		case []any:
+			printSlice(w, level+1, typed)
+		default:
+			fmt.Fprintf(w, "%s%v\n", indent, typed)
+		}
+	}
+}
+
+func printEvents(w io.Writer, events []corev1.Event, now time.Time) {
+	if len(events) == 0 {
+		fmt.Fprintf(w, "Events:\t<none>\n")
+		return
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return eventTime(events[i]).Before(eventTime(events[j]))
+	})
+	fmt.Fprintf(w, "Events:\n  Type\tReason\tAge\tFrom\tMessage\n")
+	fmt.Fprintf(w, "  ----\t------\t----\t----\t-------\n")
+	for _, event := range events {
+		age := "<unknown>"
+		if t := eventTime(event); !t.IsZero() {
+			age = duration.HumanDuration(now.Sub(t))
+		}
+		from := event.Source.Component
+		if from == "" {
+			from = event.ReportingController
+		}
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\n", event.Type, event.Reason, age, from, strings.TrimSpace(event.Message))
+	}
+}
+
+func eventTime(event corev1.Event) time.Time {
+	if !event.LastTimestamp.IsZero() {
+		return event.LastTimestamp.Time
+	}
+	if !event.EventTime.IsZero() {
+		return event.EventTime.Time
+	}
+	return event.CreationTimestamp.Time
+}
+
+// smartLabel converts a camel case field name into a title case label (creationTimestamp -> Creation Timestamp).
+func smartLabel(key string) string {
+	var words []string
+	var current []rune
+	runes := []rune(key)
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) && (unicode.IsLower(runes[i-1]) || startsWord(runes, i)) {
+			words = append(words, string(current))
+			current = nil
+		}
+		current = append(current, r)
+	}
+	if len(current) != 0 {
+		words = append(words, string(current))
+	}
+	for i, word := range words {
+		if acronym, ok := acronyms[strings.ToLower(word)]; ok {
+			words[i] = acronym
+		} else {
+			runes := []rune(word)
+			runes[0] = unicode.ToUpper(runes[0])
+			words[i] = string(runes)
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// startsWord returns true when the upper case rune at index i ends an acronym and starts a new word (HTTPServer -> HTTP Server).
+// A trailing plural suffix is kept with the acronym (podIPs -> Pod IPs).
+func startsWord(runes []rune, i int) bool {
+	if i+1 >= len(runes) || !unicode.IsLower(runes[i+1]) {
+		return false
+	}
+	return i+2 != len(runes) || runes[i+1] != 's'
+}
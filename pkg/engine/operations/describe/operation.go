@@ -0,0 +1,140 @@
+package describe
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/client"
+	"github.com/kyverno/chainsaw/pkg/engine/namespacer"
+	"github.com/kyverno/chainsaw/pkg/engine/operations"
+	"github.com/kyverno/chainsaw/pkg/engine/operations/internal"
+	"github.com/kyverno/chainsaw/pkg/engine/outputs"
+	"github.com/kyverno/chainsaw/pkg/logging"
+	"github.com/kyverno/kyverno-json/pkg/core/compilers"
+	"github.com/kyverno/pkg/ext/output/color"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	kdescribe "k8s.io/kubectl/pkg/describe"
+)
+
+// chunkSize is the list chunk size used by kubectl describers (same as kubectl default).
+const chunkSize = 500
+
+type describerFactory = func(cfg *rest.Config, kind schema.GroupKind) (kdescribe.ResourceDescriber, bool)
+
+type operation struct {
+	compilers    compilers.Compilers
+	cfg          *rest.Config
+	client       client.Client
+	namespacer   namespacer.Namespacer
+	describe     v1alpha1.Describe
+	describerFor describerFactory
+}
+
+func New(
+	compilers compilers.Compilers,
+	cfg *rest.Config,
+	client client.Client,
+	namespacer namespacer.Namespacer,
+	describe v1alpha1.Describe,
+) operations.Operation {
+	return &operation{
+		compilers:    compilers,
+		cfg:          cfg,
+		client:       client,
+		namespacer:   namespacer,
+		describe:     describe,
+		describerFor: describerFor,
+	}
+}
+
+// describerFor returns the kubectl describer for a kind, if any.
+// Kinds without a kubectl describer (custom resources for example) use the generic describer.
+func describerFor(cfg *rest.Config, kind schema.GroupKind) (kdescribe.ResourceDescriber, bool) {
+	if cfg == nil {
+		return nil, false
+	}
+	return kdescribe.DescriberFor(kind, cfg)
+}
+
+func (o *operation) Exec(ctx context.Context, bindings apis.Bindings) (_ outputs.Outputs, _err error) {
+	if bindings == nil {
+		bindings = apis.NewBindings()
+	}
+	var obj client.Object
+	defer func() {
+		internal.LogEnd(ctx, logging.Describe, obj, _err)
+	}()
+	ref, err := internal.ResolveObject(ctx, o.compilers, o.client, bindings, o.namespacer, o.describe.ObjectType, o.describe.ActionObjectSelector)
+	if err != nil {
+		return nil, err
+	}
+	obj = ref.Object()
+	internal.LogStart(ctx, logging.Describe, obj)
+	var output internal.CommandOutput
+	defer func() {
+		if sections := output.Sections(); len(sections) != 0 {
+			logging.Log(ctx, logging.Describe, logging.LogStatus, obj, color.BoldFgCyan, sections...)
+		}
+	}()
+	return nil, o.execute(ctx, ref, &output)
+}
+
+func (o *operation) execute(ctx context.Context, ref *internal.ObjectRef, output *internal.CommandOutput) error {
+	objs, err := internal.ReadObjects(ctx, o.client, ref)
+	if err != nil {
+		return err
+	}
+	if len(objs) == 0 {
+		_, err := fmt.Fprintln(&output.Stderr, internal.NoResourcesFound(ref))
+		return err
+	}
+	showEvents := o.describe.ShowEvents == nil || *o.describe.ShowEvents
+	now := time.Now()
+	for i, obj := range objs {
+		if i != 0 {
+			fmt.Fprint(&output.Stdout, "\n\n")
+		}
+		if describer, ok := o.describerFor(o.cfg, obj.GroupVersionKind().GroupKind()); ok {
+			out, err := describer.Describe(obj.GetNamespace(), obj.GetName(), kdescribe.DescriberSettings{
+				ShowEvents: showEvents,
+				ChunkSize:  chunkSize,
+			})
+			if err != nil {
+				return err
+			}
+			fmt.Fprint(&output.Stdout, out)
+			continue
+		}
+		var events []corev1.Event
+		if showEvents {
+			events, err = o.events(ctx, obj)
+			if err != nil {
+				return err
+			}
+		}
+		if err := describe(&output.Stdout, obj, events, showEvents, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *operation) events(ctx context.Context, obj unstructured.Unstructured) ([]corev1.Event, error) {
+	var list corev1.EventList
+	opts := []client.ListOption{
+		client.MatchingFields{"involvedObject.uid": string(obj.GetUID())},
+	}
+	if obj.GetNamespace() != "" {
+		opts = append(opts, client.InNamespace(obj.GetNamespace()))
+	}
+	if err := o.client.List(ctx, &list, opts...); err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
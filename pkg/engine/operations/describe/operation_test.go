@@ -0,0 +1,275 @@
+package describe
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/client"
+	tclient "github.com/kyverno/chainsaw/pkg/client/testing"
+	"github.com/kyverno/chainsaw/pkg/engine/namespacer"
+	"github.com/kyverno/chainsaw/pkg/logging"
+	"github.com/kyverno/chainsaw/pkg/mocks"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	kdescribe "k8s.io/kubectl/pkg/describe"
+)
+
+func restMapper() meta.RESTMapper {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, meta.RESTScopeNamespace)
+	return mapper
+}
+
+func Test_operation(t *testing.T) {
+	configMap := unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]any{
+				"name":      "test-cm",
+				"namespace": "foo",
+				"uid":       "1234",
+				"labels": map[string]any{
+					"app": "test",
+				},
+			},
+			"data": map[string]any{
+				"fooBar": "baz",
+			},
+		},
+	}
+	tests := []struct {
+		name         string
+		client       client.Client
+		describe     v1alpha1.Describe
+		describerFor describerFactory
+		expectedErr  error
+		expectedLogs []string
+	}{{
+		name: "not found",
+		client: &tclient.FakeClient{
+			RESTMapperFn: func(int) meta.RESTMapper { return restMapper() },
+			ListFn: func(_ context.Context, _ int, _ client.ObjectList, _ ...client.ListOption) error {
+				return nil
+			},
+		},
+		describe: v1alpha1.Describe{
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType: v1alpha1.ObjectType{APIVersion: "v1", Kind: "ConfigMap"},
+			},
+		},
+		expectedLogs: []string{
+			"DESCRIBE: RUN - []",
+			"DESCRIBE: LOG - [=== STDERR\nNo resources found in foo namespace.]",
+			"DESCRIBE: DONE - []",
+		},
+	}, {
+		name: "list error",
+		client: &tclient.FakeClient{
+			RESTMapperFn: func(int) meta.RESTMapper { return restMapper() },
+			ListFn: func(_ context.Context, _ int, _ client.ObjectList, _ ...client.ListOption) error {
+				return errors.New("failed to list")
+			},
+		},
+		describe: v1alpha1.Describe{
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType: v1alpha1.ObjectType{APIVersion: "v1", Kind: "ConfigMap"},
+			},
+		},
+		expectedErr: errors.New("failed to list"),
+		expectedLogs: []string{
+			"DESCRIBE: RUN - []",
+			"DESCRIBE: ERROR - [=== ERROR\nfailed to list]",
+		},
+	}, {
+		name: "with events",
+		client: &tclient.FakeClient{
+			RESTMapperFn: func(int) meta.RESTMapper { return restMapper() },
+			GetFn: func(_ context.Context, _ int, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+				assert.Equal(t, client.ObjectKey{Namespace: "foo", Name: "test-cm"}, key)
+				*obj.(*unstructured.Unstructured) = *configMap.DeepCopy()
+				return nil
+			},
+			ListFn: func(_ context.Context, _ int, list client.ObjectList, _ ...client.ListOption) error {
+				list.(*corev1.EventList).Items = []corev1.Event{{
+					Type:    "Normal",
+					Reason:  "Created",
+					Message: "config map created",
+					Source:  corev1.EventSource{Component: "test"},
+				}}
+				return nil
+			},
+		},
+		describe: v1alpha1.Describe{
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType: v1alpha1.ObjectType{APIVersion: "v1", Kind: "ConfigMap"},
+				ActionObjectSelector: v1alpha1.ActionObjectSelector{
+					ObjectName: v1alpha1.ObjectName{Name: "test-cm"},
+				},
+			},
+		},
+		expectedLogs: []string{
+			"DESCRIBE: RUN - []",
+			"DESCRIBE: LOG - [=== STDOUT\n" +
+				"Name:         test-cm\n" +
+				"Namespace:    foo\n" +
+				"Labels:       app=test\n" +
+				"Annotations:  <none>\n" +
+				"API Version:  v1\n" +
+				"Data:\n" +
+				"  Foo Bar:  baz\n" +
+				"Kind:       ConfigMap\n" +
+				"Metadata:\n" +
+				"  UID:  1234\n" +
+				"Events:\n" +
+				"  Type    Reason   Age        From  Message\n" +
+				"  ----    ------   ----       ----  -------\n" +
+				"  Normal  Created  <unknown>  test  config map created]",
+			"DESCRIBE: DONE - []",
+		},
+	}, {
+		name: "with kubectl describer",
+		client: &tclient.FakeClient{
+			RESTMapperFn: func(int) meta.RESTMapper { return restMapper() },
+			GetFn: func(_ context.Context, _ int, _ client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+				*obj.(*unstructured.Unstructured) = *configMap.DeepCopy()
+				return nil
+			},
+		},
+		describe: v1alpha1.Describe{
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType: v1alpha1.ObjectType{APIVersion: "v1", Kind: "ConfigMap"},
+				ActionObjectSelector: v1alpha1.ActionObjectSelector{
+					ObjectName: v1alpha1.ObjectName{Name: "test-cm"},
+				},
+			},
+			ShowEvents: new(false),
+		},
+		describerFor: func(_ *rest.Config, kind schema.GroupKind) (kdescribe.ResourceDescriber, bool) {
+			assert.Equal(t, schema.GroupKind{Kind: "ConfigMap"}, kind)
+			return fakeDescriber(func(namespace, name string, settings kdescribe.DescriberSettings) (string, error) {
+				assert.False(t, settings.ShowEvents)
+				return "Name: " + name + "\nNamespace: " + namespace + "\n", nil
+			}), true
+		},
+		expectedLogs: []string{
+			"DESCRIBE: RUN - []",
+			"DESCRIBE: LOG - [=== STDOUT\nName: test-cm\nNamespace: foo]",
+			"DESCRIBE: DONE - []",
+		},
+	}, {
+		name: "kubectl describer error",
+		client: &tclient.FakeClient{
+			RESTMapperFn: func(int) meta.RESTMapper { return restMapper() },
+			GetFn: func(_ context.Context, _ int, _ client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+				*obj.(*unstructured.Unstructured) = *configMap.DeepCopy()
+				return nil
+			},
+		},
+		describe: v1alpha1.Describe{
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType: v1alpha1.ObjectType{APIVersion: "v1", Kind: "ConfigMap"},
+				ActionObjectSelector: v1alpha1.ActionObjectSelector{
+					ObjectName: v1alpha1.ObjectName{Name: "test-cm"},
+				},
+			},
+		},
+		describerFor: func(*rest.Config, schema.GroupKind) (kdescribe.ResourceDescriber, bool) {
+			return fakeDescriber(func(string, string, kdescribe.DescriberSettings) (string, error) {
+				return "", errors.New("failed to describe")
+			}), true
+		},
+		expectedErr: errors.New("failed to describe"),
+		expectedLogs: []string{
+			"DESCRIBE: RUN - []",
+			"DESCRIBE: ERROR - [=== ERROR\nfailed to describe]",
+		},
+	}, {
+		name: "without events",
+		client: &tclient.FakeClient{
+			RESTMapperFn: func(int) meta.RESTMapper { return restMapper() },
+			GetFn: func(_ context.Context, _ int, _ client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+				*obj.(*unstructured.Unstructured) = *configMap.DeepCopy()
+				return nil
+			},
+		},
+		describe: v1alpha1.Describe{
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType: v1alpha1.ObjectType{APIVersion: "v1", Kind: "ConfigMap"},
+				ActionObjectSelector: v1alpha1.ActionObjectSelector{
+					ObjectName: v1alpha1.ObjectName{Name: "test-cm"},
+				},
+			},
+			ShowEvents: new(false),
+		},
+		expectedLogs: []string{
+			"DESCRIBE: RUN - []",
+			"DESCRIBE: LOG - [=== STDOUT\n" +
+				"Name:         test-cm\n" +
+				"Namespace:    foo\n" +
+				"Labels:       app=test\n" +
+				"Annotations:  <none>\n" +
+				"API Version:  v1\n" +
+				"Data:\n" +
+				"  Foo Bar:  baz\n" +
+				"Kind:       ConfigMap\n" +
+				"Metadata:\n" +
+				"  UID:  1234]",
+			"DESCRIBE: DONE - []",
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			operation := New(apis.DefaultCompilers, nil, tt.client, namespacer.New("foo"), tt.describe).(*operation)
+			if tt.describerFor != nil {
+				operation.describerFor = tt.describerFor
+			}
+			logger := &mocks.Logger{}
+			outputs, err := operation.Exec(logging.WithLogger(ctx, logger), nil)
+			assert.Nil(t, outputs)
+			if tt.expectedErr != nil {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedLogs, logger.Logs)
+		})
+	}
+}
+
+type fakeDescriber func(string, string, kdescribe.DescriberSettings) (string, error)
+
+func (d fakeDescriber) Describe(namespace, name string, settings kdescribe.DescriberSettings) (string, error) {
+	return d(namespace, name, settings)
+}
+
+func Test_smartLabel(t *testing.T) {
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{key: "name", want: "Name"},
+		{key: "creationTimestamp", want: "Creation Timestamp"},
+		{key: "apiVersion", want: "API Version"},
+		{key: "podIPs", want: "Pod IPs"},
+		{key: "hostIP", want: "Host IP"},
+		{key: "uid", want: "UID"},
+		{key: "httpServer", want: "Http Server"},
+		{key: "URLPath", want: "URL Path"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			assert.Equal(t, tt.want, smartLabel(tt.key))
+		})
+	}
+}
@@ -4,11 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/kyverno/chainsaw/pkg/apis"
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
 	"github.com/kyverno/chainsaw/pkg/client"
 	"github.com/kyverno/chainsaw/pkg/engine/checks"
+	"github.com/kyverno/chainsaw/pkg/engine/informers"
 	"github.com/kyverno/chainsaw/pkg/engine/namespacer"
 	"github.com/kyverno/chainsaw/pkg/engine/operations"
 	"github.com/kyverno/chainsaw/pkg/engine/operations/internal"
@@ -19,15 +21,17 @@ import (
 	"go.uber.org/multierr"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/apimachinery/pkg/util/wait"
 )
 
 type operation struct {
-	compilers  compilers.Compilers
-	client     client.Client
-	base       unstructured.Unstructured
-	namespacer namespacer.Namespacer
-	template   bool
+	compilers    compilers.Compilers
+	client       client.Client
+	base         unstructured.Unstructured
+	namespacer   namespacer.Namespacer
+	template     bool
+	informers    informers.Informers
+	consistently time.Duration
+	matchMode    *v1alpha1.MatchMode
 }
 
 func New(
@@ -36,13 +40,19 @@ func New(
 	expected unstructured.Unstructured,
 	namespacer namespacer.Namespacer,
 	template bool,
+	informers informers.Informers,
+	consistently time.Duration,
+	matchMode *v1alpha1.MatchMode,
 ) operations.Operation {
 	return &operation{
-		compilers:  compilers,
-		client:     client,
-		base:       expected,
-		namespacer: namespacer,
-		template:   template,
+		compilers:    compilers,
+		client:       client,
+		base:         expected,
+		namespacer:   namespacer,
+		template:     template,
+		informers:    informers,
+		consistently: consistently,
+		matchMode:    matchMode,
 	}
 }
 
@@ -67,13 +77,21 @@ func (o *operation) Exec(ctx context.Context, bindings apis.Bindings) (_ outputs
 			return nil, err
 		}
 	}
-	internal.LogStart(ctx, logging.Error, &obj)
+	if err := internal.ValidateMatchMode(o.matchMode); err != nil {
+		return nil, err
+	}
+	if o.consistently != 0 {
+		internal.LogStart(ctx, logging.Error, &obj, logging.Section("CONSISTENTLY", o.consistently.String()))
+	} else {
+		internal.LogStart(ctx, logging.Error, &obj)
+	}
 	return nil, o.execute(ctx, bindings, obj)
 }
 
 func (o *operation) execute(ctx context.Context, bindings apis.Bindings, obj unstructured.Unstructured) error {
+	source := internal.WatchSource(ctx, logging.Error, o.client, o.informers, &obj)
 	var lastErrs []error
-	err := wait.PollUntilContextCancel(ctx, client.PollInterval, false, func(ctx context.Context) (_ bool, err error) {
+	condition := func(ctx context.Context) (_ bool, err error) {
 		var errs []error
 		defer func() {
 			// record last errors only if there was no real error
@@ -86,33 +104,50 @@ func (o *operation) execute(ctx context.Context, bindings apis.Bindings, obj uns
 			if err != nil {
 				return false, err
 			}
+			matched := 0
 			if len(_errs) == 0 {
-				errs = append(errs, fmt.Errorf("expectation matched"))
+				matched = 1
+			}
+			// the expectation must not hold
+			if internal.CheckMatchMode(o.matchMode, matched, 1) != nil {
+				return true, nil
 			}
-			return len(errs) == 0, nil
+			errs = append(errs, fmt.Errorf("expectation matched"))
+			return false, nil
 		} else {
-			if candidates, err := internal.Read(ctx, &obj, o.client); err != nil {
-				if kerrors.IsNotFound(err) {
-					return true, nil
-				}
+			candidates, err := internal.ReadFrom(ctx, source, &obj, o.client)
+			if err != nil && !kerrors.IsNotFound(err) {
 				return false, err
-			} else if len(candidates) == 0 {
-				return true, nil
-			} else {
-				for i := range candidates {
-					candidate := candidates[i]
-					_errs, err := checks.Check(ctx, o.compilers, candidate.UnstructuredContent(), bindings, new(v1alpha1.NewCheck(obj.UnstructuredContent())))
-					if err != nil {
-						return false, err
-					}
-					if len(_errs) == 0 {
-						errs = append(errs, fmt.Errorf("%s/%s/%s - resource matches expectation", candidate.GetAPIVersion(), candidate.GetKind(), client.Name(client.Key(&candidate))))
-					}
+			}
+			matched := 0
+			for i := range candidates {
+				candidate := candidates[i]
+				_errs, err := checks.Check(ctx, o.compilers, candidate.UnstructuredContent(), bindings, new(v1alpha1.NewCheck(obj.UnstructuredContent())))
+				if err != nil {
+					return false, err
 				}
-				return len(errs) == 0, nil
+				if len(_errs) == 0 {
+					matched++
+					errs = append(errs, fmt.Errorf("%s/%s/%s - resource matches expectation", candidate.GetAPIVersion(), candidate.GetKind(), client.Name(client.Key(&candidate))))
+				}
+			}
+			// the expectation must not hold
+			if internal.CheckMatchMode(o.matchMode, matched, len(candidates)) != nil {
+				errs = nil
+				return true, nil
 			}
+			return false, nil
 		}
-	})
+	}
+	if o.consistently != 0 {
+		err := internal.Consistently(ctx, source, o.consistently, condition)
+		// report the evaluation that didn't hold
+		if errors.Is(err, internal.ErrInconsistent) && len(lastErrs) != 0 {
+			return multierr.Combine(lastErrs...)
+		}
+		return err
+	}
+	err := internal.Evaluate(ctx, source, condition)
 	// if no error, return success
 	if err == nil {
 		return nil
@@ -8,16 +8,21 @@ import (
 	"time"
 
 	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
 	"github.com/kyverno/chainsaw/pkg/client"
 	tclient "github.com/kyverno/chainsaw/pkg/client/testing"
+	"github.com/kyverno/chainsaw/pkg/engine/informers"
+	tinformers "github.com/kyverno/chainsaw/pkg/engine/informers/testing"
 	"github.com/kyverno/chainsaw/pkg/engine/namespacer"
 	tnamespacer "github.com/kyverno/chainsaw/pkg/engine/namespacer/testing"
 	"github.com/kyverno/chainsaw/pkg/logging"
 	"github.com/kyverno/chainsaw/pkg/mocks"
 	"github.com/stretchr/testify/assert"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 func Test_operationError(t *testing.T) {
@@ -214,6 +219,9 @@ func Test_operationError(t *testing.T) {
 				tt.expected,
 				nspacer,
 				false,
+				nil,
+				0,
+				nil,
 			)
 			logger := &mocks.Logger{}
 			outputs, err := operation.Exec(logging.WithLogger(ctx, logger), nil)
@@ -227,3 +235,226 @@ func Test_operationError(t *testing.T) {
 		})
 	}
 }
+
+func Test_operationError_watch(t *testing.T) {
+	expected := unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata": map[string]any{
+				"namespace": "foo",
+				"name":      "test-pod",
+			},
+		},
+	}
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, meta.RESTScopeNamespace)
+	notFound := kerrors.NewNotFound(schema.GroupResource{Resource: "pods"}, "test-pod")
+	tests := []struct {
+		name         string
+		client       *tclient.FakeClient
+		source       *tinformers.FakeSource
+		sourceErr    error
+		expectedErr  error
+		expectedLogs []string
+	}{{
+		name: "evaluated on change",
+		client: &tclient.FakeClient{
+			RESTMapperFn: func(int) meta.RESTMapper { return mapper },
+		},
+		source: &tinformers.FakeSource{
+			ReadFn: func(call int, _ client.Object) ([]unstructured.Unstructured, error) {
+				if call == 0 {
+					return []unstructured.Unstructured{expected}, nil
+				}
+				return nil, notFound
+			},
+			Changes: make(chan struct{}, 1),
+		},
+		expectedLogs: []string{"ERROR: RUN - []", "ERROR: DONE - []"},
+	}, {
+		name: "fallback to polling",
+		client: &tclient.FakeClient{
+			RESTMapperFn: func(int) meta.RESTMapper { return mapper },
+			GetFn: func(_ context.Context, _ int, _ client.ObjectKey, _ client.Object, _ ...client.GetOption) error {
+				return notFound
+			},
+		},
+		sourceErr:    errors.New("forbidden"),
+		expectedLogs: []string{"ERROR: RUN - []", "ERROR: WARN - [=== WATCH\nfalling back to polling: forbidden]", "ERROR: DONE - []"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+			defer cancel()
+			if tt.source != nil && tt.source.Changes != nil {
+				tt.source.Changes <- struct{}{}
+			}
+			informers := &tinformers.FakeInformers{
+				SourceFn: func(_ context.Context, _ int, mapping *meta.RESTMapping, namespace string) (informers.Source, error) {
+					assert.Equal(t, "pods", mapping.Resource.Resource)
+					assert.Equal(t, "foo", namespace)
+					if tt.sourceErr != nil {
+						return nil, tt.sourceErr
+					}
+					return tt.source, nil
+				},
+			}
+			operation := New(apis.DefaultCompilers, tt.client, expected, nil, false, informers, 0, nil)
+			logger := &mocks.Logger{}
+			outputs, err := operation.Exec(logging.WithLogger(ctx, logger), nil)
+			assert.Nil(t, outputs)
+			if tt.expectedErr != nil {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedLogs, logger.Logs)
+			if tt.source != nil {
+				assert.Equal(t, 2, tt.source.NumCalls())
+			}
+		})
+	}
+}
+
+func Test_operationError_consistently(t *testing.T) {
+	expected := unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata": map[string]any{
+				"namespace": "foo",
+				"name":      "test-pod",
+			},
+		},
+	}
+	notFound := kerrors.NewNotFound(schema.GroupResource{Resource: "pods"}, "test-pod")
+	tests := []struct {
+		name         string
+		client       *tclient.FakeClient
+		expectedErr  error
+		expectedLogs []string
+	}{{
+		name: "holds",
+		client: &tclient.FakeClient{
+			GetFn: func(_ context.Context, _ int, _ client.ObjectKey, _ client.Object, _ ...client.GetOption) error {
+				return notFound
+			},
+		},
+		expectedLogs: []string{"ERROR: RUN - [=== CONSISTENTLY\n300ms]", "ERROR: DONE - []"},
+	}, {
+		name: "doesn't hold",
+		client: &tclient.FakeClient{
+			GetFn: func(_ context.Context, call int, _ client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+				if call < 2 {
+					return notFound
+				}
+				*obj.(*unstructured.Unstructured) = expected
+				return nil
+			},
+		},
+		expectedErr:  errors.New("v1/Pod/foo/test-pod - resource matches expectation"),
+		expectedLogs: []string{"ERROR: RUN - [=== CONSISTENTLY\n300ms]", "ERROR: ERROR - [=== ERROR\nv1/Pod/foo/test-pod - resource matches expectation]"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			operation := New(apis.DefaultCompilers, tt.client, expected, nil, false, nil, 300*time.Millisecond, nil)
+			logger := &mocks.Logger{}
+			start := time.Now()
+			outputs, err := operation.Exec(logging.WithLogger(ctx, logger), nil)
+			assert.Nil(t, outputs)
+			if tt.expectedErr != nil {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+				// the operation fails as soon as the check doesn't hold
+				assert.Less(t, time.Since(start), 300*time.Millisecond)
+			} else {
+				assert.NoError(t, err)
+				assert.GreaterOrEqual(t, time.Since(start), 300*time.Millisecond)
+			}
+			assert.Equal(t, tt.expectedLogs, logger.Logs)
+		})
+	}
+}
+
+func Test_operationError_matchMode(t *testing.T) {
+	expected := unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata": map[string]any{
+				"namespace": "test-ns",
+			},
+			"status": map[string]any{
+				"phase": "Running",
+			},
+		},
+	}
+	pod := func(name, phase string) unstructured.Unstructured {
+		return unstructured.Unstructured{
+			Object: map[string]any{
+				"apiVersion": "v1",
+				"kind":       "Pod",
+				"metadata": map[string]any{
+					"name":      name,
+					"namespace": "test-ns",
+				},
+				"status": map[string]any{
+					"phase": phase,
+				},
+			},
+		}
+	}
+	fakeClient := &tclient.FakeClient{
+		ListFn: func(_ context.Context, _ int, list client.ObjectList, _ ...client.ListOption) error {
+			uList := list.(*unstructured.UnstructuredList)
+			uList.Items = append(uList.Items, pod("pod-a", "Running"), pod("pod-b", "Pending"), pod("pod-c", "Running"))
+			return nil
+		},
+	}
+	tests := []struct {
+		name      string
+		matchMode *v1alpha1.MatchMode
+		expectErr []string
+	}{{
+		name:      "any",
+		expectErr: []string{"v1/Pod/test-ns/pod-a - resource matches expectation", "v1/Pod/test-ns/pod-c - resource matches expectation"},
+	}, {
+		name:      "all",
+		matchMode: &v1alpha1.MatchMode{Policy: v1alpha1.MatchPolicyAll},
+	}, {
+		name:      "exactly",
+		matchMode: &v1alpha1.MatchMode{Policy: v1alpha1.MatchPolicyExactly, Count: new(2)},
+		expectErr: []string{"v1/Pod/test-ns/pod-a - resource matches expectation"},
+	}, {
+		name:      "exactly with another count",
+		matchMode: &v1alpha1.MatchMode{Policy: v1alpha1.MatchPolicyExactly, Count: new(1)},
+	}, {
+		name:      "at least",
+		matchMode: &v1alpha1.MatchMode{Policy: v1alpha1.MatchPolicyAtLeast, Count: new(3)},
+	}, {
+		name:      "invalid",
+		matchMode: &v1alpha1.MatchMode{Policy: "Some"},
+		expectErr: []string{"unsupported match policy Some"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+			defer cancel()
+			operation := New(apis.DefaultCompilers, fakeClient, expected, nil, false, nil, 0, tt.matchMode)
+			outputs, err := operation.Exec(logging.WithLogger(ctx, &mocks.Logger{}), nil)
+			assert.Nil(t, outputs)
+			if len(tt.expectErr) != 0 {
+				assert.Error(t, err)
+				if err != nil {
+					for _, expectErr := range tt.expectErr {
+						assert.Contains(t, err.Error(), expectErr)
+					}
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
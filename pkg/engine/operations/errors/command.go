@@ -0,0 +1,51 @@
+package errors
+
+import (
+	"errors"
+	"os/exec"
+
+	"github.com/kyverno/chainsaw/pkg/model"
+	utilexec "k8s.io/client-go/util/exec"
+)
+
+type commandError struct {
+	err    error
+	stdout string
+	stderr string
+}
+
+// CommandError wraps an error returned by a command, script or exec with the command output.
+// The error message is not modified, the output and exit code are exposed as structured failure details.
+func CommandError(err error, stdout string, stderr string) error {
+	if err == nil {
+		return nil
+	}
+	return commandError{
+		err:    err,
+		stdout: stdout,
+		stderr: stderr,
+	}
+}
+
+func (e commandError) Error() string {
+	return e.err.Error()
+}
+
+func (e commandError) Unwrap() error {
+	return e.err
+}
+
+func (e commandError) Failure() model.Failure {
+	failure := model.Failure{
+		Stdout: e.stdout,
+		Stderr: e.stderr,
+	}
+	var exitErr *exec.ExitError
+	var remoteExitErr utilexec.ExitError
+	if errors.As(e.err, &exitErr) {
+		failure.ExitCode = new(exitErr.ExitCode())
+	} else if errors.As(e.err, &remoteExitErr) {
+		failure.ExitCode = new(remoteExitErr.ExitStatus())
+	}
+	return failure
+}
@@ -0,0 +1,38 @@
+package errors
+
+import (
+	"errors"
+	"os/exec"
+	"testing"
+
+	"github.com/kyverno/chainsaw/pkg/model"
+	"github.com/stretchr/testify/assert"
+	utilexec "k8s.io/client-go/util/exec"
+)
+
+func TestCommandError(t *testing.T) {
+	assert.NoError(t, CommandError(nil, "out", "err"))
+	{
+		inner := errors.New("check failed")
+		err := CommandError(inner, "out", "err")
+		assert.EqualError(t, err, "check failed")
+		assert.ErrorIs(t, err, inner)
+		var failureErr model.FailureError
+		assert.ErrorAs(t, err, &failureErr)
+		assert.Equal(t, model.Failure{Stdout: "out", Stderr: "err"}, failureErr.Failure())
+	}
+	{
+		inner := exec.Command("sh", "-c", "exit 3").Run()
+		err := CommandError(inner, "", "boom")
+		var failureErr model.FailureError
+		assert.ErrorAs(t, err, &failureErr)
+		assert.Equal(t, model.Failure{Stderr: "boom", ExitCode: new(3)}, failureErr.Failure())
+	}
+	{
+		inner := utilexec.CodeExitError{Err: errors.New("command terminated with exit code 2"), Code: 2}
+		err := CommandError(inner, "", "boom")
+		var failureErr model.FailureError
+		assert.ErrorAs(t, err, &failureErr)
+		assert.Equal(t, model.Failure{Stderr: "boom", ExitCode: new(2)}, failureErr.Failure())
+	}
+}
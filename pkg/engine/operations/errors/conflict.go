@@ -0,0 +1,54 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type conflictError struct {
+	err       error
+	conflicts []metav1.StatusCause
+}
+
+// ConflictError makes server-side apply field manager conflicts readable, it lists conflicting fields and the managers owning them.
+// Other errors are returned unchanged.
+func ConflictError(err error) error {
+	if err == nil || !kerrors.IsConflict(err) {
+		return err
+	}
+	var status kerrors.APIStatus
+	if !errors.As(err, &status) || status.Status().Details == nil {
+		return err
+	}
+	var conflicts []metav1.StatusCause
+	for _, cause := range status.Status().Details.Causes {
+		if cause.Type == metav1.CauseTypeFieldManagerConflict {
+			conflicts = append(conflicts, cause)
+		}
+	}
+	if len(conflicts) == 0 {
+		return err
+	}
+	return conflictError{
+		err:       err,
+		conflicts: conflicts,
+	}
+}
+
+func (e conflictError) Error() string {
+	lines := []string{
+		fmt.Sprintf("server-side apply failed with %d conflict(s), set force to take ownership of the conflicting fields", len(e.conflicts)),
+	}
+	for _, conflict := range e.conflicts {
+		lines = append(lines, fmt.Sprintf("* %s: %s", conflict.Field, conflict.Message))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (e conflictError) Unwrap() error {
+	return e.err
+}
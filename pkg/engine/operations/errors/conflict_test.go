@@ -0,0 +1,37 @@
+package errors
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestConflictError(t *testing.T) {
+	assert.NoError(t, ConflictError(nil))
+	{
+		err := errors.New("dummy")
+		assert.Equal(t, err, ConflictError(err))
+	}
+	{
+		err := kerrors.NewConflict(schema.GroupResource{Resource: "configmaps"}, "foo", errors.New("dummy"))
+		assert.Equal(t, err, ConflictError(err))
+	}
+	{
+		err := kerrors.NewApplyConflict([]metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldManagerConflict,
+			Message: `conflict with "kubectl"`,
+			Field:   ".data.foo",
+		}, {
+			Type:    metav1.CauseTypeFieldManagerConflict,
+			Message: `conflict with "helm" using v1`,
+			Field:   ".data.bar",
+		}}, "Apply failed with 2 conflicts")
+		got := ConflictError(err)
+		assert.ErrorIs(t, got, err)
+		assert.EqualError(t, got, "server-side apply failed with 2 conflict(s), set force to take ownership of the conflicting fields\n* .data.foo: conflict with \"kubectl\"\n* .data.bar: conflict with \"helm\" using v1")
+	}
+}
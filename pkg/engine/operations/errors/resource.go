@@ -10,6 +10,7 @@ import (
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
 	"github.com/kyverno/chainsaw/pkg/client"
 	"github.com/kyverno/chainsaw/pkg/engine/templating"
+	"github.com/kyverno/chainsaw/pkg/model"
 	diffutils "github.com/kyverno/chainsaw/pkg/utils/diff"
 	"github.com/kyverno/kyverno-json/pkg/core/compilers"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -56,6 +57,37 @@ func (e resourceError) Error() string {
 		slices.Sort(errLines)
 		lines = append(lines, errLines...)
 	}
+	diff, templateErr, err := e.diff()
+	if templateErr != nil {
+		lines = append(lines, fmt.Sprintf("* ERROR: failed to compute expected template: %s", templateErr))
+	}
+	if err != nil {
+		lines = append(lines, fmt.Sprintf("* %s", err))
+	} else {
+		lines = append(lines, "", diff)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (e resourceError) Failure() model.Failure {
+	failure := model.Failure{
+		Resource: &model.ResourceReference{
+			APIVersion: e.actual.GetAPIVersion(),
+			Kind:       e.actual.GetKind(),
+			Namespace:  e.actual.GetNamespace(),
+			Name:       e.actual.GetName(),
+		},
+		FieldErrors: e.errs,
+	}
+	if diff, _, err := e.diff(); err == nil {
+		failure.Diff = diff
+	}
+	return failure
+}
+
+// diff computes the diff between the expected and actual resources.
+// If the expected resource is a template that can't be computed, the raw expected resource is used and the template error is returned.
+func (e resourceError) diff() (string, error, error) {
 	expected := e.expected
 	var templateErr error
 	if e.template {
@@ -66,14 +98,6 @@ func (e resourceError) Error() string {
 			expected = merged
 		}
 	}
-	if templateErr != nil {
-		lines = append(lines, fmt.Sprintf("* ERROR: failed to compute expected template: %s", templateErr))
-	}
 	diff, err := diffutils.PrettyDiff(expected, *e.actual.DeepCopy())
-	if err != nil {
-		lines = append(lines, fmt.Sprintf("* %s", err))
-	} else {
-		lines = append(lines, "", diff)
-	}
-	return strings.Join(lines, "\n")
+	return diff, templateErr, err
 }
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/model"
 	"github.com/stretchr/testify/assert"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -73,6 +74,43 @@ func TestResourceError(t *testing.T) {
 	assert.Contains(t, errMsg, "key1:")
 }
 
+func TestResourceErrorFailure(t *testing.T) {
+	expected := unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]any{
+				"name":      "test-config",
+				"namespace": "default",
+			},
+			"data": map[string]any{
+				"key1": "expected-value",
+			},
+		},
+	}
+	actual := *expected.DeepCopy()
+	actual.Object["data"] = map[string]any{
+		"key1": "actual-value",
+	}
+	errorList := field.ErrorList{
+		field.Invalid(field.NewPath("data", "key1"), "actual-value", "expected 'expected-value'"),
+	}
+	err := ResourceError(apis.DefaultCompilers, expected, actual, false, apis.NewBindings(), errorList)
+	var failureErr model.FailureError
+	assert.ErrorAs(t, err, &failureErr)
+	failure := failureErr.Failure()
+	assert.Equal(t, &model.ResourceReference{
+		APIVersion: "v1",
+		Kind:       "ConfigMap",
+		Namespace:  "default",
+		Name:       "test-config",
+	}, failure.Resource)
+	assert.Equal(t, errorList, failure.FieldErrors)
+	assert.Contains(t, failure.Diff, "-  key1: expected-value")
+	assert.Contains(t, failure.Diff, "+  key1: actual-value")
+	assert.Contains(t, err.Error(), failure.Diff)
+}
+
 func TestResourceErrorWithTemplating(t *testing.T) {
 	// Create test objects
 	expected := unstructured.Unstructured{
@@ -0,0 +1,221 @@
+package exec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/client"
+	apibindings "github.com/kyverno/chainsaw/pkg/engine/bindings"
+	"github.com/kyverno/chainsaw/pkg/engine/checks"
+	"github.com/kyverno/chainsaw/pkg/engine/namespacer"
+	"github.com/kyverno/chainsaw/pkg/engine/operations"
+	operrors "github.com/kyverno/chainsaw/pkg/engine/operations/errors"
+	"github.com/kyverno/chainsaw/pkg/engine/operations/internal"
+	"github.com/kyverno/chainsaw/pkg/engine/outputs"
+	"github.com/kyverno/chainsaw/pkg/logging"
+	"github.com/kyverno/kyverno-json/pkg/core/compilers"
+	"github.com/kyverno/pkg/ext/output/color"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// defaultContainerAnnotation is the annotation used by kubectl to select the default container of a pod.
+const defaultContainerAnnotation = "kubectl.kubernetes.io/default-container"
+
+type executorFactory = func(*rest.Config, *url.URL) (remotecommand.Executor, error)
+
+type operation struct {
+	compilers   compilers.Compilers
+	cfg         *rest.Config
+	namespacer  namespacer.Namespacer
+	exec        v1alpha1.Exec
+	newExecutor executorFactory
+}
+
+func New(
+	compilers compilers.Compilers,
+	cfg *rest.Config,
+	namespacer namespacer.Namespacer,
+	exec v1alpha1.Exec,
+) operations.Operation {
+	return &operation{
+		compilers:   compilers,
+		cfg:         cfg,
+		namespacer:  namespacer,
+		exec:        exec,
+		newExecutor: newExecutor,
+	}
+}
+
+// newExecutor creates an executor using websockets, falling back to SPDY when websockets are not supported (same as kubectl).
+func newExecutor(cfg *rest.Config, url *url.URL) (remotecommand.Executor, error) {
+	spdy, err := remotecommand.NewSPDYExecutor(cfg, "POST", url)
+	if err != nil {
+		return nil, err
+	}
+	websocket, err := remotecommand.NewWebSocketExecutor(cfg, "GET", url.String())
+	if err != nil {
+		return nil, err
+	}
+	return remotecommand.NewFallbackExecutor(websocket, spdy, func(err error) bool {
+		return httpstream.IsUpgradeFailure(err) || httpstream.IsHTTPSProxyError(err)
+	})
+}
+
+func (o *operation) Exec(ctx context.Context, bindings apis.Bindings) (_ outputs.Outputs, _err error) {
+	if bindings == nil {
+		bindings = apis.NewBindings()
+	}
+	var obj client.Object
+	defer func() {
+		internal.LogEnd(ctx, logging.Exec, obj, _err)
+	}()
+	if o.cfg == nil {
+		return nil, errors.New("cluster config not set")
+	}
+	if len(o.exec.Command) == 0 {
+		return nil, errors.New("a command must be specified")
+	}
+	name, err := o.exec.Name.Value(ctx, o.compilers, bindings)
+	if err != nil {
+		return nil, err
+	}
+	namespace, err := o.exec.Namespace.Value(ctx, o.compilers, bindings)
+	if err != nil {
+		return nil, err
+	}
+	selector, err := o.exec.Selector.Value(ctx, o.compilers, bindings)
+	if err != nil {
+		return nil, err
+	}
+	container, err := o.exec.Container.Value(ctx, o.compilers, bindings)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" && selector == "" {
+		return nil, errors.New("a name or selector must be specified")
+	}
+	if name != "" && selector != "" {
+		return nil, errors.New("name cannot be provided when a selector is specified")
+	}
+	if namespace == "" && o.namespacer != nil {
+		namespace = o.namespacer.GetNamespace()
+	}
+	clientset, err := kubernetes.NewForConfig(o.cfg)
+	if err != nil {
+		return nil, err
+	}
+	pod, err := getPod(ctx, clientset, namespace, name, selector)
+	if err != nil {
+		return nil, err
+	}
+	if container == "" {
+		container = defaultContainer(*pod)
+	}
+	target := &unstructured.Unstructured{}
+	target.SetAPIVersion("v1")
+	target.SetKind("Pod")
+	target.SetName(pod.Name)
+	target.SetNamespace(pod.Namespace)
+	obj = target
+	var logOpts []fmt.Stringer
+	if !o.exec.SkipCommandOutput {
+		logOpts = append(logOpts, logging.Section("COMMAND", fmt.Sprintf("[%s] %s", container, strings.Join(o.exec.Command, " "))))
+	}
+	internal.LogStart(ctx, logging.Exec, obj, logOpts...)
+	req := clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(pod.Namespace).
+		Name(pod.Name).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: container,
+			Command:   o.exec.Command,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+	executor, err := o.newExecutor(o.cfg, req.URL())
+	if err != nil {
+		return nil, err
+	}
+	return o.execute(ctx, bindings, executor)
+}
+
+// getPod returns the pod with the given name, or the first running pod matching the given selector.
+func getPod(ctx context.Context, clientset kubernetes.Interface, namespace, name, selector string) (*corev1.Pod, error) {
+	if name != "" {
+		return clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	}
+	list, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, err
+	}
+	for i := range list.Items {
+		if list.Items[i].Status.Phase == corev1.PodRunning {
+			return &list.Items[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no running pod found in %s namespace matching selector %s", namespace, selector)
+}
+
+// defaultContainer returns the container designated by the default container annotation, or the first container of the pod.
+func defaultContainer(pod corev1.Pod) string {
+	if name := pod.Annotations[defaultContainerAnnotation]; name != "" {
+		return name
+	}
+	if len(pod.Spec.Containers) != 0 {
+		return pod.Spec.Containers[0].Name
+	}
+	return ""
+}
+
+func (o *operation) execute(ctx context.Context, bindings apis.Bindings, executor remotecommand.Executor) (_outputs outputs.Outputs, _err error) {
+	var output internal.CommandOutput
+	if !o.exec.SkipLogOutput {
+		defer func() {
+			if sections := output.Sections(); len(sections) != 0 {
+				logging.Log(ctx, logging.Exec, logging.LogStatus, nil, color.BoldFgCyan, sections...)
+			}
+		}()
+	}
+	err := executor.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdout: &output.Stdout,
+		Stderr: &output.Stderr,
+	})
+	bindings = apibindings.RegisterBinding(bindings, "stdout", output.Out())
+	bindings = apibindings.RegisterBinding(bindings, "stderr", output.Err())
+	if err == nil {
+		bindings = apibindings.RegisterBinding(bindings, "error", nil)
+	} else {
+		bindings = apibindings.RegisterBinding(bindings, "error", err.Error())
+	}
+	defer func(bindings apis.Bindings) {
+		if _err == nil {
+			outputs, err := outputs.Process(ctx, o.compilers, bindings, nil, o.exec.Outputs...)
+			if err != nil {
+				_err = err
+				return
+			}
+			_outputs = outputs
+		}
+	}(bindings)
+	if o.exec.Check == nil || o.exec.Check.IsNil() {
+		return nil, operrors.CommandError(err, output.Out(), output.Err())
+	}
+	if errs, err := checks.Check(ctx, o.compilers, nil, bindings, o.exec.Check); err != nil {
+		return nil, err
+	} else {
+		return nil, operrors.CommandError(errs.ToAggregate(), output.Out(), output.Err())
+	}
+}
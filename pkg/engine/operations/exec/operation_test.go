@@ -0,0 +1,237 @@
+package exec
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/engine/namespacer"
+	"github.com/kyverno/chainsaw/pkg/logging"
+	"github.com/kyverno/chainsaw/pkg/mocks"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
+)
+
+type fakeExecutor struct {
+	stdout string
+	stderr string
+	err    error
+}
+
+func (e *fakeExecutor) Stream(options remotecommand.StreamOptions) error {
+	return e.StreamWithContext(context.TODO(), options)
+}
+
+func (e *fakeExecutor) StreamWithContext(_ context.Context, options remotecommand.StreamOptions) error {
+	if _, err := io.WriteString(options.Stdout, e.stdout); err != nil {
+		return err
+	}
+	if _, err := io.WriteString(options.Stderr, e.stderr); err != nil {
+		return err
+	}
+	return e.err
+}
+
+func Test_operation(t *testing.T) {
+	pod := corev1.Pod{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-pod",
+			Namespace:   "foo",
+			Annotations: map[string]string{defaultContainerAnnotation: "sidecar"},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "main"}, {Name: "sidecar"}},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/namespaces/foo/pods/test-pod":
+			w.Header().Set("Content-Type", "application/json")
+			assert.NoError(t, json.NewEncoder(w).Encode(pod))
+		case "/api/v1/namespaces/foo/pods":
+			list := corev1.PodList{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "PodList"}}
+			if r.URL.Query().Get("labelSelector") == "app=test" {
+				list.Items = append(list.Items, pod)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			assert.NoError(t, json.NewEncoder(w).Encode(list))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	tests := []struct {
+		name          string
+		cfg           *rest.Config
+		exec          v1alpha1.Exec
+		executor      *fakeExecutor
+		wantErr       string
+		wantURL       string
+		wantOutputs   map[string]any
+		wantExitError bool
+	}{{
+		name:    "no config",
+		exec:    v1alpha1.Exec{Command: []string{"ls"}},
+		wantErr: "cluster config not set",
+	}, {
+		name:    "no command",
+		cfg:     &rest.Config{Host: server.URL},
+		exec:    v1alpha1.Exec{},
+		wantErr: "a command must be specified",
+	}, {
+		name:    "no name or selector",
+		cfg:     &rest.Config{Host: server.URL},
+		exec:    v1alpha1.Exec{Command: []string{"ls"}},
+		wantErr: "a name or selector must be specified",
+	}, {
+		name: "name and selector",
+		cfg:  &rest.Config{Host: server.URL},
+		exec: v1alpha1.Exec{
+			ActionObjectSelector: v1alpha1.ActionObjectSelector{
+				ObjectName: v1alpha1.ObjectName{Name: "test-pod"},
+				Selector:   "app=test",
+			},
+			Command: []string{"ls"},
+		},
+		wantErr: "name cannot be provided when a selector is specified",
+	}, {
+		name: "by name",
+		cfg:  &rest.Config{Host: server.URL},
+		exec: v1alpha1.Exec{
+			ActionObjectSelector: v1alpha1.ActionObjectSelector{
+				ObjectName: v1alpha1.ObjectName{Name: "test-pod"},
+			},
+			Command: []string{"cat", "/etc/hostname"},
+		},
+		executor: &fakeExecutor{stdout: "test-pod"},
+		wantURL:  "/api/v1/namespaces/foo/pods/test-pod/exec?command=cat&command=%2Fetc%2Fhostname&container=sidecar&stderr=true&stdout=true",
+	}, {
+		name: "by selector with container",
+		cfg:  &rest.Config{Host: server.URL},
+		exec: v1alpha1.Exec{
+			ActionObjectSelector: v1alpha1.ActionObjectSelector{
+				Selector: "app=test",
+			},
+			Container: "main",
+			Command:   []string{"ls"},
+		},
+		executor: &fakeExecutor{stdout: "file"},
+		wantURL:  "/api/v1/namespaces/foo/pods/test-pod/exec?command=ls&container=main&stderr=true&stdout=true",
+	}, {
+		name: "no matching pod",
+		cfg:  &rest.Config{Host: server.URL},
+		exec: v1alpha1.Exec{
+			ActionObjectSelector: v1alpha1.ActionObjectSelector{
+				Selector: "app=other",
+			},
+			Command: []string{"ls"},
+		},
+		wantErr: "no running pod found in foo namespace matching selector app=other",
+	}, {
+		name: "command failure",
+		cfg:  &rest.Config{Host: server.URL},
+		exec: v1alpha1.Exec{
+			ActionObjectSelector: v1alpha1.ActionObjectSelector{
+				ObjectName: v1alpha1.ObjectName{Name: "test-pod"},
+			},
+			Command: []string{"false"},
+		},
+		executor: &fakeExecutor{
+			stderr: "boom",
+			err:    utilexec.CodeExitError{Err: errors.New("command terminated with exit code 1"), Code: 1},
+		},
+		wantErr:       "command terminated with exit code 1",
+		wantExitError: true,
+	}, {
+		name: "command failure with check",
+		cfg:  &rest.Config{Host: server.URL},
+		exec: v1alpha1.Exec{
+			ActionObjectSelector: v1alpha1.ActionObjectSelector{
+				ObjectName: v1alpha1.ObjectName{Name: "test-pod"},
+			},
+			ActionCheck: v1alpha1.ActionCheck{
+				Check: new(v1alpha1.NewCheck(map[string]any{
+					"($error != null)": true,
+					"($stderr)":        "boom",
+				})),
+			},
+			Command: []string{"false"},
+		},
+		executor: &fakeExecutor{
+			stderr: "boom",
+			err:    utilexec.CodeExitError{Err: errors.New("command terminated with exit code 1"), Code: 1},
+		},
+	}, {
+		name: "outputs",
+		cfg:  &rest.Config{Host: server.URL},
+		exec: v1alpha1.Exec{
+			ActionObjectSelector: v1alpha1.ActionObjectSelector{
+				ObjectName: v1alpha1.ObjectName{Name: "test-pod"},
+			},
+			ActionOutputs: v1alpha1.ActionOutputs{
+				Outputs: []v1alpha1.Output{{
+					Binding: v1alpha1.Binding{
+						Name:  "hostname",
+						Value: v1alpha1.NewProjection("($stdout)"),
+					},
+				}},
+			},
+			Command: []string{"hostname"},
+		},
+		executor: &fakeExecutor{stdout: "test-pod"},
+		wantOutputs: map[string]any{
+			"hostname": "test-pod",
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := logging.WithLogger(context.TODO(), &mocks.Logger{})
+			operation := New(
+				apis.DefaultCompilers,
+				tt.cfg,
+				namespacer.New("foo"),
+				tt.exec,
+			).(*operation)
+			var gotURL *url.URL
+			operation.newExecutor = func(_ *rest.Config, url *url.URL) (remotecommand.Executor, error) {
+				gotURL = url
+				return tt.executor, nil
+			}
+			outputs, err := operation.Exec(ctx, nil)
+			if tt.wantErr != "" {
+				assert.Error(t, err)
+				if err != nil {
+					assert.Contains(t, err.Error(), tt.wantErr)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+			if tt.wantURL != "" {
+				assert.NotNil(t, gotURL)
+				if gotURL != nil {
+					assert.Equal(t, tt.wantURL, gotURL.RequestURI())
+				}
+			}
+			if tt.wantOutputs != nil {
+				assert.Equal(t, tt.wantOutputs, map[string]any(outputs))
+			}
+			if tt.wantExitError {
+				var exitErr utilexec.ExitError
+				assert.ErrorAs(t, err, &exitErr)
+			}
+		})
+	}
+}
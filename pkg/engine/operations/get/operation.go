@@ -0,0 +1,92 @@
+package get
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/client"
+	"github.com/kyverno/chainsaw/pkg/engine/namespacer"
+	"github.com/kyverno/chainsaw/pkg/engine/operations"
+	"github.com/kyverno/chainsaw/pkg/engine/operations/internal"
+	"github.com/kyverno/chainsaw/pkg/engine/outputs"
+	"github.com/kyverno/chainsaw/pkg/logging"
+	"github.com/kyverno/kyverno-json/pkg/core/compilers"
+	"github.com/kyverno/pkg/ext/output/color"
+	"k8s.io/client-go/rest"
+)
+
+type operation struct {
+	compilers  compilers.Compilers
+	client     client.Client
+	cfg        *rest.Config
+	namespacer namespacer.Namespacer
+	get        v1alpha1.Get
+}
+
+func New(
+	compilers compilers.Compilers,
+	client client.Client,
+	cfg *rest.Config,
+	namespacer namespacer.Namespacer,
+	get v1alpha1.Get,
+) operations.Operation {
+	return &operation{
+		compilers:  compilers,
+		client:     client,
+		cfg:        cfg,
+		namespacer: namespacer,
+		get:        get,
+	}
+}
+
+func (o *operation) Exec(ctx context.Context, bindings apis.Bindings) (_ outputs.Outputs, _err error) {
+	if bindings == nil {
+		bindings = apis.NewBindings()
+	}
+	var obj client.Object
+	defer func() {
+		internal.LogEnd(ctx, logging.Get, obj, _err)
+	}()
+	ref, err := internal.ResolveObject(ctx, o.compilers, o.client, bindings, o.namespacer, o.get.ObjectType, o.get.ActionObjectSelector)
+	if err != nil {
+		return nil, err
+	}
+	format, err := v1alpha1.Expression(o.get.Format).Value(ctx, o.compilers, bindings)
+	if err != nil {
+		return nil, err
+	}
+	obj = ref.Object()
+	internal.LogStart(ctx, logging.Get, obj)
+	var output internal.CommandOutput
+	defer func() {
+		if sections := output.Sections(); len(sections) != 0 {
+			logging.Log(ctx, logging.Get, logging.LogStatus, obj, color.BoldFgCyan, sections...)
+		}
+	}()
+	return nil, o.execute(ctx, ref, format, &output)
+}
+
+func (o *operation) execute(ctx context.Context, ref *internal.ObjectRef, format string, output *internal.CommandOutput) error {
+	if format != "" {
+		objs, err := internal.ReadObjects(ctx, o.client, ref)
+		if err != nil {
+			return err
+		}
+		return internal.PrintObjects(&output.Stdout, format, ref.Name != "", objs...)
+	}
+	if o.cfg == nil {
+		return errors.New("cluster config not set")
+	}
+	table, err := internal.FetchTable(ctx, o.cfg, ref)
+	if err != nil {
+		return err
+	}
+	if len(table.Rows) == 0 {
+		_, err := fmt.Fprintln(&output.Stderr, internal.NoResourcesFound(ref))
+		return err
+	}
+	return internal.PrintTable(&output.Stdout, table, ref.AllNamespaces)
+}
@@ -0,0 +1,179 @@
+package get
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/client"
+	tclient "github.com/kyverno/chainsaw/pkg/client/testing"
+	"github.com/kyverno/chainsaw/pkg/engine/namespacer"
+	"github.com/kyverno/chainsaw/pkg/logging"
+	"github.com/kyverno/chainsaw/pkg/mocks"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+)
+
+func restMapper() meta.RESTMapper {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, meta.RESTScopeNamespace)
+	return mapper
+}
+
+func pod(name string) unstructured.Unstructured {
+	return unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata": map[string]any{
+				"name":      name,
+				"namespace": "foo",
+			},
+		},
+	}
+}
+
+func Test_operation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		table := metav1.Table{
+			ColumnDefinitions: []metav1.TableColumnDefinition{{Name: "Name"}, {Name: "Status"}, {Name: "Node", Priority: 1}},
+		}
+		if r.URL.Path == "/api/v1/namespaces/foo/pods" {
+			assert.Contains(t, r.Header.Get("Accept"), "as=Table")
+			table.Rows = []metav1.TableRow{{
+				Cells:  []any{"test-pod", "Running", "node"},
+				Object: runtime.RawExtension{Raw: []byte(`{"metadata":{"name":"test-pod","namespace":"foo"}}`)},
+			}}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		assert.NoError(t, json.NewEncoder(w).Encode(table))
+	}))
+	defer server.Close()
+	tests := []struct {
+		name         string
+		client       client.Client
+		cfg          *rest.Config
+		get          v1alpha1.Get
+		expectedErr  error
+		expectedLogs []string
+	}{{
+		name: "no client",
+		get: v1alpha1.Get{
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType: v1alpha1.ObjectType{APIVersion: "v1", Kind: "Pod"},
+			},
+		},
+		expectedErr:  errors.New("cluster client not set"),
+		expectedLogs: []string{"GET: ERROR - [=== ERROR\ncluster client not set]"},
+	}, {
+		name:   "name and selector",
+		client: &tclient.FakeClient{},
+		get: v1alpha1.Get{
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType: v1alpha1.ObjectType{APIVersion: "v1", Kind: "Pod"},
+				ActionObjectSelector: v1alpha1.ActionObjectSelector{
+					ObjectName: v1alpha1.ObjectName{Name: "foo"},
+					Selector:   "app=foo",
+				},
+			},
+		},
+		expectedErr:  errors.New("name cannot be provided when a selector is specified"),
+		expectedLogs: []string{"GET: ERROR - [=== ERROR\nname cannot be provided when a selector is specified]"},
+	}, {
+		name: "with format",
+		client: &tclient.FakeClient{
+			RESTMapperFn: func(int) meta.RESTMapper { return restMapper() },
+			GetFn: func(_ context.Context, _ int, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+				assert.Equal(t, client.ObjectKey{Namespace: "foo", Name: "test-pod"}, key)
+				pod := pod("test-pod")
+				*obj.(*unstructured.Unstructured) = pod
+				return nil
+			},
+		},
+		get: v1alpha1.Get{
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType: v1alpha1.ObjectType{APIVersion: "v1", Kind: "Pod"},
+				ActionObjectSelector: v1alpha1.ActionObjectSelector{
+					ObjectName: v1alpha1.ObjectName{Name: "test-pod"},
+				},
+			},
+			ActionFormat: v1alpha1.ActionFormat{Format: "yaml"},
+		},
+		expectedLogs: []string{
+			"GET: RUN - []",
+			"GET: LOG - [=== STDOUT\napiVersion: v1\nkind: Pod\nmetadata:\n  name: test-pod\n  namespace: foo]",
+			"GET: DONE - []",
+		},
+	}, {
+		name:   "no config",
+		client: &tclient.FakeClient{RESTMapperFn: func(int) meta.RESTMapper { return restMapper() }},
+		get: v1alpha1.Get{
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType: v1alpha1.ObjectType{APIVersion: "v1", Kind: "Pod"},
+			},
+		},
+		expectedErr: errors.New("cluster config not set"),
+		expectedLogs: []string{
+			"GET: RUN - []",
+			"GET: ERROR - [=== ERROR\ncluster config not set]",
+		},
+	}, {
+		name:   "table",
+		client: &tclient.FakeClient{RESTMapperFn: func(int) meta.RESTMapper { return restMapper() }},
+		cfg:    &rest.Config{Host: server.URL},
+		get: v1alpha1.Get{
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType: v1alpha1.ObjectType{APIVersion: "v1", Kind: "Pod"},
+			},
+		},
+		expectedLogs: []string{
+			"GET: RUN - []",
+			"GET: LOG - [=== STDOUT\nNAME       STATUS\ntest-pod   Running]",
+			"GET: DONE - []",
+		},
+	}, {
+		name:   "no resources",
+		client: &tclient.FakeClient{RESTMapperFn: func(int) meta.RESTMapper { return restMapper() }},
+		cfg:    &rest.Config{Host: server.URL},
+		get: v1alpha1.Get{
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType: v1alpha1.ObjectType{APIVersion: "v1", Kind: "Pod"},
+				ActionObjectSelector: v1alpha1.ActionObjectSelector{
+					ObjectName: v1alpha1.ObjectName{Namespace: "bar"},
+				},
+			},
+		},
+		expectedLogs: []string{
+			"GET: RUN - []",
+			"GET: LOG - [=== STDERR\nNo resources found in bar namespace.]",
+			"GET: DONE - []",
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			operation := New(apis.DefaultCompilers, tt.client, tt.cfg, namespacer.New("foo"), tt.get)
+			logger := &mocks.Logger{}
+			outputs, err := operation.Exec(logging.WithLogger(ctx, logger), nil)
+			assert.Nil(t, outputs)
+			if tt.expectedErr != nil {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedLogs, logger.Logs)
+		})
+	}
+}
@@ -0,0 +1,344 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	nethttp "net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/client"
+	apibindings "github.com/kyverno/chainsaw/pkg/engine/bindings"
+	"github.com/kyverno/chainsaw/pkg/engine/checks"
+	"github.com/kyverno/chainsaw/pkg/engine/namespacer"
+	"github.com/kyverno/chainsaw/pkg/engine/operations"
+	"github.com/kyverno/chainsaw/pkg/engine/operations/internal"
+	"github.com/kyverno/chainsaw/pkg/engine/outputs"
+	"github.com/kyverno/chainsaw/pkg/engine/templating"
+	"github.com/kyverno/chainsaw/pkg/logging"
+	"github.com/kyverno/kyverno-json/pkg/core/compilers"
+	"github.com/kyverno/pkg/ext/output/color"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/rest"
+)
+
+type request struct {
+	method  string
+	url     string
+	headers map[string]string
+	body    []byte
+	json    bool
+}
+
+type response struct {
+	status  int
+	headers map[string]any
+	body    any
+	raw     string
+}
+
+func (r *response) value() map[string]any {
+	return map[string]any{
+		"status":  r.status,
+		"headers": r.headers,
+		"body":    r.body,
+	}
+}
+
+type operation struct {
+	compilers  compilers.Compilers
+	client     client.Client
+	cfg        *rest.Config
+	namespacer namespacer.Namespacer
+	basePath   string
+	http       v1alpha1.HTTP
+}
+
+func New(
+	compilers compilers.Compilers,
+	client client.Client,
+	cfg *rest.Config,
+	namespacer namespacer.Namespacer,
+	basePath string,
+	http v1alpha1.HTTP,
+) operations.Operation {
+	return &operation{
+		compilers:  compilers,
+		client:     client,
+		cfg:        cfg,
+		namespacer: namespacer,
+		basePath:   basePath,
+		http:       http,
+	}
+}
+
+func (o *operation) Exec(ctx context.Context, bindings apis.Bindings) (_ outputs.Outputs, _err error) {
+	if bindings == nil {
+		bindings = apis.NewBindings()
+	}
+	var obj client.Object
+	defer func() {
+		internal.LogEnd(ctx, logging.HTTP, obj, _err)
+	}()
+	method := o.http.Method
+	if method == "" {
+		method = nethttp.MethodGet
+	}
+	var url string
+	var httpClient *nethttp.Client
+	if o.http.Target != nil {
+		target, absPath, err := o.target(ctx, bindings)
+		if err != nil {
+			return nil, err
+		}
+		obj = target
+		if c, err := rest.HTTPClientFor(o.cfg); err != nil {
+			return nil, err
+		} else {
+			httpClient = c
+		}
+		url = strings.TrimSuffix(o.cfg.Host, "/") + absPath
+	} else {
+		value, err := o.http.URL.Value(ctx, o.compilers, bindings)
+		if err != nil {
+			return nil, err
+		}
+		if value == "" {
+			return nil, errors.New("either url or target must be specified")
+		}
+		url = value
+		if c, err := o.httpClient(); err != nil {
+			return nil, err
+		} else {
+			httpClient = c
+		}
+	}
+	req := request{
+		method:  method,
+		url:     url,
+		headers: map[string]string{},
+	}
+	for name, value := range o.http.Headers {
+		value, err := value.Value(ctx, o.compilers, bindings)
+		if err != nil {
+			return nil, err
+		}
+		req.headers[name] = value
+	}
+	if o.http.Body != nil {
+		body, err := templating.Template(ctx, o.compilers, *o.http.Body, nil, bindings)
+		if err != nil {
+			return nil, err
+		}
+		if value, ok := body.(string); ok {
+			req.body = []byte(value)
+		} else if data, err := json.Marshal(body); err != nil {
+			return nil, err
+		} else {
+			req.body = data
+			req.json = true
+		}
+	}
+	internal.LogStart(ctx, logging.HTTP, obj, logging.Section("REQUEST", method+" "+url))
+	return o.execute(ctx, bindings, httpClient, req)
+}
+
+func (o *operation) target(ctx context.Context, bindings apis.Bindings) (client.Object, string, error) {
+	if o.client == nil {
+		return nil, "", errors.New("cluster client not set")
+	}
+	if o.cfg == nil {
+		return nil, "", errors.New("cluster config not set")
+	}
+	name, err := o.http.Target.Name.Value(ctx, o.compilers, bindings)
+	if err != nil {
+		return nil, "", err
+	}
+	namespace, err := o.http.Target.Namespace.Value(ctx, o.compilers, bindings)
+	if err != nil {
+		return nil, "", err
+	}
+	targetPath, err := o.http.Target.Path.Value(ctx, o.compilers, bindings)
+	if err != nil {
+		return nil, "", err
+	}
+	targetPort, err := o.http.Target.Port.Value(ctx, o.compilers, bindings)
+	if err != nil {
+		return nil, "", err
+	}
+	mapping, err := internal.MapResource(ctx, o.compilers, o.client, bindings, o.http.Target.ObjectType)
+	if err != nil {
+		return nil, "", err
+	}
+	if namespace == "" && o.namespacer != nil {
+		namespace = o.namespacer.GetNamespace()
+	}
+	target := &unstructured.Unstructured{}
+	target.SetGroupVersionKind(mapping.GroupVersionKind)
+	target.SetName(name)
+	target.SetNamespace(namespace)
+	// the api server proxy expects [scheme:]name[:port]
+	resourceName := name
+	if scheme, port, ok := strings.Cut(targetPort, ":"); ok {
+		resourceName = scheme + ":" + name + ":" + port
+	} else if targetPort != "" {
+		resourceName = name + ":" + targetPort
+	}
+	gv := mapping.Resource.GroupVersion()
+	apiPath := path.Join("/apis", gv.Group, gv.Version)
+	if gv.Group == "" {
+		apiPath = path.Join("/api", gv.Version)
+	}
+	absPath := path.Join(apiPath, "namespaces", namespace, mapping.Resource.Resource, resourceName, "proxy", targetPath)
+	// path.Join drops the trailing slash, some servers redirect when it's missing
+	if strings.HasSuffix(targetPath, "/") {
+		absPath += "/"
+	}
+	return target, absPath, nil
+}
+
+func (o *operation) httpClient() (*nethttp.Client, error) {
+	if o.http.TLS == nil {
+		return &nethttp.Client{}, nil
+	}
+	config := &tls.Config{
+		InsecureSkipVerify: o.http.TLS.InsecureSkipVerify, //nolint:gosec
+	}
+	if o.http.TLS.CAFile != "" {
+		caFile := o.http.TLS.CAFile
+		if !filepath.IsAbs(caFile) {
+			caFile = filepath.Join(o.basePath, caFile)
+		}
+		data, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("failed to parse CA certificates from %s", o.http.TLS.CAFile)
+		}
+		config.RootCAs = pool
+	}
+	transport := nethttp.DefaultTransport.(*nethttp.Transport).Clone()
+	transport.TLSClientConfig = config
+	return &nethttp.Client{Transport: transport}, nil
+}
+
+func (o *operation) execute(ctx context.Context, bindings apis.Bindings, httpClient *nethttp.Client, req request) (outputs.Outputs, error) {
+	var lastErr error
+	var lastResponse *response
+	var outputs outputs.Outputs
+	defer func() {
+		if lastResponse != nil {
+			sections := []fmt.Stringer{logging.Section("STATUS", fmt.Sprint(lastResponse.status))}
+			if body := strings.TrimSpace(lastResponse.raw); body != "" {
+				sections = append(sections, logging.Section("BODY", body))
+			}
+			logging.Log(ctx, logging.HTTP, logging.LogStatus, nil, color.BoldFgCyan, sections...)
+		}
+	}()
+	// the request is sent until the check passes or the operation times out
+	err := wait.PollUntilContextCancel(ctx, client.PollInterval, true, func(ctx context.Context) (bool, error) {
+		attemptOutputs, attemptResponse, attemptErr := o.tryRequest(ctx, bindings, httpClient, req)
+		// keep the outcome of the previous attempt when this one was interrupted by the timeout
+		if attemptErr != nil && ctx.Err() != nil && lastErr != nil {
+			return false, nil
+		}
+		outputs, lastResponse, lastErr = attemptOutputs, attemptResponse, attemptErr
+		return lastErr == nil, nil
+	})
+	if err == nil {
+		return outputs, nil
+	}
+	if lastErr != nil {
+		return outputs, lastErr
+	}
+	return outputs, err
+}
+
+func (o *operation) tryRequest(ctx context.Context, bindings apis.Bindings, httpClient *nethttp.Client, req request) (outputs.Outputs, *response, error) {
+	resp, err := o.send(ctx, httpClient, req)
+	var value any
+	if resp != nil {
+		value = resp.value()
+		bindings = apibindings.RegisterBinding(bindings, "status", resp.status)
+		bindings = apibindings.RegisterBinding(bindings, "headers", resp.headers)
+		bindings = apibindings.RegisterBinding(bindings, "body", resp.body)
+	} else {
+		bindings = apibindings.RegisterBinding(bindings, "status", nil)
+		bindings = apibindings.RegisterBinding(bindings, "headers", nil)
+		bindings = apibindings.RegisterBinding(bindings, "body", nil)
+	}
+	if err == nil {
+		bindings = apibindings.RegisterBinding(bindings, "error", nil)
+	} else {
+		bindings = apibindings.RegisterBinding(bindings, "error", err.Error())
+	}
+	if o.http.Check == nil || o.http.Check.IsNil() {
+		if err == nil && resp.status >= nethttp.StatusBadRequest {
+			err = fmt.Errorf("unexpected status code: %d", resp.status)
+		}
+	} else if errs, checkErr := checks.Check(ctx, o.compilers, value, bindings, o.http.Check); checkErr != nil {
+		err = checkErr
+	} else {
+		err = errs.ToAggregate()
+	}
+	if err != nil {
+		return nil, resp, err
+	}
+	outputs, err := outputs.Process(ctx, o.compilers, bindings, value, o.http.Outputs...)
+	return outputs, resp, err
+}
+
+func (o *operation) send(ctx context.Context, httpClient *nethttp.Client, req request) (*response, error) {
+	var body io.Reader
+	if req.body != nil {
+		body = bytes.NewReader(req.body)
+	}
+	httpReq, err := nethttp.NewRequestWithContext(ctx, req.method, req.url, body)
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range req.headers {
+		httpReq.Header.Set(name, value)
+	}
+	if req.json && httpReq.Header.Get("Content-Type") == "" {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+	httpResp, err := httpClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+	data, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, err
+	}
+	headers := map[string]any{}
+	for name, values := range httpResp.Header {
+		headers[name] = strings.Join(values, ", ")
+	}
+	resp := &response{
+		status:  httpResp.StatusCode,
+		headers: headers,
+		body:    string(data),
+		raw:     string(data),
+	}
+	// the body is exposed as parsed JSON when possible
+	var parsed any
+	if err := json.Unmarshal(data, &parsed); err == nil {
+		resp.body = parsed
+	}
+	return resp, nil
+}
@@ -0,0 +1,283 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	nethttp "net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	tclient "github.com/kyverno/chainsaw/pkg/client/testing"
+	"github.com/kyverno/chainsaw/pkg/logging"
+	"github.com/kyverno/chainsaw/pkg/mocks"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+)
+
+func Test_operation(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		switch r.URL.Path {
+		case "/not-found":
+			w.WriteHeader(nethttp.StatusNotFound)
+		case "/eventually":
+			if calls.Add(1) < 3 {
+				w.WriteHeader(nethttp.StatusServiceUnavailable)
+				return
+			}
+			_, _ = w.Write([]byte("ready"))
+		default:
+			data, _ := io.ReadAll(r.Body)
+			var payload any
+			_ = json.Unmarshal(data, &payload)
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("X-Echo", r.Header.Get("X-Test"))
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"method":      r.Method,
+				"contentType": r.Header.Get("Content-Type"),
+				"payload":     payload,
+			})
+		}
+	}))
+	defer server.Close()
+	tests := []struct {
+		name        string
+		http        v1alpha1.HTTP
+		wantErr     string
+		wantOutputs map[string]any
+	}{{
+		name: "get",
+		http: v1alpha1.HTTP{
+			URL: v1alpha1.Expression(server.URL),
+		},
+	}, {
+		name:    "no url",
+		http:    v1alpha1.HTTP{},
+		wantErr: "either url or target must be specified",
+	}, {
+		name: "target without client",
+		http: v1alpha1.HTTP{
+			Target: &v1alpha1.HTTPTarget{},
+		},
+		wantErr: "cluster client not set",
+	}, {
+		name: "not found",
+		http: v1alpha1.HTTP{
+			URL: v1alpha1.Expression(server.URL + "/not-found"),
+		},
+		wantErr: "unexpected status code: 404",
+	}, {
+		name: "not found with check",
+		http: v1alpha1.HTTP{
+			URL: v1alpha1.Expression(server.URL + "/not-found"),
+			ActionCheck: v1alpha1.ActionCheck{
+				Check: new(v1alpha1.NewCheck(map[string]any{
+					"status": 404,
+				})),
+			},
+		},
+	}, {
+		name: "post with check",
+		http: v1alpha1.HTTP{
+			Method: "POST",
+			URL:    v1alpha1.Expression(server.URL),
+			Headers: map[string]v1alpha1.Expression{
+				"X-Test": "(join('-', ['foo', 'bar']))",
+			},
+			Body: new(v1alpha1.NewProjection(map[string]any{
+				"foo": "bar",
+			})),
+			ActionCheck: v1alpha1.ActionCheck{
+				Check: new(v1alpha1.NewCheck(map[string]any{
+					"status": 200,
+					"headers": map[string]any{
+						"X-Echo": "foo-bar",
+					},
+					"body": map[string]any{
+						"method":      "POST",
+						"contentType": "application/json",
+						"payload": map[string]any{
+							"foo": "bar",
+						},
+					},
+					"($status)":      200,
+					"($body.method)": "POST",
+				})),
+			},
+		},
+	}, {
+		name: "failing check",
+		http: v1alpha1.HTTP{
+			URL: v1alpha1.Expression(server.URL),
+			ActionCheck: v1alpha1.ActionCheck{
+				Check: new(v1alpha1.NewCheck(map[string]any{
+					"status": 201,
+				})),
+			},
+		},
+		wantErr: "status: Invalid value: 200: Expected value: 201",
+	}, {
+		name: "polling",
+		http: v1alpha1.HTTP{
+			URL: v1alpha1.Expression(server.URL + "/eventually"),
+			ActionCheck: v1alpha1.ActionCheck{
+				Check: new(v1alpha1.NewCheck(map[string]any{
+					"status": 200,
+					"body":   "ready",
+				})),
+			},
+		},
+	}, {
+		name: "connection error with check",
+		http: v1alpha1.HTTP{
+			URL: "http://127.0.0.1:0",
+			ActionCheck: v1alpha1.ActionCheck{
+				Check: new(v1alpha1.NewCheck(map[string]any{
+					"($error != null)": true,
+				})),
+			},
+		},
+	}, {
+		name: "outputs",
+		http: v1alpha1.HTTP{
+			URL: v1alpha1.Expression(server.URL),
+			ActionOutputs: v1alpha1.ActionOutputs{
+				Outputs: []v1alpha1.Output{{
+					Binding: v1alpha1.Binding{
+						Name:  "method",
+						Value: v1alpha1.NewProjection("($body.method)"),
+					},
+				}},
+			},
+		},
+		wantOutputs: map[string]any{
+			"method": "GET",
+		},
+	}, {
+		name: "bad ca file",
+		http: v1alpha1.HTTP{
+			URL: v1alpha1.Expression(server.URL),
+			TLS: &v1alpha1.HTTPTLS{
+				CAFile: "operation.go",
+			},
+		},
+		wantErr: "failed to parse CA certificates from operation.go",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := logging.WithLogger(context.TODO(), &mocks.Logger{})
+			ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+			defer cancel()
+			operation := New(
+				apis.DefaultCompilers,
+				nil,
+				nil,
+				nil,
+				".",
+				tt.http,
+			)
+			outputs, err := operation.Exec(ctx, nil)
+			if tt.wantErr != "" {
+				assert.Error(t, err)
+				if err != nil {
+					assert.Contains(t, err.Error(), tt.wantErr)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+			if tt.wantOutputs != nil {
+				assert.Equal(t, tt.wantOutputs, map[string]any(outputs))
+			}
+		})
+	}
+}
+
+func Test_operation_tls(t *testing.T) {
+	server := httptest.NewUnstartedServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		w.WriteHeader(nethttp.StatusNoContent)
+	}))
+	server.Config.ErrorLog = log.New(io.Discard, "", 0)
+	server.StartTLS()
+	defer server.Close()
+	{
+		ctx, cancel := context.WithTimeout(logging.WithLogger(context.TODO(), &mocks.Logger{}), 500*time.Millisecond)
+		defer cancel()
+		operation := New(apis.DefaultCompilers, nil, nil, nil, ".", v1alpha1.HTTP{
+			URL: v1alpha1.Expression(server.URL),
+		})
+		_, err := operation.Exec(ctx, nil)
+		assert.Error(t, err)
+	}
+	{
+		ctx, cancel := context.WithTimeout(logging.WithLogger(context.TODO(), &mocks.Logger{}), 500*time.Millisecond)
+		defer cancel()
+		operation := New(apis.DefaultCompilers, nil, nil, nil, ".", v1alpha1.HTTP{
+			URL: v1alpha1.Expression(server.URL),
+			TLS: &v1alpha1.HTTPTLS{
+				InsecureSkipVerify: true,
+			},
+		})
+		_, err := operation.Exec(ctx, nil)
+		assert.NoError(t, err)
+	}
+}
+
+func Test_operation_target(t *testing.T) {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Service"}, meta.RESTScopeNamespace)
+	client := &tclient.FakeClient{
+		RESTMapperFn: func(int) meta.RESTMapper { return mapper },
+	}
+	tests := []struct {
+		name string
+		port v1alpha1.Expression
+		path v1alpha1.Expression
+		want string
+	}{{
+		name: "no port",
+		path: "healthz",
+		want: "/api/v1/namespaces/foo/services/bar/proxy/healthz",
+	}, {
+		name: "port",
+		port: "8080",
+		path: "healthz",
+		want: "/api/v1/namespaces/foo/services/bar:8080/proxy/healthz",
+	}, {
+		name: "named port",
+		port: "http",
+		want: "/api/v1/namespaces/foo/services/bar:http/proxy",
+	}, {
+		name: "https port",
+		port: "https:443",
+		path: "healthz/",
+		want: "/api/v1/namespaces/foo/services/https:bar:443/proxy/healthz/",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			operation := &operation{
+				compilers: apis.DefaultCompilers,
+				client:    client,
+				cfg:       &rest.Config{},
+				http: v1alpha1.HTTP{
+					Target: &v1alpha1.HTTPTarget{
+						ObjectName: v1alpha1.ObjectName{Namespace: "foo", Name: "bar"},
+						ObjectType: v1alpha1.ObjectType{APIVersion: "v1", Kind: "Service"},
+						Port:       tt.port,
+						Path:       tt.path,
+					},
+				},
+			}
+			_, got, err := operation.target(context.TODO(), apis.NewBindings())
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
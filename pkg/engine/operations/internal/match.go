@@ -0,0 +1,62 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+)
+
+// ValidateMatchMode checks the match mode is valid, a nil match mode requires at least one candidate to match.
+func ValidateMatchMode(mode *v1alpha1.MatchMode) error {
+	if mode == nil {
+		return nil
+	}
+	switch mode.Policy {
+	case "", v1alpha1.MatchPolicyAny, v1alpha1.MatchPolicyAll:
+		return nil
+	case v1alpha1.MatchPolicyExactly, v1alpha1.MatchPolicyAtLeast:
+		if mode.Count == nil {
+			return fmt.Errorf("a count must be specified with the %s match policy", mode.Policy)
+		}
+		if *mode.Count < 0 {
+			return errors.New("match count must not be negative")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported match policy %s", mode.Policy)
+	}
+}
+
+// IsAnyMatchMode returns whether the match mode requires at least one candidate to match (the default).
+func IsAnyMatchMode(mode *v1alpha1.MatchMode) bool {
+	return mode == nil || mode.Policy == "" || mode.Policy == v1alpha1.MatchPolicyAny
+}
+
+// CheckMatchMode returns an error when the number of matched candidates doesn't satisfy the match mode.
+// The match mode is expected to be valid.
+func CheckMatchMode(mode *v1alpha1.MatchMode, matched, candidates int) error {
+	if IsAnyMatchMode(mode) {
+		if matched > 0 {
+			return nil
+		}
+		return fmt.Errorf("%d of %d candidates matched, expected at least one candidate to match", matched, candidates)
+	}
+	switch mode.Policy {
+	case v1alpha1.MatchPolicyAll:
+		if candidates > 0 && matched == candidates {
+			return nil
+		}
+		return fmt.Errorf("%d of %d candidates matched, expected all candidates to match", matched, candidates)
+	case v1alpha1.MatchPolicyExactly:
+		if matched == *mode.Count {
+			return nil
+		}
+		return fmt.Errorf("%d of %d candidates matched, expected exactly %d", matched, candidates, *mode.Count)
+	default:
+		if matched >= *mode.Count {
+			return nil
+		}
+		return fmt.Errorf("%d of %d candidates matched, expected at least %d", matched, candidates, *mode.Count)
+	}
+}
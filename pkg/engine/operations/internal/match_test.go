@@ -0,0 +1,117 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateMatchMode(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    *v1alpha1.MatchMode
+		wantErr string
+	}{{
+		name: "nil",
+	}, {
+		name: "default",
+		mode: &v1alpha1.MatchMode{},
+	}, {
+		name: "all",
+		mode: &v1alpha1.MatchMode{Policy: v1alpha1.MatchPolicyAll},
+	}, {
+		name: "exactly",
+		mode: &v1alpha1.MatchMode{Policy: v1alpha1.MatchPolicyExactly, Count: new(3)},
+	}, {
+		name:    "exactly without count",
+		mode:    &v1alpha1.MatchMode{Policy: v1alpha1.MatchPolicyExactly},
+		wantErr: "a count must be specified with the Exactly match policy",
+	}, {
+		name:    "at least with negative count",
+		mode:    &v1alpha1.MatchMode{Policy: v1alpha1.MatchPolicyAtLeast, Count: new(-1)},
+		wantErr: "match count must not be negative",
+	}, {
+		name:    "unsupported",
+		mode:    &v1alpha1.MatchMode{Policy: "Some"},
+		wantErr: "unsupported match policy Some",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateMatchMode(tt.mode)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCheckMatchMode(t *testing.T) {
+	tests := []struct {
+		name       string
+		mode       *v1alpha1.MatchMode
+		matched    int
+		candidates int
+		wantErr    string
+	}{{
+		name:       "any",
+		matched:    1,
+		candidates: 3,
+	}, {
+		name:       "any without match",
+		candidates: 3,
+		wantErr:    "0 of 3 candidates matched, expected at least one candidate to match",
+	}, {
+		name:       "all",
+		mode:       &v1alpha1.MatchMode{Policy: v1alpha1.MatchPolicyAll},
+		matched:    3,
+		candidates: 3,
+	}, {
+		name:       "all with a candidate not matching",
+		mode:       &v1alpha1.MatchMode{Policy: v1alpha1.MatchPolicyAll},
+		matched:    2,
+		candidates: 3,
+		wantErr:    "2 of 3 candidates matched, expected all candidates to match",
+	}, {
+		name:    "all without candidates",
+		mode:    &v1alpha1.MatchMode{Policy: v1alpha1.MatchPolicyAll},
+		wantErr: "0 of 0 candidates matched, expected all candidates to match",
+	}, {
+		name:       "exactly",
+		mode:       &v1alpha1.MatchMode{Policy: v1alpha1.MatchPolicyExactly, Count: new(2)},
+		matched:    2,
+		candidates: 3,
+	}, {
+		name:       "exactly with too many matches",
+		mode:       &v1alpha1.MatchMode{Policy: v1alpha1.MatchPolicyExactly, Count: new(2)},
+		matched:    3,
+		candidates: 3,
+		wantErr:    "3 of 3 candidates matched, expected exactly 2",
+	}, {
+		name: "exactly zero",
+		mode: &v1alpha1.MatchMode{Policy: v1alpha1.MatchPolicyExactly, Count: new(0)},
+	}, {
+		name:       "at least",
+		mode:       &v1alpha1.MatchMode{Policy: v1alpha1.MatchPolicyAtLeast, Count: new(2)},
+		matched:    3,
+		candidates: 3,
+	}, {
+		name:       "at least with too few matches",
+		mode:       &v1alpha1.MatchMode{Policy: v1alpha1.MatchPolicyAtLeast, Count: new(2)},
+		matched:    1,
+		candidates: 3,
+		wantErr:    "1 of 3 candidates matched, expected at least 2",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckMatchMode(tt.mode, tt.matched, tt.candidates)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
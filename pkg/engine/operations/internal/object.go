@@ -0,0 +1,157 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/client"
+	"github.com/kyverno/chainsaw/pkg/engine/namespacer"
+	"github.com/kyverno/kyverno-json/pkg/core/compilers"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ObjectRef identifies one or more objects of a given resource.
+type ObjectRef struct {
+	Mapping       *meta.RESTMapping
+	Name          string
+	Namespace     string
+	Selector      labels.Selector
+	AllNamespaces bool
+}
+
+func (r *ObjectRef) Clustered() bool {
+	return r.Mapping.Scope.Name() == meta.RESTScopeNameRoot
+}
+
+// Resource returns the resource name in the kubectl `resource.version.group` form.
+func (r *ObjectRef) Resource() string {
+	if r.Mapping.Resource.Group == "" {
+		return r.Mapping.Resource.Resource
+	}
+	return strings.Join([]string{r.Mapping.Resource.Resource, r.Mapping.Resource.Version, r.Mapping.Resource.Group}, ".")
+}
+
+// Object returns a partial object used to identify the reference in logs.
+func (r *ObjectRef) Object() *unstructured.Unstructured {
+	var obj unstructured.Unstructured
+	obj.SetGroupVersionKind(r.Mapping.GroupVersionKind)
+	obj.SetName(r.Name)
+	obj.SetNamespace(r.Namespace)
+	return &obj
+}
+
+// ObjectName returns the object name in the kubectl `kind.group/name` form.
+func (r *ObjectRef) ObjectName(obj unstructured.Unstructured) string {
+	kind := strings.ToLower(r.Mapping.GroupVersionKind.Kind)
+	if group := r.Mapping.GroupVersionKind.Group; group != "" {
+		kind += "." + group
+	}
+	return kind + "/" + obj.GetName()
+}
+
+func ResolveObject(
+	ctx context.Context,
+	compilers compilers.Compilers,
+	c client.Client,
+	bindings apis.Bindings,
+	namespacer namespacer.Namespacer,
+	objectType v1alpha1.ObjectType,
+	objectSelector v1alpha1.ActionObjectSelector,
+) (*ObjectRef, error) {
+	if c == nil {
+		return nil, errors.New("cluster client not set")
+	}
+	name, err := objectSelector.Name.Value(ctx, compilers, bindings)
+	if err != nil {
+		return nil, err
+	}
+	namespace, err := objectSelector.Namespace.Value(ctx, compilers, bindings)
+	if err != nil {
+		return nil, err
+	}
+	selector, err := objectSelector.Selector.Value(ctx, compilers, bindings)
+	if err != nil {
+		return nil, err
+	}
+	if name != "" && selector != "" {
+		return nil, errors.New("name cannot be provided when a selector is specified")
+	}
+	mapping, err := MapResource(ctx, compilers, c, bindings, objectType)
+	if err != nil {
+		return nil, err
+	}
+	ref := ObjectRef{
+		Mapping: mapping,
+		Name:    name,
+	}
+	if selector != "" {
+		parsed, err := labels.Parse(selector)
+		if err != nil {
+			return nil, err
+		}
+		ref.Selector = parsed
+	}
+	if !ref.Clustered() {
+		if namespace == "*" {
+			ref.AllNamespaces = true
+		} else {
+			if namespace == "" && namespacer != nil {
+				namespace = namespacer.GetNamespace()
+			}
+			ref.Namespace = namespace
+		}
+	}
+	return &ref, nil
+}
+
+func MapResource(ctx context.Context, compilers compilers.Compilers, c client.Client, bindings apis.Bindings, resource v1alpha1.ObjectType) (*meta.RESTMapping, error) {
+	if resource.APIVersion == "" || resource.Kind == "" {
+		return nil, errors.New("failed to map resource, either kind or resource must be specified")
+	}
+	apiVersion, err := resource.APIVersion.Value(ctx, compilers, bindings)
+	if err != nil {
+		return nil, err
+	}
+	kind, err := resource.Kind.Value(ctx, compilers, bindings)
+	if err != nil {
+		return nil, err
+	}
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return nil, err
+	}
+	return c.RESTMapper().RESTMapping(gv.WithKind(kind).GroupKind(), gv.Version)
+}
+
+// ReadObjects fetches the objects identified by the given reference.
+// When fetching a single object by name, a not found error is returned if the object doesn't exist.
+func ReadObjects(ctx context.Context, c client.Client, ref *ObjectRef) ([]unstructured.Unstructured, error) {
+	gvk := ref.Mapping.GroupVersionKind
+	if ref.Name != "" {
+		var obj unstructured.Unstructured
+		obj.SetGroupVersionKind(gvk)
+		if err := c.Get(ctx, client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}, &obj); err != nil {
+			return nil, err
+		}
+		return []unstructured.Unstructured{obj}, nil
+	}
+	var list unstructured.UnstructuredList
+	list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	var opts []client.ListOption
+	if ref.Namespace != "" {
+		opts = append(opts, client.InNamespace(ref.Namespace))
+	}
+	if ref.Selector != nil {
+		opts = append(opts, client.MatchingLabelsSelector{Selector: ref.Selector})
+	}
+	if err := c.List(ctx, &list, opts...); err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
@@ -0,0 +1,149 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/yaml"
+)
+
+const tableAcceptHeader = "application/json;as=Table;v=v1;g=meta.k8s.io,application/json;as=Table;v=v1beta1;g=meta.k8s.io,application/json"
+
+func RESTClient(cfg *rest.Config, gv schema.GroupVersion) (rest.Interface, error) {
+	config := rest.CopyConfig(cfg)
+	config.GroupVersion = &gv
+	if gv.Group == "" {
+		config.APIPath = "/api"
+	} else {
+		config.APIPath = "/apis"
+	}
+	config.NegotiatedSerializer = scheme.Codecs.WithoutConversion()
+	if config.UserAgent == "" {
+		config.UserAgent = rest.DefaultKubernetesUserAgent()
+	}
+	return rest.RESTClientFor(config)
+}
+
+// FetchTable retrieves the server side table representation of the objects identified by the given reference.
+func FetchTable(ctx context.Context, cfg *rest.Config, ref *ObjectRef) (*metav1.Table, error) {
+	rc, err := RESTClient(cfg, ref.Mapping.Resource.GroupVersion())
+	if err != nil {
+		return nil, err
+	}
+	req := rc.Get().
+		NamespaceIfScoped(ref.Namespace, !ref.Clustered() && !ref.AllNamespaces).
+		Resource(ref.Mapping.Resource.Resource).
+		SetHeader("Accept", tableAcceptHeader)
+	if ref.Name != "" {
+		req = req.Name(ref.Name)
+	}
+	if ref.Selector != nil {
+		req = req.Param("labelSelector", ref.Selector.String())
+	}
+	data, err := req.Do(ctx).Raw()
+	if err != nil {
+		return nil, err
+	}
+	var table metav1.Table
+	if err := json.Unmarshal(data, &table); err != nil {
+		return nil, err
+	}
+	return &table, nil
+}
+
+// PrintTable prints a server side table the same way kubectl prints it.
+func PrintTable(w io.Writer, table *metav1.Table, withNamespace bool) error {
+	tw := tabwriter.NewWriter(w, 6, 4, 3, ' ', 0)
+	var columns []int
+	var headers []string
+	if withNamespace {
+		headers = append(headers, "NAMESPACE")
+	}
+	for i, column := range table.ColumnDefinitions {
+		if column.Priority == 0 {
+			columns = append(columns, i)
+			headers = append(headers, strings.ToUpper(column.Name))
+		}
+	}
+	fmt.Fprintln(tw, strings.Join(headers, "\t"))
+	for _, row := range table.Rows {
+		var cells []string
+		if withNamespace {
+			var meta metav1.PartialObjectMetadata
+			if row.Object.Raw != nil {
+				if err := json.Unmarshal(row.Object.Raw, &meta); err != nil {
+					return err
+				}
+			}
+			cells = append(cells, meta.Namespace)
+		}
+		for _, i := range columns {
+			if i < len(row.Cells) && row.Cells[i] != nil {
+				cells = append(cells, fmt.Sprint(row.Cells[i]))
+			} else {
+				cells = append(cells, "<none>")
+			}
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	return tw.Flush()
+}
+
+// PrintObjects prints objects in json or yaml format, a list is used unless a single object was requested.
+func PrintObjects(w io.Writer, format string, single bool, objs ...unstructured.Unstructured) error {
+	var content any
+	if single && len(objs) == 1 {
+		content = objs[0].UnstructuredContent()
+	} else {
+		var items []any
+		for _, obj := range objs {
+			items = append(items, obj.UnstructuredContent())
+		}
+		if items == nil {
+			items = []any{}
+		}
+		content = map[string]any{
+			"apiVersion": "v1",
+			"kind":       "List",
+			"items":      items,
+			"metadata": map[string]any{
+				"resourceVersion": "",
+			},
+		}
+	}
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(content, "", "    ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	case "yaml":
+		data, err := yaml.Marshal(content)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	default:
+		return fmt.Errorf("unsupported output format: %s", format)
+	}
+}
+
+// NoResourcesFound returns the message printed by kubectl when no object was found.
+func NoResourcesFound(ref *ObjectRef) string {
+	if ref.Clustered() || ref.AllNamespaces || ref.Namespace == "" {
+		return "No resources found"
+	}
+	return fmt.Sprintf("No resources found in %s namespace.", ref.Namespace)
+}
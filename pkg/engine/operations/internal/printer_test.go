@@ -0,0 +1,108 @@
+package internal
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestPrintTable(t *testing.T) {
+	table := &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{{Name: "Name"}, {Name: "Age"}, {Name: "Node", Priority: 1}},
+		Rows: []metav1.TableRow{{
+			Cells:  []any{"foo", "1m", "node"},
+			Object: runtime.RawExtension{Raw: []byte(`{"metadata":{"name":"foo","namespace":"bar"}}`)},
+		}, {
+			Cells: []any{"quux", nil, "node"},
+		}},
+	}
+	tests := []struct {
+		name          string
+		withNamespace bool
+		want          string
+	}{{
+		name: "default",
+		want: "NAME   AGE\nfoo    1m\nquux   <none>\n",
+	}, {
+		name:          "with namespace",
+		withNamespace: true,
+		want:          "NAMESPACE   NAME   AGE\nbar         foo    1m\n            quux   <none>\n",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			assert.NoError(t, PrintTable(&buf, table, tt.withNamespace))
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestPrintObjects(t *testing.T) {
+	obj := unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]any{
+				"name": "foo",
+			},
+		},
+	}
+	tests := []struct {
+		name    string
+		format  string
+		single  bool
+		objs    []unstructured.Unstructured
+		want    string
+		wantErr bool
+	}{{
+		name:   "single yaml",
+		format: "yaml",
+		single: true,
+		objs:   []unstructured.Unstructured{obj},
+		want:   "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: foo\n",
+	}, {
+		name:   "single json",
+		format: "json",
+		single: true,
+		objs:   []unstructured.Unstructured{obj},
+		want:   "{\n    \"apiVersion\": \"v1\",\n    \"kind\": \"ConfigMap\",\n    \"metadata\": {\n        \"name\": \"foo\"\n    }\n}\n",
+	}, {
+		name:   "list yaml",
+		format: "yaml",
+		objs:   []unstructured.Unstructured{obj},
+		want:   "apiVersion: v1\nitems:\n- apiVersion: v1\n  kind: ConfigMap\n  metadata:\n    name: foo\nkind: List\nmetadata:\n  resourceVersion: \"\"\n",
+	}, {
+		name:   "empty list",
+		format: "yaml",
+		want:   "apiVersion: v1\nitems: []\nkind: List\nmetadata:\n  resourceVersion: \"\"\n",
+	}, {
+		name:    "unsupported",
+		format:  "wide",
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := PrintObjects(&buf, tt.format, tt.single, tt.objs...)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, buf.String())
+			}
+		})
+	}
+}
+
+func TestNoResourcesFound(t *testing.T) {
+	namespaced := &meta.RESTMapping{Scope: meta.RESTScopeNamespace}
+	clustered := &meta.RESTMapping{Scope: meta.RESTScopeRoot}
+	assert.Equal(t, "No resources found in foo namespace.", NoResourcesFound(&ObjectRef{Mapping: namespaced, Namespace: "foo"}))
+	assert.Equal(t, "No resources found", NoResourcesFound(&ObjectRef{Mapping: namespaced, AllNamespaces: true}))
+	assert.Equal(t, "No resources found", NoResourcesFound(&ObjectRef{Mapping: clustered}))
+}
@@ -0,0 +1,123 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// processWaitDelay bounds the time spent waiting for output pipes once a background process exited,
+// children of the process can keep the pipes open after it was killed.
+const processWaitDelay = 5 * time.Second
+
+var processes = struct {
+	lock    sync.Mutex
+	counter int
+	items   map[string]*Process
+}{
+	items: map[string]*Process{},
+}
+
+// Process is a command running in the background.
+type Process struct {
+	Output        CommandOutput
+	SkipLogOutput bool
+	cmd           *exec.Cmd
+	done          chan struct{}
+	stopped       atomic.Bool
+	err           error
+}
+
+// StartProcess starts a command in the background and returns the handle of the process.
+// The release function is called once the process exited.
+func StartProcess(cmd *exec.Cmd, skipLogOutput bool, release func()) (string, *Process, error) {
+	process := &Process{
+		SkipLogOutput: skipLogOutput,
+		cmd:           cmd,
+		done:          make(chan struct{}),
+	}
+	cmd.Stdout = &process.Output.Stdout
+	cmd.Stderr = &process.Output.Stderr
+	cmd.WaitDelay = processWaitDelay
+	if err := cmd.Start(); err != nil {
+		if release != nil {
+			release()
+		}
+		return "", nil, err
+	}
+	go func() {
+		err := cmd.Wait()
+		if release != nil {
+			release()
+		}
+		// a process stopped on purpose doesn't report the kill error
+		if !process.stopped.Load() {
+			process.err = err
+		}
+		close(process.done)
+	}()
+	processes.lock.Lock()
+	defer processes.lock.Unlock()
+	processes.counter++
+	handle := fmt.Sprintf("process-%d", processes.counter)
+	processes.items[handle] = process
+	return handle, process, nil
+}
+
+// GetProcess returns the process registered with the given handle.
+func GetProcess(handle string) (*Process, error) {
+	processes.lock.Lock()
+	defer processes.lock.Unlock()
+	if process, ok := processes.items[handle]; ok {
+		return process, nil
+	}
+	return nil, fmt.Errorf("process %s not found", handle)
+}
+
+// ReleaseProcess stops the process registered with the given handle (if still running) and forgets it.
+func ReleaseProcess(ctx context.Context, handle string) error {
+	processes.lock.Lock()
+	process, ok := processes.items[handle]
+	delete(processes.items, handle)
+	processes.lock.Unlock()
+	if !ok {
+		return nil
+	}
+	// the exit status of a completed process is reported by the operation waiting for it, not here
+	select {
+	case <-process.done:
+		return nil
+	default:
+		return process.Stop(ctx)
+	}
+}
+
+// Wait waits for the process to complete and returns the process error.
+// Output must not be read before Wait or Stop returned.
+func (p *Process) Wait(ctx context.Context) error {
+	select {
+	case <-p.done:
+		return p.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Stop kills the process if it is still running and waits for it to complete.
+func (p *Process) Stop(ctx context.Context) error {
+	select {
+	case <-p.done:
+		return p.err
+	default:
+	}
+	p.stopped.Store(true)
+	if err := p.cmd.Process.Kill(); err != nil && !errors.Is(err, os.ErrProcessDone) {
+		return err
+	}
+	return p.Wait(ctx)
+}
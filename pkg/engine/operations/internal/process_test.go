@@ -0,0 +1,58 @@
+package internal
+
+import (
+	"context"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStartProcess(t *testing.T) {
+	released := false
+	handle, process, err := StartProcess(exec.Command("sh", "-c", "echo out; echo err >&2"), true, func() { released = true })
+	assert.NoError(t, err)
+	assert.True(t, process.SkipLogOutput)
+	got, err := GetProcess(handle)
+	assert.NoError(t, err)
+	assert.Same(t, process, got)
+	assert.NoError(t, process.Wait(context.TODO()))
+	assert.True(t, released)
+	assert.Equal(t, "out\n", process.Output.Out())
+	assert.Equal(t, "err\n", process.Output.Err())
+	// waiting again returns the same result
+	assert.NoError(t, process.Wait(context.TODO()))
+	assert.NoError(t, ReleaseProcess(context.TODO(), handle))
+	_, err = GetProcess(handle)
+	assert.Error(t, err)
+}
+
+func TestStartProcess_error(t *testing.T) {
+	released := false
+	_, _, err := StartProcess(exec.Command("chainsaw-command-not-found"), false, func() { released = true })
+	assert.Error(t, err)
+	assert.True(t, released)
+}
+
+func TestProcess_Stop(t *testing.T) {
+	handle, process, err := StartProcess(exec.Command("sleep", "30"), false, nil)
+	assert.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.TODO(), 100*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, process.Wait(ctx), context.DeadlineExceeded)
+	// a stopped process doesn't report the kill error
+	assert.NoError(t, process.Stop(context.TODO()))
+	assert.NoError(t, process.Stop(context.TODO()))
+	assert.NoError(t, ReleaseProcess(context.TODO(), handle))
+}
+
+func TestReleaseProcess(t *testing.T) {
+	handle, process, err := StartProcess(exec.Command("sh", "-c", "exit 1"), false, nil)
+	assert.NoError(t, err)
+	assert.Error(t, process.Wait(context.TODO()))
+	// the exit status of a completed process is not reported
+	assert.NoError(t, ReleaseProcess(context.TODO(), handle))
+	// unknown handles are ignored
+	assert.NoError(t, ReleaseProcess(context.TODO(), handle))
+}
@@ -0,0 +1,112 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/client"
+	"github.com/kyverno/chainsaw/pkg/engine/informers"
+	"github.com/kyverno/chainsaw/pkg/logging"
+	"github.com/kyverno/pkg/ext/output/color"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// ErrInconsistent is returned by Consistently when the condition didn't hold.
+var ErrInconsistent = errors.New("condition didn't hold consistently")
+
+// WatchSource returns a watch source for the expected object.
+// It returns nil when no informers are configured or the resource can't be watched, in which case polling should be used.
+func WatchSource(ctx context.Context, op logging.Operation, c client.Client, informers informers.Informers, expected *unstructured.Unstructured) informers.Source {
+	if informers == nil || c == nil || expected.GetAPIVersion() == "" || expected.GetKind() == "" {
+		return nil
+	}
+	gvk := expected.GroupVersionKind()
+	mapping, err := c.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil
+	}
+	source, err := informers.Source(ctx, mapping, expected.GetNamespace())
+	if err != nil {
+		if ctx.Err() == nil {
+			logging.Log(ctx, op, logging.WarnStatus, expected, color.BoldYellow, logging.Section("WATCH", "falling back to polling: "+err.Error()))
+		}
+		return nil
+	}
+	return source
+}
+
+// ReadFrom reads the expected objects from the watch source if any, or from the client otherwise.
+func ReadFrom(ctx context.Context, source informers.Source, expected client.Object, c client.Client) ([]unstructured.Unstructured, error) {
+	if source != nil {
+		return source.Read(expected)
+	}
+	return Read(ctx, expected, c)
+}
+
+// Evaluate runs the condition until it returns true, returns an error or the context is cancelled.
+// When a watch source is available the condition is also evaluated every time an object changes,
+// it is evaluated at every poll interval in all cases as it can depend on other resources or on time.
+// Without a watch source the first evaluation happens after one poll interval.
+func Evaluate(ctx context.Context, source informers.Source, condition wait.ConditionWithContextFunc) error {
+	changes, unsubscribe := subscribe(source)
+	defer unsubscribe()
+	ticker := time.NewTicker(client.PollInterval)
+	defer ticker.Stop()
+	if source == nil {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+	for {
+		if done, err := condition(ctx); err != nil {
+			return err
+		} else if done {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-changes:
+		case <-ticker.C:
+		}
+	}
+}
+
+// Consistently runs the condition until the duration elapsed, it stops as soon as the condition doesn't hold (ErrInconsistent is returned),
+// returns an error or the context is cancelled.
+// The condition is evaluated at every poll interval and, when a watch source is available, every time an object changes.
+func Consistently(ctx context.Context, source informers.Source, duration time.Duration, condition wait.ConditionWithContextFunc) error {
+	changes, unsubscribe := subscribe(source)
+	defer unsubscribe()
+	ticker := time.NewTicker(client.PollInterval)
+	defer ticker.Stop()
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	for {
+		if ok, err := condition(ctx); err != nil {
+			return err
+		} else if !ok {
+			return ErrInconsistent
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+			return nil
+		case <-changes:
+		case <-ticker.C:
+		}
+	}
+}
+
+// subscribe subscribes to the changes of the source, the returned channel is nil (receiving from it blocks forever) without a source.
+func subscribe(source informers.Source) (<-chan struct{}, func()) {
+	if source == nil {
+		return nil, func() {}
+	}
+	return source.Subscribe()
+}
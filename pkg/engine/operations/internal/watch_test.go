@@ -0,0 +1,75 @@
+package internal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/client"
+	"github.com/kyverno/chainsaw/pkg/engine/informers"
+	tinformers "github.com/kyverno/chainsaw/pkg/engine/informers/testing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		name   string
+		source informers.Source
+	}{{
+		name: "without source",
+	}, {
+		// conditions can depend on other resources or on time, they are re-evaluated without events
+		name:   "with source without events",
+		source: &tinformers.FakeSource{},
+	}, {
+		name:   "with source",
+		source: &tinformers.FakeSource{Changes: make(chan struct{}, 1)},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			calls := 0
+			err := Evaluate(ctx, tt.source, func(context.Context) (bool, error) {
+				calls++
+				if source, ok := tt.source.(*tinformers.FakeSource); ok && source.Changes != nil {
+					source.Changes <- struct{}{}
+				}
+				return calls == 3, nil
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, 3, calls)
+		})
+	}
+}
+
+func TestEvaluate_Polling(t *testing.T) {
+	// without a source the condition is not evaluated before the first poll interval
+	ctx, cancel := context.WithTimeout(context.Background(), client.PollInterval/2)
+	defer cancel()
+	calls := 0
+	err := Evaluate(ctx, nil, func(context.Context) (bool, error) {
+		calls++
+		return true, nil
+	})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 0, calls)
+}
+
+func TestConsistently(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	calls := 0
+	err := Consistently(ctx, &tinformers.FakeSource{}, time.Minute, func(context.Context) (bool, error) {
+		calls++
+		return calls != 3, nil
+	})
+	assert.ErrorIs(t, err, ErrInconsistent)
+	calls = 0
+	err = Consistently(ctx, nil, 200*time.Millisecond, func(context.Context) (bool, error) {
+		calls++
+		return true, nil
+	})
+	assert.NoError(t, err)
+	assert.Greater(t, calls, 1)
+}
@@ -3,6 +3,7 @@ package patch
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/kyverno/chainsaw/pkg/apis"
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
@@ -32,6 +33,8 @@ type operation struct {
 	expect      []v1alpha1.Expectation
 	outputs     []v1alpha1.Output
 	subresource string
+	patchType   v1alpha1.PatchType
+	jsonPatch   []v1alpha1.JSONPatchOperation
 }
 
 func New(
@@ -43,6 +46,8 @@ func New(
 	expect []v1alpha1.Expectation,
 	outputs []v1alpha1.Output,
 	subresource string,
+	patchType v1alpha1.PatchType,
+	jsonPatch []v1alpha1.JSONPatchOperation,
 ) operations.Operation {
 	return &operation{
 		compilers:   compilers,
@@ -53,6 +58,8 @@ func New(
 		expect:      expect,
 		outputs:     outputs,
 		subresource: subresource,
+		patchType:   patchType,
+		jsonPatch:   jsonPatch,
 	}
 }
 
@@ -78,15 +85,69 @@ func (o *operation) Exec(ctx context.Context, bindings apis.Bindings) (_ outputs
 	if err := internal.ApplyNamespacer(o.namespacer, o.client, &obj); err != nil {
 		return nil, err
 	}
+	patchType, data, err := o.patchData(ctx, bindings, obj)
+	if err != nil {
+		return nil, err
+	}
 	internal.LogStart(ctx, logging.Patch, &obj)
-	return o.execute(ctx, bindings, obj)
+	return o.execute(ctx, bindings, obj, patchType, data)
+}
+
+// patchData returns the patch type and, for json patches, the patch document.
+// Merge and strategic merge patches are computed from the actual resource when the patch is sent.
+func (o *operation) patchData(ctx context.Context, bindings apis.Bindings, obj unstructured.Unstructured) (types.PatchType, []byte, error) {
+	switch o.patchType {
+	case "", v1alpha1.MergePatchType:
+		if len(o.jsonPatch) != 0 {
+			return "", nil, errors.New("json patch operations require the json patch type")
+		}
+		return types.MergePatchType, nil, nil
+	case v1alpha1.StrategicMergePatchType:
+		if len(o.jsonPatch) != 0 {
+			return "", nil, errors.New("json patch operations require the json patch type")
+		}
+		return types.StrategicMergePatchType, nil, nil
+	case v1alpha1.JSONPatchType:
+		if len(o.jsonPatch) == 0 {
+			return "", nil, errors.New("json patch type requires json patch operations")
+		}
+		document := make([]any, 0, len(o.jsonPatch))
+		for _, operation := range o.jsonPatch {
+			op := map[string]any{
+				"op":   operation.Op,
+				"path": operation.Path,
+			}
+			if operation.From != "" {
+				op["from"] = operation.From
+			}
+			if operation.Value != nil {
+				op["value"] = operation.Value.Value()
+			}
+			if o.template {
+				templated, err := templating.Template(ctx, o.compilers, v1alpha1.NewProjection(op), obj.UnstructuredContent(), bindings)
+				if err != nil {
+					return "", nil, err
+				}
+				document = append(document, templated)
+			} else {
+				document = append(document, op)
+			}
+		}
+		data, err := json.Marshal(document)
+		if err != nil {
+			return "", nil, err
+		}
+		return types.JSONPatchType, data, nil
+	default:
+		return "", nil, fmt.Errorf("unsupported patch type: %s", o.patchType)
+	}
 }
 
-func (o *operation) execute(ctx context.Context, bindings apis.Bindings, obj unstructured.Unstructured) (outputs.Outputs, error) {
+func (o *operation) execute(ctx context.Context, bindings apis.Bindings, obj unstructured.Unstructured, patchType types.PatchType, data []byte) (outputs.Outputs, error) {
 	var lastErr error
 	var outputs outputs.Outputs
 	err := wait.PollUntilContextCancel(ctx, client.PollInterval, false, func(ctx context.Context) (bool, error) {
-		outputs, lastErr = o.tryPatchResource(ctx, bindings, obj)
+		outputs, lastErr = o.tryPatchResource(ctx, bindings, obj, patchType, data)
 		// Check if the error is retryable
 		if lastErr != nil {
 			// Conflict errors should be retried
@@ -123,29 +184,32 @@ func (o *operation) execute(ctx context.Context, bindings apis.Bindings, obj uns
 	return outputs, err
 }
 
-func (o *operation) tryPatchResource(ctx context.Context, bindings apis.Bindings, obj unstructured.Unstructured) (outputs.Outputs, error) {
+func (o *operation) tryPatchResource(ctx context.Context, bindings apis.Bindings, obj unstructured.Unstructured, patchType types.PatchType, data []byte) (outputs.Outputs, error) {
 	var actual unstructured.Unstructured
 	actual.SetGroupVersionKind(obj.GetObjectKind().GroupVersionKind())
 	err := o.client.Get(ctx, client.Key(&obj), &actual)
 	if err != nil {
 		return nil, err
 	}
-	return o.updateResource(ctx, bindings, &actual, obj)
+	return o.updateResource(ctx, bindings, &actual, obj, patchType, data)
 }
 
-func (o *operation) updateResource(ctx context.Context, bindings apis.Bindings, actual *unstructured.Unstructured, obj unstructured.Unstructured) (outputs.Outputs, error) {
-	patched, err := client.PatchObject(actual, &obj)
-	if err != nil {
-		return nil, err
-	}
-	bytes, err := json.Marshal(patched)
-	if err != nil {
-		return nil, err
+func (o *operation) updateResource(ctx context.Context, bindings apis.Bindings, actual *unstructured.Unstructured, obj unstructured.Unstructured, patchType types.PatchType, data []byte) (outputs.Outputs, error) {
+	if data == nil {
+		patched, err := client.PatchObject(actual, &obj)
+		if err != nil {
+			return nil, err
+		}
+		bytes, err := json.Marshal(patched)
+		if err != nil {
+			return nil, err
+		}
+		data = bytes
 	}
 	if o.subresource != "" {
-		return o.handleCheck(ctx, bindings, obj, o.client.SubResource(o.subresource).Patch(ctx, actual, client.RawPatch(types.MergePatchType, bytes)))
+		return o.handleCheck(ctx, bindings, obj, o.client.SubResource(o.subresource).Patch(ctx, actual, client.RawPatch(patchType, data)))
 	}
-	return o.handleCheck(ctx, bindings, obj, o.client.Patch(ctx, actual, client.RawPatch(types.MergePatchType, bytes)))
+	return o.handleCheck(ctx, bindings, obj, o.client.Patch(ctx, actual, client.RawPatch(patchType, data)))
 }
 
 func (o *operation) handleCheck(ctx context.Context, bindings apis.Bindings, obj unstructured.Unstructured, err error) (_outputs outputs.Outputs, _err error) {
@@ -53,6 +53,8 @@ func Test_create(t *testing.T) {
 		expect      []v1alpha1.Expectation
 		expectedErr error
 		subresource string
+		patchType   v1alpha1.PatchType
+		jsonPatch   []v1alpha1.JSONPatchOperation
 	}{{
 		name:   "Resource doesn't exist",
 		object: pod,
@@ -223,6 +225,72 @@ func Test_create(t *testing.T) {
 		expect:      nil,
 		expectedErr: nil,
 		subresource: "status",
+	}, {
+		name:   "Strategic merge patch",
+		object: pod,
+		client: &tclient.FakeClient{
+			GetFn: func(ctx context.Context, _ int, _ client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				*obj.(*unstructured.Unstructured) = pod
+				return nil
+			},
+			PatchFn: func(_ context.Context, _ int, _ client.Object, patch client.Patch, _ ...client.PatchOption) error {
+				if patch.Type() != types.StrategicMergePatchType {
+					return errors.New("unexpected patch type")
+				}
+				return nil
+			},
+		},
+		patchType:   v1alpha1.StrategicMergePatchType,
+		expectedErr: nil,
+	}, {
+		name:   "Json patch",
+		object: pod,
+		client: &tclient.FakeClient{
+			GetFn: func(ctx context.Context, _ int, _ client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				*obj.(*unstructured.Unstructured) = pod
+				return nil
+			},
+			PatchFn: func(_ context.Context, _ int, _ client.Object, patch client.Patch, _ ...client.PatchOption) error {
+				if patch.Type() != types.JSONPatchType {
+					return errors.New("unexpected patch type")
+				}
+				data, err := patch.Data(nil)
+				if err != nil {
+					return err
+				}
+				if string(data) != `[{"op":"remove","path":"/status/conditions/0"}]` {
+					return errors.New("unexpected patch data")
+				}
+				return nil
+			},
+		},
+		patchType: v1alpha1.JSONPatchType,
+		jsonPatch: []v1alpha1.JSONPatchOperation{{
+			Op:   "remove",
+			Path: "/status/conditions/0",
+		}},
+		expectedErr: nil,
+	}, {
+		name:        "Json patch without operations",
+		object:      pod,
+		client:      &tclient.FakeClient{},
+		patchType:   v1alpha1.JSONPatchType,
+		expectedErr: errors.New("json patch type requires json patch operations"),
+	}, {
+		name:   "Json patch operations without json patch type",
+		object: pod,
+		client: &tclient.FakeClient{},
+		jsonPatch: []v1alpha1.JSONPatchOperation{{
+			Op:   "remove",
+			Path: "/status/conditions/0",
+		}},
+		expectedErr: errors.New("json patch operations require the json patch type"),
+	}, {
+		name:        "Unsupported patch type",
+		object:      pod,
+		client:      &tclient.FakeClient{},
+		patchType:   v1alpha1.PatchType("foo"),
+		expectedErr: errors.New("unsupported patch type: foo"),
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -240,6 +308,8 @@ func Test_create(t *testing.T) {
 				tt.expect,
 				nil,
 				tt.subresource,
+				tt.patchType,
+				tt.jsonPatch,
 			)
 			outputs, err := operation.Exec(ctx, nil)
 			assert.Nil(t, outputs)
@@ -252,6 +322,51 @@ func Test_create(t *testing.T) {
 	}
 }
 
+func Test_jsonPatch_template(t *testing.T) {
+	pod := unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata": map[string]any{
+				"name": "test-pod",
+			},
+		},
+	}
+	var got string
+	fake := &tclient.FakeClient{
+		GetFn: func(ctx context.Context, _ int, _ client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			*obj.(*unstructured.Unstructured) = pod
+			return nil
+		},
+		PatchFn: func(_ context.Context, _ int, _ client.Object, patch client.Patch, _ ...client.PatchOption) error {
+			data, err := patch.Data(nil)
+			got = string(data)
+			return err
+		},
+	}
+	value := v1alpha1.NewProjection("($value)")
+	operation := New(
+		apis.DefaultCompilers,
+		fake,
+		pod,
+		nil,
+		true,
+		nil,
+		nil,
+		"",
+		v1alpha1.JSONPatchType,
+		[]v1alpha1.JSONPatchOperation{{
+			Op:    "test",
+			Path:  "/metadata/name",
+			Value: &value,
+		}},
+	)
+	bindings := apis.NewBindings().Register("$value", apis.NewBinding("test-pod"))
+	_, err := operation.Exec(logging.WithLogger(context.TODO(), &mocks.Logger{}), bindings)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[{"op":"test","path":"/metadata/name","value":"test-pod"}]`, got)
+}
+
 func Test_retry_logic(t *testing.T) {
 	pod := unstructured.Unstructured{
 		Object: map[string]any{
@@ -426,6 +541,8 @@ func Test_retry_logic(t *testing.T) {
 				tt.expect,
 				nil,
 				"",
+				"",
+				nil,
 			)
 			outputs, err := operation.Exec(ctx, nil)
 			assert.Nil(t, outputs)
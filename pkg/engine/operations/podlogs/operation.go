@@ -0,0 +1,177 @@
+package podlogs
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/client"
+	"github.com/kyverno/chainsaw/pkg/engine/namespacer"
+	"github.com/kyverno/chainsaw/pkg/engine/operations"
+	"github.com/kyverno/chainsaw/pkg/engine/operations/internal"
+	"github.com/kyverno/chainsaw/pkg/engine/outputs"
+	"github.com/kyverno/chainsaw/pkg/logging"
+	"github.com/kyverno/kyverno-json/pkg/core/compilers"
+	"github.com/kyverno/pkg/ext/output/color"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+type operation struct {
+	compilers  compilers.Compilers
+	cfg        *rest.Config
+	namespacer namespacer.Namespacer
+	podLogs    v1alpha1.PodLogs
+}
+
+func New(
+	compilers compilers.Compilers,
+	cfg *rest.Config,
+	namespacer namespacer.Namespacer,
+	podLogs v1alpha1.PodLogs,
+) operations.Operation {
+	return &operation{
+		compilers:  compilers,
+		cfg:        cfg,
+		namespacer: namespacer,
+		podLogs:    podLogs,
+	}
+}
+
+func (o *operation) Exec(ctx context.Context, bindings apis.Bindings) (_ outputs.Outputs, _err error) {
+	if bindings == nil {
+		bindings = apis.NewBindings()
+	}
+	var obj client.Object
+	defer func() {
+		internal.LogEnd(ctx, logging.PodLogs, obj, _err)
+	}()
+	if o.cfg == nil {
+		return nil, errors.New("cluster config not set")
+	}
+	name, err := o.podLogs.Name.Value(ctx, o.compilers, bindings)
+	if err != nil {
+		return nil, err
+	}
+	namespace, err := o.podLogs.Namespace.Value(ctx, o.compilers, bindings)
+	if err != nil {
+		return nil, err
+	}
+	selector, err := o.podLogs.Selector.Value(ctx, o.compilers, bindings)
+	if err != nil {
+		return nil, err
+	}
+	container, err := o.podLogs.Container.Value(ctx, o.compilers, bindings)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" && selector == "" {
+		return nil, errors.New("a name or selector must be specified")
+	}
+	if name != "" && selector != "" {
+		return nil, errors.New("name cannot be provided when a selector is specified")
+	}
+	if namespace == "" && o.namespacer != nil {
+		namespace = o.namespacer.GetNamespace()
+	}
+	clientset, err := kubernetes.NewForConfig(o.cfg)
+	if err != nil {
+		return nil, err
+	}
+	pod := &unstructured.Unstructured{}
+	pod.SetAPIVersion("v1")
+	pod.SetKind("Pod")
+	pod.SetName(name)
+	pod.SetNamespace(namespace)
+	obj = pod
+	internal.LogStart(ctx, logging.PodLogs, obj)
+	var output internal.CommandOutput
+	defer func() {
+		if sections := output.Sections(); len(sections) != 0 {
+			logging.Log(ctx, logging.PodLogs, logging.LogStatus, obj, color.BoldFgCyan, sections...)
+		}
+	}()
+	return nil, o.execute(ctx, clientset, namespace, name, selector, container, &output)
+}
+
+func (o *operation) execute(ctx context.Context, clientset kubernetes.Interface, namespace, name, selector, container string, output *internal.CommandOutput) error {
+	var pods []corev1.Pod
+	tail := int64(-1)
+	if name != "" {
+		pod, err := clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		pods = append(pods, *pod)
+	} else {
+		tail = 10
+		list, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			return err
+		}
+		pods = list.Items
+		if len(pods) == 0 {
+			_, err := fmt.Fprintf(&output.Stderr, "No resources found in %s namespace.\n", namespace)
+			return err
+		}
+	}
+	if o.podLogs.Tail != nil && *o.podLogs.Tail != 0 {
+		tail = int64(*o.podLogs.Tail)
+	}
+	for _, pod := range pods {
+		containers := []string{container}
+		if container == "" {
+			containers = allContainers(pod)
+		}
+		for _, container := range containers {
+			if err := streamLogs(ctx, clientset, pod, container, tail, output); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// allContainers returns the names of init, regular and ephemeral containers of a pod.
+func allContainers(pod corev1.Pod) []string {
+	var names []string
+	for _, container := range pod.Spec.InitContainers {
+		names = append(names, container.Name)
+	}
+	for _, container := range pod.Spec.Containers {
+		names = append(names, container.Name)
+	}
+	for _, container := range pod.Spec.EphemeralContainers {
+		names = append(names, container.Name)
+	}
+	return names
+}
+
+func streamLogs(ctx context.Context, clientset kubernetes.Interface, pod corev1.Pod, container string, tail int64, output *internal.CommandOutput) error {
+	opts := corev1.PodLogOptions{
+		Container: container,
+	}
+	if tail >= 0 {
+		opts.TailLines = new(tail)
+	}
+	stream, err := clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &opts).Stream(ctx)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+	prefix := fmt.Sprintf("[pod/%s/%s] ", pod.Name, container)
+	scanner := bufio.NewScanner(stream)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if _, err := fmt.Fprintln(&output.Stdout, prefix+scanner.Text()); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
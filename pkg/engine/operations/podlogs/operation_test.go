@@ -0,0 +1,152 @@
+package podlogs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/engine/namespacer"
+	"github.com/kyverno/chainsaw/pkg/logging"
+	"github.com/kyverno/chainsaw/pkg/mocks"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+)
+
+func Test_operation(t *testing.T) {
+	pod := corev1.Pod{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pod", Namespace: "foo"},
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: "init"}},
+			Containers:     []corev1.Container{{Name: "main"}},
+		},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/namespaces/foo/pods/test-pod":
+			w.Header().Set("Content-Type", "application/json")
+			assert.NoError(t, json.NewEncoder(w).Encode(pod))
+		case "/api/v1/namespaces/foo/pods":
+			list := corev1.PodList{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "PodList"}}
+			if r.URL.Query().Get("labelSelector") == "app=test" {
+				list.Items = append(list.Items, pod)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			assert.NoError(t, json.NewEncoder(w).Encode(list))
+		case "/api/v1/namespaces/foo/pods/test-pod/log":
+			fmt.Fprintf(w, "container=%s tail=%s\n", r.URL.Query().Get("container"), r.URL.Query().Get("tailLines"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	tests := []struct {
+		name         string
+		cfg          *rest.Config
+		podLogs      v1alpha1.PodLogs
+		expectedErr  error
+		expectedLogs []string
+	}{{
+		name:         "no config",
+		podLogs:      v1alpha1.PodLogs{},
+		expectedErr:  errors.New("cluster config not set"),
+		expectedLogs: []string{"LOGS: ERROR - [=== ERROR\ncluster config not set]"},
+	}, {
+		name:         "no name or selector",
+		cfg:          &rest.Config{Host: server.URL},
+		podLogs:      v1alpha1.PodLogs{},
+		expectedErr:  errors.New("a name or selector must be specified"),
+		expectedLogs: []string{"LOGS: ERROR - [=== ERROR\na name or selector must be specified]"},
+	}, {
+		name: "name and selector",
+		cfg:  &rest.Config{Host: server.URL},
+		podLogs: v1alpha1.PodLogs{
+			ActionObjectSelector: v1alpha1.ActionObjectSelector{
+				ObjectName: v1alpha1.ObjectName{Name: "test-pod"},
+				Selector:   "app=test",
+			},
+		},
+		expectedErr:  errors.New("name cannot be provided when a selector is specified"),
+		expectedLogs: []string{"LOGS: ERROR - [=== ERROR\nname cannot be provided when a selector is specified]"},
+	}, {
+		name: "all containers",
+		cfg:  &rest.Config{Host: server.URL},
+		podLogs: v1alpha1.PodLogs{
+			ActionObjectSelector: v1alpha1.ActionObjectSelector{
+				ObjectName: v1alpha1.ObjectName{Name: "test-pod"},
+			},
+		},
+		expectedLogs: []string{
+			"LOGS: RUN - []",
+			"LOGS: LOG - [=== STDOUT\n[pod/test-pod/init] container=init tail=\n[pod/test-pod/main] container=main tail=]",
+			"LOGS: DONE - []",
+		},
+	}, {
+		name: "selector with container and tail",
+		cfg:  &rest.Config{Host: server.URL},
+		podLogs: v1alpha1.PodLogs{
+			ActionObjectSelector: v1alpha1.ActionObjectSelector{
+				Selector: "app=test",
+			},
+			Container: "main",
+			Tail:      new(5),
+		},
+		expectedLogs: []string{
+			"LOGS: RUN - []",
+			"LOGS: LOG - [=== STDOUT\n[pod/test-pod/main] container=main tail=5]",
+			"LOGS: DONE - []",
+		},
+	}, {
+		name: "selector default tail",
+		cfg:  &rest.Config{Host: server.URL},
+		podLogs: v1alpha1.PodLogs{
+			ActionObjectSelector: v1alpha1.ActionObjectSelector{
+				Selector: "app=test",
+			},
+			Container: "main",
+		},
+		expectedLogs: []string{
+			"LOGS: RUN - []",
+			"LOGS: LOG - [=== STDOUT\n[pod/test-pod/main] container=main tail=10]",
+			"LOGS: DONE - []",
+		},
+	}, {
+		name: "no pods",
+		cfg:  &rest.Config{Host: server.URL},
+		podLogs: v1alpha1.PodLogs{
+			ActionObjectSelector: v1alpha1.ActionObjectSelector{
+				Selector: "app=other",
+			},
+		},
+		expectedLogs: []string{
+			"LOGS: RUN - []",
+			"LOGS: LOG - [=== STDERR\nNo resources found in foo namespace.]",
+			"LOGS: DONE - []",
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			operation := New(apis.DefaultCompilers, tt.cfg, namespacer.New("foo"), tt.podLogs)
+			logger := &mocks.Logger{}
+			outputs, err := operation.Exec(logging.WithLogger(ctx, logger), nil)
+			assert.Nil(t, outputs)
+			if tt.expectedErr != nil {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedLogs, logger.Logs)
+		})
+	}
+}
@@ -0,0 +1,282 @@
+package portforward
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	nethttp "net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/cleanup/cleaner"
+	"github.com/kyverno/chainsaw/pkg/client"
+	apibindings "github.com/kyverno/chainsaw/pkg/engine/bindings"
+	"github.com/kyverno/chainsaw/pkg/engine/namespacer"
+	"github.com/kyverno/chainsaw/pkg/engine/operations"
+	"github.com/kyverno/chainsaw/pkg/engine/operations/internal"
+	"github.com/kyverno/chainsaw/pkg/engine/outputs"
+	"github.com/kyverno/chainsaw/pkg/logging"
+	"github.com/kyverno/chainsaw/pkg/model"
+	"github.com/kyverno/kyverno-json/pkg/core/compilers"
+	"github.com/kyverno/pkg/ext/output/color"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+const defaultAddress = "127.0.0.1"
+
+type forwarder interface {
+	ForwardPorts() error
+	GetPorts() ([]portforward.ForwardedPort, error)
+}
+
+type forwarderFactory = func(cfg *rest.Config, url *url.URL, address string, ports []string, stopChan <-chan struct{}, readyChan chan struct{}) (forwarder, error)
+
+type operation struct {
+	compilers    compilers.Compilers
+	cfg          *rest.Config
+	namespacer   namespacer.Namespacer
+	cleaner      cleaner.CleanerCollector
+	portForward  v1alpha1.PortForward
+	newForwarder forwarderFactory
+}
+
+func New(
+	compilers compilers.Compilers,
+	cfg *rest.Config,
+	namespacer namespacer.Namespacer,
+	cleaner cleaner.CleanerCollector,
+	portForward v1alpha1.PortForward,
+) operations.Operation {
+	return &operation{
+		compilers:    compilers,
+		cfg:          cfg,
+		namespacer:   namespacer,
+		cleaner:      cleaner,
+		portForward:  portForward,
+		newForwarder: newForwarder,
+	}
+}
+
+// newForwarder creates a port forwarder using websockets, falling back to SPDY when websockets are not supported (same as kubectl).
+func newForwarder(cfg *rest.Config, url *url.URL, address string, ports []string, stopChan <-chan struct{}, readyChan chan struct{}) (forwarder, error) {
+	transport, upgrader, err := spdy.RoundTripperFor(cfg)
+	if err != nil {
+		return nil, err
+	}
+	dialer := spdy.NewDialer(upgrader, &nethttp.Client{Transport: transport}, nethttp.MethodPost, url)
+	websocket, err := portforward.NewSPDYOverWebsocketDialer(url, cfg)
+	if err != nil {
+		return nil, err
+	}
+	dialer = portforward.NewFallbackDialer(websocket, dialer, func(err error) bool {
+		return httpstream.IsUpgradeFailure(err) || httpstream.IsHTTPSProxyError(err)
+	})
+	return portforward.NewOnAddresses(dialer, []string{address}, ports, stopChan, readyChan, io.Discard, io.Discard)
+}
+
+func (o *operation) Exec(ctx context.Context, bindings apis.Bindings) (_ outputs.Outputs, _err error) {
+	if bindings == nil {
+		bindings = apis.NewBindings()
+	}
+	var obj client.Object
+	defer func() {
+		internal.LogEnd(ctx, logging.PortForward, obj, _err)
+	}()
+	if o.cfg == nil {
+		return nil, errors.New("cluster config not set")
+	}
+	if o.cleaner == nil {
+		return nil, errors.New("cleaner not set")
+	}
+	apiVersion, err := o.portForward.APIVersion.Value(ctx, o.compilers, bindings)
+	if err != nil {
+		return nil, err
+	}
+	kind, err := o.portForward.Kind.Value(ctx, o.compilers, bindings)
+	if err != nil {
+		return nil, err
+	}
+	name, err := o.portForward.Name.Value(ctx, o.compilers, bindings)
+	if err != nil {
+		return nil, err
+	}
+	namespace, err := o.portForward.Namespace.Value(ctx, o.compilers, bindings)
+	if err != nil {
+		return nil, err
+	}
+	port, err := o.portForward.Port.Value(ctx, o.compilers, bindings)
+	if err != nil {
+		return nil, err
+	}
+	localPort, err := o.portForward.LocalPort.Value(ctx, o.compilers, bindings)
+	if err != nil {
+		return nil, err
+	}
+	address, err := o.portForward.Address.Value(ctx, o.compilers, bindings)
+	if err != nil {
+		return nil, err
+	}
+	if apiVersion != "v1" || (kind != "Pod" && kind != "Service") {
+		return nil, fmt.Errorf("port forward is only supported for v1/Pod and v1/Service (got %s/%s)", apiVersion, kind)
+	}
+	if name == "" {
+		return nil, errors.New("a name must be specified")
+	}
+	if port == "" {
+		return nil, errors.New("a port must be specified")
+	}
+	if address == "" {
+		address = defaultAddress
+	}
+	if namespace == "" && o.namespacer != nil {
+		namespace = o.namespacer.GetNamespace()
+	}
+	target := &unstructured.Unstructured{}
+	target.SetAPIVersion(apiVersion)
+	target.SetKind(kind)
+	target.SetName(name)
+	target.SetNamespace(namespace)
+	obj = target
+	clientset, err := kubernetes.NewForConfig(o.cfg)
+	if err != nil {
+		return nil, err
+	}
+	pod, remotePort, err := resolve(ctx, clientset, kind, namespace, name, port)
+	if err != nil {
+		return nil, err
+	}
+	internal.LogStart(ctx, logging.PortForward, obj, logging.Section("POD", fmt.Sprintf("%s:%d", pod.Name, remotePort)))
+	req := clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(pod.Namespace).
+		Name(pod.Name).
+		SubResource("portforward")
+	stopChan := make(chan struct{})
+	readyChan := make(chan struct{})
+	fw, err := o.newForwarder(o.cfg, req.URL(), address, []string{fmt.Sprintf("%s:%d", localPort, remotePort)}, stopChan, readyChan)
+	if err != nil {
+		return nil, err
+	}
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- fw.ForwardPorts()
+	}()
+	select {
+	case <-readyChan:
+	case err := <-errChan:
+		if err == nil {
+			err = errors.New("port forward stopped unexpectedly")
+		}
+		return nil, err
+	case <-ctx.Done():
+		close(stopChan)
+		return nil, ctx.Err()
+	}
+	// the port forward is stopped by the cleaner, it remains open for the rest of the test
+	o.cleaner.AddFunc(model.OperationTypePortForward, func(ctx context.Context) error {
+		close(stopChan)
+		select {
+		case <-errChan:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+	ports, err := fw.GetPorts()
+	if err != nil {
+		return nil, err
+	}
+	if len(ports) == 0 {
+		return nil, errors.New("no port forwarded")
+	}
+	localAddress := net.JoinHostPort(address, strconv.Itoa(int(ports[0].Local)))
+	logging.Log(ctx, logging.PortForward, logging.LogStatus, obj, color.BoldFgCyan, logging.Section("ADDRESS", localAddress))
+	bindings = apibindings.RegisterBinding(bindings, "address", localAddress)
+	bindings = apibindings.RegisterBinding(bindings, "localPort", int(ports[0].Local))
+	return outputs.Process(ctx, o.compilers, bindings, nil, o.portForward.Outputs...)
+}
+
+// resolve returns the pod and the pod port to forward to.
+// For a service, the first running pod selected by the service is used and the service port is mapped to the corresponding target port.
+func resolve(ctx context.Context, clientset kubernetes.Interface, kind, namespace, name, port string) (*corev1.Pod, int32, error) {
+	if kind == "Pod" {
+		pod, err := clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, 0, err
+		}
+		remotePort, err := podPort(*pod, intstr.Parse(port))
+		if err != nil {
+			return nil, 0, err
+		}
+		return pod, remotePort, nil
+	}
+	service, err := clientset.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, 0, err
+	}
+	servicePort, err := findServicePort(*service, port)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(service.Spec.Selector) == 0 {
+		return nil, 0, fmt.Errorf("service %s/%s has no selector", namespace, name)
+	}
+	selector := labels.SelectorFromSet(service.Spec.Selector).String()
+	list, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := range list.Items {
+		if list.Items[i].Status.Phase == corev1.PodRunning {
+			targetPort := servicePort.TargetPort
+			// an unset target port defaults to the service port
+			if targetPort.Type == intstr.Int && targetPort.IntVal == 0 {
+				targetPort = intstr.FromInt32(servicePort.Port)
+			}
+			remotePort, err := podPort(list.Items[i], targetPort)
+			if err != nil {
+				return nil, 0, err
+			}
+			return &list.Items[i], remotePort, nil
+		}
+	}
+	return nil, 0, fmt.Errorf("no running pod found in %s namespace matching selector %s", namespace, selector)
+}
+
+// findServicePort returns the service port matching the given name or number.
+func findServicePort(service corev1.Service, port string) (corev1.ServicePort, error) {
+	for _, servicePort := range service.Spec.Ports {
+		if servicePort.Name == port || strconv.Itoa(int(servicePort.Port)) == port {
+			return servicePort, nil
+		}
+	}
+	return corev1.ServicePort{}, fmt.Errorf("port %s not found in service %s/%s", port, service.Namespace, service.Name)
+}
+
+// podPort returns the port number, named ports are looked up in the pod containers.
+func podPort(pod corev1.Pod, port intstr.IntOrString) (int32, error) {
+	if port.Type == intstr.Int {
+		return port.IntVal, nil
+	}
+	for _, container := range pod.Spec.Containers {
+		for _, containerPort := range container.Ports {
+			if containerPort.Name == port.StrVal {
+				return containerPort.ContainerPort, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("port %s not found in pod %s/%s", port.StrVal, pod.Namespace, pod.Name)
+}
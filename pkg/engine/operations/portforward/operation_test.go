@@ -0,0 +1,308 @@
+package portforward
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/cleanup/cleaner"
+	"github.com/kyverno/chainsaw/pkg/engine/namespacer"
+	"github.com/kyverno/chainsaw/pkg/logging"
+	"github.com/kyverno/chainsaw/pkg/mocks"
+	"github.com/kyverno/chainsaw/pkg/model"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/portforward"
+)
+
+type fakeForwarder struct {
+	local     uint16
+	err       error
+	stopChan  <-chan struct{}
+	readyChan chan struct{}
+	stopped   bool
+}
+
+func (f *fakeForwarder) ForwardPorts() error {
+	if f.err != nil {
+		return f.err
+	}
+	close(f.readyChan)
+	<-f.stopChan
+	f.stopped = true
+	return nil
+}
+
+func (f *fakeForwarder) GetPorts() ([]portforward.ForwardedPort, error) {
+	return []portforward.ForwardedPort{{Local: f.local}}, nil
+}
+
+func Test_operation(t *testing.T) {
+	pod := corev1.Pod{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-pod",
+			Namespace: "foo",
+			Labels:    map[string]string{"app": "test"},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:  "main",
+				Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: 8080}},
+			}},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	service := corev1.Service{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-service",
+			Namespace: "foo",
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": "test"},
+			Ports: []corev1.ServicePort{{
+				Name:       "web",
+				Port:       80,
+				TargetPort: intstr.FromString("http"),
+			}, {
+				Name:       "metrics",
+				Port:       9090,
+				TargetPort: intstr.FromInt32(9091),
+			}},
+		},
+	}
+	other := service
+	other.Name = "other-service"
+	other.Spec.Selector = map[string]string{"app": "other"}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/namespaces/foo/pods/test-pod":
+			assert.NoError(t, json.NewEncoder(w).Encode(pod))
+		case "/api/v1/namespaces/foo/services/test-service":
+			assert.NoError(t, json.NewEncoder(w).Encode(service))
+		case "/api/v1/namespaces/foo/services/other-service":
+			assert.NoError(t, json.NewEncoder(w).Encode(other))
+		case "/api/v1/namespaces/foo/pods":
+			list := corev1.PodList{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "PodList"}}
+			if r.URL.Query().Get("labelSelector") == "app=test" {
+				list.Items = append(list.Items, pod)
+			}
+			assert.NoError(t, json.NewEncoder(w).Encode(list))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	podType := v1alpha1.ObjectType{APIVersion: "v1", Kind: "Pod"}
+	serviceType := v1alpha1.ObjectType{APIVersion: "v1", Kind: "Service"}
+	tests := []struct {
+		name        string
+		cfg         *rest.Config
+		portForward v1alpha1.PortForward
+		forwarder   *fakeForwarder
+		wantErr     string
+		wantURL     string
+		wantAddress string
+		wantPorts   []string
+		wantOutputs map[string]any
+	}{{
+		name:        "no config",
+		portForward: v1alpha1.PortForward{ObjectType: podType},
+		wantErr:     "cluster config not set",
+	}, {
+		name: "unsupported kind",
+		cfg:  &rest.Config{Host: server.URL},
+		portForward: v1alpha1.PortForward{
+			ObjectType: v1alpha1.ObjectType{APIVersion: "apps/v1", Kind: "Deployment"},
+			ObjectName: v1alpha1.ObjectName{Name: "test"},
+			Port:       "80",
+		},
+		wantErr: "port forward is only supported for v1/Pod and v1/Service (got apps/v1/Deployment)",
+	}, {
+		name: "no port",
+		cfg:  &rest.Config{Host: server.URL},
+		portForward: v1alpha1.PortForward{
+			ObjectType: podType,
+			ObjectName: v1alpha1.ObjectName{Name: "test-pod"},
+		},
+		wantErr: "a port must be specified",
+	}, {
+		name: "pod",
+		cfg:  &rest.Config{Host: server.URL},
+		portForward: v1alpha1.PortForward{
+			ObjectType: podType,
+			ObjectName: v1alpha1.ObjectName{Name: "test-pod"},
+			Port:       "8080",
+		},
+		forwarder:   &fakeForwarder{local: 34567},
+		wantURL:     "/api/v1/namespaces/foo/pods/test-pod/portforward",
+		wantAddress: "127.0.0.1",
+		wantPorts:   []string{":8080"},
+	}, {
+		name: "pod with named port",
+		cfg:  &rest.Config{Host: server.URL},
+		portForward: v1alpha1.PortForward{
+			ObjectType: podType,
+			ObjectName: v1alpha1.ObjectName{Name: "test-pod"},
+			Port:       "http",
+			LocalPort:  "9000",
+			Address:    "0.0.0.0",
+		},
+		forwarder:   &fakeForwarder{local: 9000},
+		wantURL:     "/api/v1/namespaces/foo/pods/test-pod/portforward",
+		wantAddress: "0.0.0.0",
+		wantPorts:   []string{"9000:8080"},
+	}, {
+		name: "pod with unknown named port",
+		cfg:  &rest.Config{Host: server.URL},
+		portForward: v1alpha1.PortForward{
+			ObjectType: podType,
+			ObjectName: v1alpha1.ObjectName{Name: "test-pod"},
+			Port:       "grpc",
+		},
+		wantErr: "port grpc not found in pod foo/test-pod",
+	}, {
+		name: "service with named target port",
+		cfg:  &rest.Config{Host: server.URL},
+		portForward: v1alpha1.PortForward{
+			ObjectType: serviceType,
+			ObjectName: v1alpha1.ObjectName{Name: "test-service"},
+			Port:       "web",
+		},
+		forwarder:   &fakeForwarder{local: 34567},
+		wantURL:     "/api/v1/namespaces/foo/pods/test-pod/portforward",
+		wantAddress: "127.0.0.1",
+		wantPorts:   []string{":8080"},
+	}, {
+		name: "service with port number",
+		cfg:  &rest.Config{Host: server.URL},
+		portForward: v1alpha1.PortForward{
+			ObjectType: serviceType,
+			ObjectName: v1alpha1.ObjectName{Name: "test-service"},
+			Port:       "9090",
+		},
+		forwarder:   &fakeForwarder{local: 34567},
+		wantURL:     "/api/v1/namespaces/foo/pods/test-pod/portforward",
+		wantAddress: "127.0.0.1",
+		wantPorts:   []string{":9091"},
+	}, {
+		name: "service with unknown port",
+		cfg:  &rest.Config{Host: server.URL},
+		portForward: v1alpha1.PortForward{
+			ObjectType: serviceType,
+			ObjectName: v1alpha1.ObjectName{Name: "test-service"},
+			Port:       "443",
+		},
+		wantErr: "port 443 not found in service foo/test-service",
+	}, {
+		name: "service without running pod",
+		cfg:  &rest.Config{Host: server.URL},
+		portForward: v1alpha1.PortForward{
+			ObjectType: serviceType,
+			ObjectName: v1alpha1.ObjectName{Name: "other-service"},
+			Port:       "web",
+		},
+		wantErr: "no running pod found in foo namespace matching selector app=other",
+	}, {
+		name: "forward error",
+		cfg:  &rest.Config{Host: server.URL},
+		portForward: v1alpha1.PortForward{
+			ObjectType: podType,
+			ObjectName: v1alpha1.ObjectName{Name: "test-pod"},
+			Port:       "8080",
+		},
+		forwarder: &fakeForwarder{err: errors.New("unable to listen on any of the requested ports")},
+		wantErr:   "unable to listen on any of the requested ports",
+	}, {
+		name: "outputs",
+		cfg:  &rest.Config{Host: server.URL},
+		portForward: v1alpha1.PortForward{
+			ObjectType: podType,
+			ObjectName: v1alpha1.ObjectName{Name: "test-pod"},
+			ActionOutputs: v1alpha1.ActionOutputs{
+				Outputs: []v1alpha1.Output{{
+					Binding: v1alpha1.Binding{
+						Name:  "address",
+						Value: v1alpha1.NewProjection("($address)"),
+					},
+				}, {
+					Binding: v1alpha1.Binding{
+						Name:  "port",
+						Value: v1alpha1.NewProjection("($localPort)"),
+					},
+				}},
+			},
+			Port: "8080",
+		},
+		forwarder:   &fakeForwarder{local: 34567},
+		wantURL:     "/api/v1/namespaces/foo/pods/test-pod/portforward",
+		wantAddress: "127.0.0.1",
+		wantPorts:   []string{":8080"},
+		wantOutputs: map[string]any{
+			"address": "127.0.0.1:34567",
+			"port":    34567,
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := logging.WithLogger(context.TODO(), &mocks.Logger{})
+			cleaner := cleaner.New(time.Second, false, nil, metav1.DeletePropagationBackground)
+			operation := New(
+				apis.DefaultCompilers,
+				tt.cfg,
+				namespacer.New("foo"),
+				cleaner,
+				tt.portForward,
+			).(*operation)
+			var gotURL *url.URL
+			var gotAddress string
+			var gotPorts []string
+			operation.newForwarder = func(_ *rest.Config, url *url.URL, address string, ports []string, stopChan <-chan struct{}, readyChan chan struct{}) (forwarder, error) {
+				gotURL, gotAddress, gotPorts = url, address, ports
+				tt.forwarder.stopChan = stopChan
+				tt.forwarder.readyChan = readyChan
+				return tt.forwarder, nil
+			}
+			outputs, err := operation.Exec(ctx, nil)
+			if tt.wantErr != "" {
+				assert.Error(t, err)
+				if err != nil {
+					assert.Contains(t, err.Error(), tt.wantErr)
+				}
+				assert.True(t, cleaner.Empty())
+				return
+			}
+			assert.NoError(t, err)
+			if tt.wantURL != "" {
+				assert.NotNil(t, gotURL)
+				if gotURL != nil {
+					assert.Equal(t, tt.wantURL, gotURL.RequestURI())
+				}
+			}
+			assert.Equal(t, tt.wantAddress, gotAddress)
+			assert.Equal(t, tt.wantPorts, gotPorts)
+			if tt.wantOutputs != nil {
+				assert.Equal(t, tt.wantOutputs, map[string]any(outputs))
+			}
+			// the port forward remains open until the cleaner runs
+			assert.False(t, tt.forwarder.stopped)
+			report := &model.StepReport{}
+			assert.Nil(t, cleaner.Run(ctx, report))
+			assert.True(t, tt.forwarder.stopped)
+			assert.Len(t, report.Operations, 1)
+			assert.Equal(t, model.OperationTypePortForward, report.Operations[0].Type)
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package process
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	apibindings "github.com/kyverno/chainsaw/pkg/engine/bindings"
+	"github.com/kyverno/chainsaw/pkg/engine/checks"
+	"github.com/kyverno/chainsaw/pkg/engine/operations"
+	operrors "github.com/kyverno/chainsaw/pkg/engine/operations/errors"
+	"github.com/kyverno/chainsaw/pkg/engine/operations/internal"
+	"github.com/kyverno/chainsaw/pkg/engine/outputs"
+	"github.com/kyverno/chainsaw/pkg/logging"
+	"github.com/kyverno/kyverno-json/pkg/core/compilers"
+	"github.com/kyverno/pkg/ext/output/color"
+)
+
+type operation struct {
+	compilers compilers.Compilers
+	process   v1alpha1.Process
+}
+
+func New(
+	compilers compilers.Compilers,
+	process v1alpha1.Process,
+) operations.Operation {
+	return &operation{
+		compilers: compilers,
+		process:   process,
+	}
+}
+
+func (o *operation) Exec(ctx context.Context, bindings apis.Bindings) (_ outputs.Outputs, _err error) {
+	if bindings == nil {
+		bindings = apis.NewBindings()
+	}
+	defer func() {
+		internal.LogEnd(ctx, logging.Process, nil, _err)
+	}()
+	handle, err := o.process.Handle.Value(ctx, o.compilers, bindings)
+	if err != nil {
+		return nil, err
+	}
+	if handle == "" {
+		return nil, errors.New("a handle must be specified")
+	}
+	process, err := internal.GetProcess(handle)
+	if err != nil {
+		return nil, err
+	}
+	action := "WAIT"
+	if o.process.Stop {
+		action = "STOP"
+	}
+	internal.LogStart(ctx, logging.Process, nil, logging.Section(action, handle))
+	if o.process.Stop {
+		err = process.Stop(ctx)
+	} else {
+		err = process.Wait(ctx)
+	}
+	// the process is still running, its output can't be read yet
+	if ctx.Err() != nil && errors.Is(err, ctx.Err()) {
+		return nil, err
+	}
+	return o.execute(ctx, bindings, process, err)
+}
+
+func (o *operation) execute(ctx context.Context, bindings apis.Bindings, process *internal.Process, err error) (_outputs outputs.Outputs, _err error) {
+	output := &process.Output
+	if !process.SkipLogOutput {
+		defer func() {
+			if sections := output.Sections(); len(sections) != 0 {
+				logging.Log(ctx, logging.Process, logging.LogStatus, nil, color.BoldFgCyan, sections...)
+			}
+		}()
+	}
+	bindings = apibindings.RegisterBinding(bindings, "stdout", output.Out())
+	bindings = apibindings.RegisterBinding(bindings, "stderr", output.Err())
+	if err == nil {
+		bindings = apibindings.RegisterBinding(bindings, "error", nil)
+	} else {
+		bindings = apibindings.RegisterBinding(bindings, "error", err.Error())
+	}
+	defer func(bindings apis.Bindings) {
+		if _err == nil {
+			outputs, err := outputs.Process(ctx, o.compilers, bindings, nil, o.process.Outputs...)
+			if err != nil {
+				_err = err
+				return
+			}
+			_outputs = outputs
+		}
+	}(bindings)
+	if o.process.Check == nil || o.process.Check.IsNil() {
+		return nil, operrors.CommandError(err, output.Out(), output.Err())
+	}
+	if errs, err := checks.Check(ctx, o.compilers, nil, bindings, o.process.Check); err != nil {
+		return nil, err
+	} else {
+		return nil, operrors.CommandError(errs.ToAggregate(), output.Out(), output.Err())
+	}
+}
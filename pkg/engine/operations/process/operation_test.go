@@ -0,0 +1,112 @@
+package process
+
+import (
+	"context"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/engine/operations/internal"
+	"github.com/kyverno/chainsaw/pkg/logging"
+	"github.com/kyverno/chainsaw/pkg/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_operation(t *testing.T) {
+	tests := []struct {
+		name        string
+		command     []string
+		process     v1alpha1.Process
+		timeout     time.Duration
+		wantErr     string
+		wantOutputs map[string]any
+	}{{
+		name:    "no handle",
+		wantErr: "a handle must be specified",
+	}, {
+		name:    "unknown handle",
+		process: v1alpha1.Process{Handle: "process-0"},
+		wantErr: "process process-0 not found",
+	}, {
+		name:    "wait",
+		command: []string{"sh", "-c", "echo hello"},
+		process: v1alpha1.Process{
+			ActionOutputs: v1alpha1.ActionOutputs{
+				Outputs: []v1alpha1.Output{{
+					Binding: v1alpha1.Binding{
+						Name:  "out",
+						Value: v1alpha1.NewProjection("($stdout)"),
+					},
+				}},
+			},
+		},
+		wantOutputs: map[string]any{
+			"out": "hello\n",
+		},
+	}, {
+		name:    "wait failure",
+		command: []string{"sh", "-c", "echo boom >&2; exit 2"},
+		wantErr: "exit status 2",
+	}, {
+		name:    "wait failure with check",
+		command: []string{"sh", "-c", "echo boom >&2; exit 2"},
+		process: v1alpha1.Process{
+			ActionCheck: v1alpha1.ActionCheck{
+				Check: new(v1alpha1.NewCheck(map[string]any{
+					"($error)":  "exit status 2",
+					"($stderr)": "boom\n",
+				})),
+			},
+		},
+	}, {
+		name:    "wait timeout",
+		command: []string{"sleep", "30"},
+		timeout: 100 * time.Millisecond,
+		wantErr: "context deadline exceeded",
+	}, {
+		name:    "stop",
+		command: []string{"sh", "-c", "echo started; exec sleep 30"},
+		process: v1alpha1.Process{
+			Stop: true,
+			ActionCheck: v1alpha1.ActionCheck{
+				Check: new(v1alpha1.NewCheck(map[string]any{
+					"($error)": nil,
+				})),
+			},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := logging.WithLogger(context.TODO(), &mocks.Logger{})
+			process := tt.process
+			if len(tt.command) != 0 {
+				handle, _, err := internal.StartProcess(exec.Command(tt.command[0], tt.command[1:]...), false, nil) //nolint:gosec
+				assert.NoError(t, err)
+				defer func() {
+					assert.NoError(t, internal.ReleaseProcess(ctx, handle))
+				}()
+				process.Handle = v1alpha1.Expression(handle)
+			}
+			timeout := tt.timeout
+			if timeout == 0 {
+				timeout = 10 * time.Second
+			}
+			execCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			outputs, err := New(apis.DefaultCompilers, process).Exec(execCtx, nil)
+			if tt.wantErr != "" {
+				assert.Error(t, err)
+				if err != nil {
+					assert.Contains(t, err.Error(), tt.wantErr)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+			if tt.wantOutputs != nil {
+				assert.Equal(t, tt.wantOutputs, map[string]any(outputs))
+			}
+		})
+	}
+}
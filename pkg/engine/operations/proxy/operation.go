@@ -0,0 +1,120 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"path"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/client"
+	apibindings "github.com/kyverno/chainsaw/pkg/engine/bindings"
+	"github.com/kyverno/chainsaw/pkg/engine/namespacer"
+	"github.com/kyverno/chainsaw/pkg/engine/operations"
+	"github.com/kyverno/chainsaw/pkg/engine/operations/internal"
+	"github.com/kyverno/chainsaw/pkg/engine/outputs"
+	"github.com/kyverno/chainsaw/pkg/logging"
+	"github.com/kyverno/kyverno-json/pkg/core/compilers"
+	"github.com/kyverno/pkg/ext/output/color"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+)
+
+type operation struct {
+	compilers  compilers.Compilers
+	client     client.Client
+	cfg        *rest.Config
+	namespacer namespacer.Namespacer
+	proxy      v1alpha1.Proxy
+}
+
+func New(
+	compilers compilers.Compilers,
+	client client.Client,
+	cfg *rest.Config,
+	namespacer namespacer.Namespacer,
+	proxy v1alpha1.Proxy,
+) operations.Operation {
+	return &operation{
+		compilers:  compilers,
+		client:     client,
+		cfg:        cfg,
+		namespacer: namespacer,
+		proxy:      proxy,
+	}
+}
+
+func (o *operation) Exec(ctx context.Context, bindings apis.Bindings) (_ outputs.Outputs, _err error) {
+	if bindings == nil {
+		bindings = apis.NewBindings()
+	}
+	var obj client.Object
+	defer func() {
+		internal.LogEnd(ctx, logging.Proxy, obj, _err)
+	}()
+	if o.client == nil {
+		return nil, errors.New("cluster client not set")
+	}
+	if o.cfg == nil {
+		return nil, errors.New("cluster config not set")
+	}
+	name, err := o.proxy.Name.Value(ctx, o.compilers, bindings)
+	if err != nil {
+		return nil, err
+	}
+	namespace, err := o.proxy.Namespace.Value(ctx, o.compilers, bindings)
+	if err != nil {
+		return nil, err
+	}
+	targetPath, err := o.proxy.TargetPath.Value(ctx, o.compilers, bindings)
+	if err != nil {
+		return nil, err
+	}
+	targetPort, err := o.proxy.TargetPort.Value(ctx, o.compilers, bindings)
+	if err != nil {
+		return nil, err
+	}
+	mapping, err := internal.MapResource(ctx, o.compilers, o.client, bindings, o.proxy.ObjectType)
+	if err != nil {
+		return nil, err
+	}
+	if namespace == "" && o.namespacer != nil {
+		namespace = o.namespacer.GetNamespace()
+	}
+	target := &unstructured.Unstructured{}
+	target.SetGroupVersionKind(mapping.GroupVersionKind)
+	target.SetName(name)
+	target.SetNamespace(namespace)
+	obj = target
+	gv := mapping.Resource.GroupVersion()
+	apiPath := path.Join("/apis", gv.Group, gv.Version)
+	if gv.Group == "" {
+		apiPath = path.Join("/api", gv.Version)
+	}
+	absPath := path.Join(apiPath, "namespaces", namespace, mapping.Resource.Resource, name+":"+targetPort, "proxy", targetPath)
+	internal.LogStart(ctx, logging.Proxy, obj, logging.Section("PATH", absPath))
+	return o.execute(ctx, bindings, gv, absPath)
+}
+
+func (o *operation) execute(ctx context.Context, bindings apis.Bindings, gv schema.GroupVersion, absPath string) (outputs.Outputs, error) {
+	var output internal.CommandOutput
+	defer func() {
+		if sections := output.Sections(); len(sections) != 0 {
+			logging.Log(ctx, logging.Proxy, logging.LogStatus, nil, color.BoldFgCyan, sections...)
+		}
+	}()
+	rc, err := internal.RESTClient(o.cfg, gv)
+	if err != nil {
+		return nil, err
+	}
+	data, err := rc.Get().AbsPath(absPath).DoRaw(ctx)
+	if err != nil {
+		return nil, err
+	}
+	output.Stdout.Write(data)
+	bindings = apibindings.RegisterBinding(bindings, "stdout", output.Out())
+	bindings = apibindings.RegisterBinding(bindings, "stderr", output.Err())
+	bindings = apibindings.RegisterBinding(bindings, "error", nil)
+	return outputs.Process(ctx, o.compilers, bindings, nil, o.proxy.Outputs...)
+}
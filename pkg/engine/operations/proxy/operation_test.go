@@ -0,0 +1,103 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/client"
+	tclient "github.com/kyverno/chainsaw/pkg/client/testing"
+	"github.com/kyverno/chainsaw/pkg/engine/namespacer"
+	"github.com/kyverno/chainsaw/pkg/engine/outputs"
+	"github.com/kyverno/chainsaw/pkg/logging"
+	"github.com/kyverno/chainsaw/pkg/mocks"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+)
+
+func Test_operation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/namespaces/foo/services/test-svc:8080/proxy/metrics" {
+			_, _ = w.Write([]byte("up 1"))
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Service"}, meta.RESTScopeNamespace)
+	fake := &tclient.FakeClient{RESTMapperFn: func(int) meta.RESTMapper { return mapper }}
+	service := v1alpha1.ObjectType{APIVersion: "v1", Kind: "Service"}
+	tests := []struct {
+		name            string
+		client          client.Client
+		cfg             *rest.Config
+		proxy           v1alpha1.Proxy
+		expectedOutputs outputs.Outputs
+		expectedErr     error
+		expectedLogs    []string
+	}{{
+		name:         "no client",
+		cfg:          &rest.Config{Host: server.URL},
+		expectedErr:  errors.New("cluster client not set"),
+		expectedLogs: []string{"PROXY: ERROR - [=== ERROR\ncluster client not set]"},
+	}, {
+		name:   "ok",
+		client: fake,
+		cfg:    &rest.Config{Host: server.URL},
+		proxy: v1alpha1.Proxy{
+			ObjectType: service,
+			ObjectName: v1alpha1.ObjectName{Name: "test-svc"},
+			TargetPort: "8080",
+			TargetPath: "metrics",
+			ActionOutputs: v1alpha1.ActionOutputs{
+				Outputs: []v1alpha1.Output{{
+					Binding: v1alpha1.Binding{Name: "metrics", Value: v1alpha1.NewProjection("($stdout)")},
+				}},
+			},
+		},
+		expectedOutputs: outputs.Outputs{"metrics": "up 1"},
+		expectedLogs: []string{
+			"PROXY: RUN - [=== PATH\n/api/v1/namespaces/foo/services/test-svc:8080/proxy/metrics]",
+			"PROXY: LOG - [=== STDOUT\nup 1]",
+			"PROXY: DONE - []",
+		},
+	}, {
+		name:   "not found",
+		client: fake,
+		cfg:    &rest.Config{Host: server.URL},
+		proxy: v1alpha1.Proxy{
+			ObjectType: service,
+			ObjectName: v1alpha1.ObjectName{Name: "other", Namespace: "bar"},
+			TargetPort: "80",
+		},
+		expectedErr: errors.New("the server could not find the requested resource"),
+		expectedLogs: []string{
+			"PROXY: RUN - [=== PATH\n/api/v1/namespaces/bar/services/other:80/proxy]",
+			"PROXY: ERROR - [=== ERROR\nthe server could not find the requested resource]",
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			operation := New(apis.DefaultCompilers, tt.client, tt.cfg, namespacer.New("foo"), tt.proxy)
+			logger := &mocks.Logger{}
+			outputs, err := operation.Exec(logging.WithLogger(ctx, logger), nil)
+			assert.Equal(t, tt.expectedOutputs, outputs)
+			if tt.expectedErr != nil {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedLogs, logger.Logs)
+		})
+	}
+}
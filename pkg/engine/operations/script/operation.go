@@ -2,6 +2,7 @@ package script
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -9,12 +10,15 @@ import (
 
 	"github.com/kyverno/chainsaw/pkg/apis"
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/cleanup/cleaner"
 	apibindings "github.com/kyverno/chainsaw/pkg/engine/bindings"
 	"github.com/kyverno/chainsaw/pkg/engine/checks"
 	"github.com/kyverno/chainsaw/pkg/engine/operations"
+	operrors "github.com/kyverno/chainsaw/pkg/engine/operations/errors"
 	"github.com/kyverno/chainsaw/pkg/engine/operations/internal"
 	"github.com/kyverno/chainsaw/pkg/engine/outputs"
 	"github.com/kyverno/chainsaw/pkg/logging"
+	"github.com/kyverno/chainsaw/pkg/model"
 	restutils "github.com/kyverno/chainsaw/pkg/utils/rest"
 	"github.com/kyverno/kyverno-json/pkg/core/compilers"
 	"github.com/kyverno/pkg/ext/output/color"
@@ -27,6 +31,7 @@ type operation struct {
 	basePath  string
 	namespace string
 	cfg       *rest.Config
+	cleaner   cleaner.CleanerCollector
 }
 
 func New(
@@ -35,6 +40,7 @@ func New(
 	basePath string,
 	namespace string,
 	cfg *rest.Config,
+	cleaner cleaner.CleanerCollector,
 ) operations.Operation {
 	return &operation{
 		compilers: compilers,
@@ -42,6 +48,7 @@ func New(
 		basePath:  basePath,
 		namespace: namespace,
 		cfg:       cfg,
+		cleaner:   cleaner,
 	}
 }
 
@@ -52,11 +59,19 @@ func (o *operation) Exec(ctx context.Context, bindings apis.Bindings) (_ outputs
 	defer func() {
 		internal.LogEnd(ctx, logging.Script, nil, _err)
 	}()
-	cmd, cancel, _err := o.createCommand(ctx, bindings)
-	if cancel != nil {
-		defer cancel()
+	if o.script.Background {
+		if o.cleaner == nil {
+			return nil, errors.New("cleaner not set")
+		}
+		if o.script.Check != nil && !o.script.Check.IsNil() {
+			return nil, errors.New("check is not supported for background scripts, use a process operation instead")
+		}
 	}
+	cmd, cancel, _err := o.createCommand(ctx, bindings)
 	if _err != nil {
+		if cancel != nil {
+			cancel()
+		}
 		return nil, _err
 	}
 	var logOpts []fmt.Stringer
@@ -64,6 +79,12 @@ func (o *operation) Exec(ctx context.Context, bindings apis.Bindings) (_ outputs
 		logOpts = append(logOpts, logging.Section("COMMAND", cmd.String()))
 	}
 	internal.LogStart(ctx, logging.Command, nil, logOpts...)
+	if o.script.Background {
+		return o.start(ctx, bindings, cmd, cancel)
+	}
+	if cancel != nil {
+		defer cancel()
+	}
 	return o.execute(ctx, bindings, cmd)
 }
 
@@ -102,7 +123,12 @@ func (o *operation) createCommand(ctx context.Context, bindings apis.Bindings) (
 		shellArgs = o.script.ShellArgs
 	}
 	shellArgs = append(shellArgs, o.script.Content)
-	cmd := exec.CommandContext(ctx, shell, shellArgs...)
+	cmdCtx := ctx
+	if o.script.Background {
+		// the process outlives the operation, it is stopped through its handle or by the cleaner
+		cmdCtx = context.WithoutCancel(ctx)
+	}
+	cmd := exec.CommandContext(cmdCtx, shell, shellArgs...)
 	cmd.Env = env
 	basePath := o.basePath
 	if o.script.WorkDir != nil {
@@ -116,6 +142,19 @@ func (o *operation) createCommand(ctx context.Context, bindings apis.Bindings) (
 	return cmd, cancel, nil
 }
 
+func (o *operation) start(ctx context.Context, bindings apis.Bindings, cmd *exec.Cmd, release func()) (outputs.Outputs, error) {
+	handle, _, err := internal.StartProcess(cmd, o.script.SkipLogOutput, release)
+	if err != nil {
+		return nil, err
+	}
+	o.cleaner.AddFunc(model.OperationTypeScript, func(ctx context.Context) error {
+		return internal.ReleaseProcess(ctx, handle)
+	})
+	logging.Log(ctx, logging.Script, logging.LogStatus, nil, color.BoldFgCyan, logging.Section("HANDLE", handle))
+	bindings = apibindings.RegisterBinding(bindings, "handle", handle)
+	return outputs.Process(ctx, o.compilers, bindings, nil, o.script.Outputs...)
+}
+
 func (o *operation) execute(ctx context.Context, bindings apis.Bindings, cmd *exec.Cmd) (_outputs outputs.Outputs, _err error) {
 	var output internal.CommandOutput
 	if !o.script.SkipLogOutput {
@@ -146,11 +185,11 @@ func (o *operation) execute(ctx context.Context, bindings apis.Bindings, cmd *ex
 		}
 	}(bindings)
 	if o.script.Check == nil || o.script.Check.IsNil() {
-		return nil, err
+		return nil, operrors.CommandError(err, output.Out(), output.Err())
 	}
 	if errs, err := checks.Check(ctx, o.compilers, nil, bindings, o.script.Check); err != nil {
 		return nil, err
 	} else {
-		return nil, errs.ToAggregate()
+		return nil, operrors.CommandError(errs.ToAggregate(), output.Out(), output.Err())
 	}
 }
@@ -3,12 +3,16 @@ package script
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/kyverno/chainsaw/pkg/apis"
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/cleanup/cleaner"
+	"github.com/kyverno/chainsaw/pkg/engine/operations/internal"
 	"github.com/kyverno/chainsaw/pkg/logging"
 	"github.com/kyverno/chainsaw/pkg/mocks"
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func Test_operationScript(t *testing.T) {
@@ -142,6 +146,7 @@ func Test_operationScript(t *testing.T) {
 				tt.basePath,
 				tt.namespace,
 				nil,
+				nil,
 			)
 			_, err := operation.Exec(ctx, nil)
 			if tt.wantErr {
@@ -155,3 +160,36 @@ func Test_operationScript(t *testing.T) {
 		})
 	}
 }
+
+func Test_operationScript_background(t *testing.T) {
+	ctx := logging.WithLogger(context.TODO(), &mocks.Logger{})
+	c := cleaner.New(time.Second, false, nil, metav1.DeletePropagationBackground)
+	operation := New(
+		apis.DefaultCompilers,
+		v1alpha1.Script{
+			Content:    "echo hello",
+			Background: true,
+			ActionOutputs: v1alpha1.ActionOutputs{
+				Outputs: []v1alpha1.Output{{
+					Binding: v1alpha1.Binding{
+						Name:  "handle",
+						Value: v1alpha1.NewProjection("($handle)"),
+					},
+				}},
+			},
+		},
+		"",
+		"test-namespace",
+		nil,
+		c,
+	)
+	outputs, err := operation.Exec(ctx, nil)
+	assert.NoError(t, err)
+	handle, ok := outputs["handle"].(string)
+	assert.True(t, ok)
+	process, err := internal.GetProcess(handle)
+	assert.NoError(t, err)
+	assert.NoError(t, process.Wait(ctx))
+	assert.Equal(t, "hello\n", process.Output.Out())
+	assert.Nil(t, c.Run(ctx, nil))
+}
@@ -0,0 +1,114 @@
+package wait
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+type condition = func(unstructured.Unstructured) (bool, error)
+
+func (o *operation) condition(ctx context.Context, bindings apis.Bindings) (condition, error) {
+	switch {
+	case o.wait.Deletion != nil:
+		return func(unstructured.Unstructured) (bool, error) {
+			return false, nil
+		}, nil
+	case o.wait.Creation != nil:
+		return func(unstructured.Unstructured) (bool, error) {
+			return true, nil
+		}, nil
+	case o.wait.Condition != nil:
+		name, err := o.wait.Condition.Name.Value(ctx, o.compilers, bindings)
+		if err != nil {
+			return nil, err
+		}
+		if name == "" {
+			return nil, errors.New("a condition name must be specified for condition wait type")
+		}
+		value := "True"
+		if o.wait.Condition.Value != nil {
+			value, err = o.wait.Condition.Value.Value(ctx, o.compilers, bindings)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return conditionMet(name, value), nil
+	case o.wait.JsonPath != nil:
+		path, err := o.wait.JsonPath.Path.Value(ctx, o.compilers, bindings)
+		if err != nil {
+			return nil, err
+		}
+		if path == "" {
+			return nil, errors.New("a path must be specified for jsonpath wait type")
+		}
+		var value *string
+		if o.wait.JsonPath.Value != nil {
+			v, err := o.wait.JsonPath.Value.Value(ctx, o.compilers, bindings)
+			if err != nil {
+				return nil, err
+			}
+			if v == "" {
+				return nil, errors.New("a value must be specified for jsonpath wait type")
+			}
+			value = &v
+		}
+		return jsonPathMet(path, value)
+	default:
+		return nil, errors.New("either a deletion or a condition must be specified")
+	}
+}
+
+func conditionMet(name, value string) condition {
+	return func(obj unstructured.Unstructured) (bool, error) {
+		conditions, _, err := unstructured.NestedSlice(obj.UnstructuredContent(), "status", "conditions")
+		if err != nil {
+			return false, nil
+		}
+		for _, c := range conditions {
+			c, ok := c.(map[string]any)
+			if !ok {
+				continue
+			}
+			if t, _ := c["type"].(string); strings.EqualFold(t, name) {
+				status := fmt.Sprint(c["status"])
+				return strings.EqualFold(status, value), nil
+			}
+		}
+		return false, nil
+	}
+}
+
+func jsonPathMet(path string, value *string) (condition, error) {
+	if !strings.HasPrefix(path, "{") {
+		path = "{" + path + "}"
+	}
+	parser := jsonpath.New("wait").AllowMissingKeys(true)
+	if err := parser.Parse(path); err != nil {
+		return nil, err
+	}
+	return func(obj unstructured.Unstructured) (bool, error) {
+		results, err := parser.FindResults(obj.UnstructuredContent())
+		if err != nil {
+			return false, err
+		}
+		var found []string
+		for _, result := range results {
+			for _, r := range result {
+				found = append(found, fmt.Sprint(r.Interface()))
+			}
+		}
+		if value == nil {
+			return len(found) != 0, nil
+		}
+		if len(found) != 1 {
+			return false, nil
+		}
+		return found[0] == *value, nil
+	}, nil
+}
@@ -0,0 +1,130 @@
+package wait
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/client"
+	"github.com/kyverno/chainsaw/pkg/engine/namespacer"
+	"github.com/kyverno/chainsaw/pkg/engine/operations"
+	"github.com/kyverno/chainsaw/pkg/engine/operations/internal"
+	"github.com/kyverno/chainsaw/pkg/engine/outputs"
+	"github.com/kyverno/chainsaw/pkg/logging"
+	"github.com/kyverno/kyverno-json/pkg/core/compilers"
+	"github.com/kyverno/pkg/ext/output/color"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+type operation struct {
+	compilers  compilers.Compilers
+	client     client.Client
+	namespacer namespacer.Namespacer
+	wait       v1alpha1.Wait
+}
+
+func New(
+	compilers compilers.Compilers,
+	client client.Client,
+	namespacer namespacer.Namespacer,
+	wait v1alpha1.Wait,
+) operations.Operation {
+	return &operation{
+		compilers:  compilers,
+		client:     client,
+		namespacer: namespacer,
+		wait:       wait,
+	}
+}
+
+func (o *operation) Exec(ctx context.Context, bindings apis.Bindings) (_ outputs.Outputs, _err error) {
+	if bindings == nil {
+		bindings = apis.NewBindings()
+	}
+	var obj client.Object
+	defer func() {
+		internal.LogEnd(ctx, logging.Wait, obj, _err)
+	}()
+	ref, err := internal.ResolveObject(ctx, o.compilers, o.client, bindings, o.namespacer, o.wait.ObjectType, o.wait.ActionObjectSelector)
+	if err != nil {
+		return nil, err
+	}
+	format, err := v1alpha1.Expression(o.wait.Format).Value(ctx, o.compilers, bindings)
+	if err != nil {
+		return nil, err
+	}
+	met, err := o.condition(ctx, bindings)
+	if err != nil {
+		return nil, err
+	}
+	obj = ref.Object()
+	internal.LogStart(ctx, logging.Wait, obj)
+	var output internal.CommandOutput
+	defer func() {
+		if sections := output.Sections(); len(sections) != 0 {
+			logging.Log(ctx, logging.Wait, logging.LogStatus, obj, color.BoldFgCyan, sections...)
+		}
+	}()
+	return nil, o.execute(ctx, ref, met, format, &output)
+}
+
+func (o *operation) execute(ctx context.Context, ref *internal.ObjectRef, met condition, format string, output *internal.CommandOutput) error {
+	deletion := o.wait.Deletion != nil
+	var seen, pending []unstructured.Unstructured
+	err := wait.PollUntilContextCancel(ctx, client.PollInterval, true, func(ctx context.Context) (bool, error) {
+		objs, err := internal.ReadObjects(ctx, o.client, ref)
+		if err != nil {
+			if !kerrors.IsNotFound(err) || !(deletion || o.wait.Creation != nil) {
+				return false, err
+			}
+			objs = nil
+		}
+		if len(objs) == 0 && !deletion && o.wait.Creation == nil {
+			return false, errors.New("no matching resources found")
+		}
+		if !deletion || seen == nil {
+			seen = objs
+		}
+		pending = pending[:0]
+		for _, obj := range objs {
+			if ok, err := met(obj); err != nil {
+				return false, err
+			} else if !ok {
+				pending = append(pending, obj)
+			}
+		}
+		if deletion {
+			return len(objs) == 0, nil
+		}
+		return len(objs) != 0 && len(pending) == 0, nil
+	})
+	if err != nil {
+		if ctx.Err() == nil {
+			return err
+		}
+		var names []string
+		for _, obj := range pending {
+			names = append(names, ref.ObjectName(obj))
+		}
+		if len(names) == 0 {
+			if ref.Name != "" {
+				names = append(names, ref.Resource()+"/"+ref.Name)
+			} else {
+				names = append(names, ref.Resource())
+			}
+		}
+		return fmt.Errorf("timed out waiting for the condition on %s", strings.Join(names, ", "))
+	}
+	if format != "" && !deletion {
+		return internal.PrintObjects(&output.Stdout, format, ref.Name != "", seen...)
+	}
+	for _, obj := range seen {
+		fmt.Fprintf(&output.Stdout, "%s condition met\n", ref.ObjectName(obj))
+	}
+	return nil
+}
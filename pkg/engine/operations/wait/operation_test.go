@@ -0,0 +1,237 @@
+package wait
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/client"
+	tclient "github.com/kyverno/chainsaw/pkg/client/testing"
+	"github.com/kyverno/chainsaw/pkg/engine/namespacer"
+	"github.com/kyverno/chainsaw/pkg/logging"
+	"github.com/kyverno/chainsaw/pkg/mocks"
+	"github.com/stretchr/testify/assert"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func restMapper() meta.RESTMapper {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+	return mapper
+}
+
+func deployment(status string) unstructured.Unstructured {
+	return unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata": map[string]any{
+				"name":      "test",
+				"namespace": "foo",
+			},
+			"status": map[string]any{
+				"replicas": int64(1),
+				"conditions": []any{
+					map[string]any{
+						"type":   "Available",
+						"status": status,
+					},
+				},
+			},
+		},
+	}
+}
+
+func Test_operation(t *testing.T) {
+	objectType := v1alpha1.ObjectType{APIVersion: "apps/v1", Kind: "Deployment"}
+	named := v1alpha1.ActionObject{
+		ObjectType: objectType,
+		ActionObjectSelector: v1alpha1.ActionObjectSelector{
+			ObjectName: v1alpha1.ObjectName{Name: "test"},
+		},
+	}
+	tests := []struct {
+		name         string
+		client       client.Client
+		wait         v1alpha1.Wait
+		timeout      time.Duration
+		expectedErr  error
+		expectedLogs []string
+	}{{
+		name:   "no condition",
+		client: &tclient.FakeClient{RESTMapperFn: func(int) meta.RESTMapper { return restMapper() }},
+		wait: v1alpha1.Wait{
+			ActionObject: named,
+		},
+		expectedErr:  errors.New("either a deletion or a condition must be specified"),
+		expectedLogs: []string{"WAIT: ERROR - [=== ERROR\neither a deletion or a condition must be specified]"},
+	}, {
+		name:   "empty condition name",
+		client: &tclient.FakeClient{RESTMapperFn: func(int) meta.RESTMapper { return restMapper() }},
+		wait: v1alpha1.Wait{
+			ActionObject: named,
+			WaitFor: v1alpha1.WaitFor{
+				Condition: &v1alpha1.WaitForCondition{},
+			},
+		},
+		expectedErr:  errors.New("a condition name must be specified for condition wait type"),
+		expectedLogs: []string{"WAIT: ERROR - [=== ERROR\na condition name must be specified for condition wait type]"},
+	}, {
+		name: "condition",
+		client: &tclient.FakeClient{
+			RESTMapperFn: func(int) meta.RESTMapper { return restMapper() },
+			GetFn: func(_ context.Context, call int, _ client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+				status := "False"
+				if call > 3 {
+					status = "True"
+				}
+				*obj.(*unstructured.Unstructured) = deployment(status)
+				return nil
+			},
+		},
+		wait: v1alpha1.Wait{
+			ActionObject: named,
+			WaitFor: v1alpha1.WaitFor{
+				Condition: &v1alpha1.WaitForCondition{Name: "available"},
+			},
+		},
+		expectedLogs: []string{
+			"WAIT: RUN - []",
+			"WAIT: LOG - [=== STDOUT\ndeployment.apps/test condition met]",
+			"WAIT: DONE - []",
+		},
+	}, {
+		name: "condition timeout",
+		client: &tclient.FakeClient{
+			RESTMapperFn: func(int) meta.RESTMapper { return restMapper() },
+			GetFn: func(_ context.Context, _ int, _ client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+				*obj.(*unstructured.Unstructured) = deployment("False")
+				return nil
+			},
+		},
+		wait: v1alpha1.Wait{
+			ActionObject: named,
+			WaitFor: v1alpha1.WaitFor{
+				Condition: &v1alpha1.WaitForCondition{Name: "Available"},
+			},
+		},
+		timeout:     200 * time.Millisecond,
+		expectedErr: errors.New("timed out waiting for the condition on deployment.apps/test"),
+		expectedLogs: []string{
+			"WAIT: RUN - []",
+			"WAIT: ERROR - [=== ERROR\ntimed out waiting for the condition on deployment.apps/test]",
+		},
+	}, {
+		name: "no matching resources",
+		client: &tclient.FakeClient{
+			RESTMapperFn: func(int) meta.RESTMapper { return restMapper() },
+			ListFn: func(_ context.Context, _ int, _ client.ObjectList, _ ...client.ListOption) error {
+				return nil
+			},
+		},
+		wait: v1alpha1.Wait{
+			ActionObject: v1alpha1.ActionObject{ObjectType: objectType},
+			WaitFor: v1alpha1.WaitFor{
+				Condition: &v1alpha1.WaitForCondition{Name: "Available"},
+			},
+		},
+		expectedErr: errors.New("no matching resources found"),
+		expectedLogs: []string{
+			"WAIT: RUN - []",
+			"WAIT: ERROR - [=== ERROR\nno matching resources found]",
+		},
+	}, {
+		name: "jsonpath",
+		client: &tclient.FakeClient{
+			RESTMapperFn: func(int) meta.RESTMapper { return restMapper() },
+			ListFn: func(_ context.Context, _ int, list client.ObjectList, _ ...client.ListOption) error {
+				list.(*unstructured.UnstructuredList).Items = []unstructured.Unstructured{deployment("True")}
+				return nil
+			},
+		},
+		wait: v1alpha1.Wait{
+			ActionObject: v1alpha1.ActionObject{ObjectType: objectType},
+			WaitFor: v1alpha1.WaitFor{
+				JsonPath: &v1alpha1.WaitForJsonPath{Path: ".status.replicas", Value: new(v1alpha1.Expression("1"))},
+			},
+		},
+		expectedLogs: []string{
+			"WAIT: RUN - []",
+			"WAIT: LOG - [=== STDOUT\ndeployment.apps/test condition met]",
+			"WAIT: DONE - []",
+		},
+	}, {
+		name: "deletion",
+		client: &tclient.FakeClient{
+			RESTMapperFn: func(int) meta.RESTMapper { return restMapper() },
+			GetFn: func(_ context.Context, call int, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+				if call > 3 {
+					return kerrors.NewNotFound(schema.GroupResource{Group: "apps", Resource: "deployments"}, key.Name)
+				}
+				*obj.(*unstructured.Unstructured) = deployment("True")
+				return nil
+			},
+		},
+		wait: v1alpha1.Wait{
+			ActionObject: named,
+			WaitFor: v1alpha1.WaitFor{
+				Deletion: &v1alpha1.WaitForDeletion{},
+			},
+		},
+		expectedLogs: []string{
+			"WAIT: RUN - []",
+			"WAIT: LOG - [=== STDOUT\ndeployment.apps/test condition met]",
+			"WAIT: DONE - []",
+		},
+	}, {
+		name: "creation with format",
+		client: &tclient.FakeClient{
+			RESTMapperFn: func(int) meta.RESTMapper { return restMapper() },
+			GetFn: func(_ context.Context, call int, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+				if call < 3 {
+					return kerrors.NewNotFound(schema.GroupResource{Group: "apps", Resource: "deployments"}, key.Name)
+				}
+				*obj.(*unstructured.Unstructured) = deployment("True")
+				return nil
+			},
+		},
+		wait: v1alpha1.Wait{
+			ActionObject: named,
+			ActionFormat: v1alpha1.ActionFormat{Format: "json"},
+			WaitFor: v1alpha1.WaitFor{
+				Creation: &v1alpha1.WaitForCreation{},
+			},
+		},
+		expectedLogs: []string{
+			"WAIT: RUN - []",
+			"WAIT: LOG - [=== STDOUT\n{\n    \"apiVersion\": \"apps/v1\",\n    \"kind\": \"Deployment\",\n    \"metadata\": {\n        \"name\": \"test\",\n        \"namespace\": \"foo\"\n    },\n    \"status\": {\n        \"conditions\": [\n            {\n                \"status\": \"True\",\n                \"type\": \"Available\"\n            }\n        ],\n        \"replicas\": 1\n    }\n}]",
+			"WAIT: DONE - []",
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			timeout := tt.timeout
+			if timeout == 0 {
+				timeout = 10 * time.Second
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+			operation := New(apis.DefaultCompilers, tt.client, namespacer.New("foo"), tt.wait)
+			logger := &mocks.Logger{}
+			outputs, err := operation.Exec(logging.WithLogger(ctx, logger), nil)
+			assert.Nil(t, outputs)
+			if tt.expectedErr != nil {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedLogs, logger.Logs)
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package expressions
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/kyverno-json/pkg/core/compilers"
+)
+
+func Bool(ctx context.Context, c compilers.Compilers, in string, bindings apis.Bindings) (bool, error) {
+	expression := Parse(ctx, in)
+	if expression == nil {
+		return false, fmt.Errorf("expression didn't evaluate to a boolean (%s)", in)
+	}
+	if compiler := c.Compiler(expression.Engine); compiler == nil {
+		if converted, err := strconv.ParseBool(expression.Statement); err != nil {
+			return false, fmt.Errorf("expression didn't evaluate to a boolean (%s)", in)
+		} else {
+			return converted, nil
+		}
+	} else if converted, err := compilers.Execute(expression.Statement, nil, bindings, compiler); err != nil {
+		return false, err
+	} else if converted == nil {
+		return false, nil
+	} else {
+		if converted, ok := converted.(bool); !ok {
+			return false, fmt.Errorf("expression didn't evaluate to a boolean (%s)", in)
+		} else {
+			return converted, nil
+		}
+	}
+}
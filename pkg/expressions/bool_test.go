@@ -0,0 +1,84 @@
+package expressions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBool(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       string
+		bindings apis.Bindings
+		want     bool
+		wantErr  bool
+	}{{
+		name:     "empty",
+		in:       "",
+		bindings: apis.NewBindings(),
+		want:     false,
+		wantErr:  true,
+	}, {
+		name:     "error",
+		in:       "($foo)",
+		bindings: apis.NewBindings(),
+		want:     false,
+		wantErr:  true,
+	}, {
+		name:     "not bool",
+		in:       "(`42`)",
+		bindings: apis.NewBindings(),
+		want:     false,
+		wantErr:  true,
+	}, {
+		name:     "null",
+		in:       "(`null`)",
+		bindings: apis.NewBindings(),
+		want:     false,
+		wantErr:  false,
+	}, {
+		name:     "true",
+		in:       "(`true`)",
+		bindings: apis.NewBindings(),
+		want:     true,
+		wantErr:  false,
+	}, {
+		name:     "literal",
+		in:       "true",
+		bindings: apis.NewBindings(),
+		want:     true,
+		wantErr:  false,
+	}, {
+		name:     "bad literal",
+		in:       "foo",
+		bindings: apis.NewBindings(),
+		want:     false,
+		wantErr:  true,
+	}, {
+		name:     "binding",
+		in:       "($foo == 'bar')",
+		bindings: apis.NewBindings().Register("$foo", apis.NewBinding("bar")),
+		want:     true,
+		wantErr:  false,
+	}, {
+		name:     "cel",
+		in:       "(cel;bindings.resolve('foo') == 'baz')",
+		bindings: apis.NewBindings().Register("$foo", apis.NewBinding("bar")),
+		want:     false,
+		wantErr:  false,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Bool(context.TODO(), apis.DefaultCompilers, tt.in, tt.bindings)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
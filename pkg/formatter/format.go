@@ -0,0 +1,189 @@
+package formatter
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha2"
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	sigsyaml "sigs.k8s.io/yaml"
+)
+
+// types maps the supported documents to the type defining their fields order.
+var types = map[schema.GroupVersionKind]reflect.Type{
+	schema.GroupVersion(v1alpha1.GroupVersion).WithKind("Test"):          reflect.TypeFor[v1alpha1.Test](),
+	schema.GroupVersion(v1alpha1.GroupVersion).WithKind("StepTemplate"):  reflect.TypeFor[v1alpha1.StepTemplate](),
+	schema.GroupVersion(v1alpha1.GroupVersion).WithKind("Configuration"): reflect.TypeFor[v1alpha1.Configuration](),
+	schema.GroupVersion(v1alpha2.GroupVersion).WithKind("Configuration"): reflect.TypeFor[v1alpha2.Configuration](),
+}
+
+// Format returns the content with chainsaw documents (tests, step templates and configurations) in a canonical layout.
+// Keys are sorted following the API fields order, strings are unquoted when possible, flow collections are converted
+// to block collections and indentation is normalized. Comments are preserved and other documents are left unchanged.
+func Format(content []byte) ([]byte, error) {
+	var out bytes.Buffer
+	for i, document := range split(content) {
+		out.WriteString(document.separator)
+		formatted, err := formatDocument(document.body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to format document %d: %w", i+1, err)
+		}
+		out.Write(formatted)
+	}
+	return out.Bytes(), nil
+}
+
+type document struct {
+	// separator is the document separator line preceding the body, empty for the first document
+	separator string
+	body      []byte
+}
+
+// split splits the content into documents, separators are kept so that unchanged documents are written back as is.
+func split(content []byte) []document {
+	var documents []document
+	var current document
+	for _, line := range bytes.SplitAfter(content, []byte("\n")) {
+		trimmed := strings.TrimRight(string(line), "\r\n")
+		if trimmed == "---" || strings.HasPrefix(trimmed, "--- ") || strings.HasPrefix(trimmed, "---\t") {
+			documents = append(documents, current)
+			current = document{separator: string(line)}
+			continue
+		}
+		current.body = append(current.body, line...)
+	}
+	return append(documents, current)
+}
+
+func formatDocument(content []byte) ([]byte, error) {
+	var nodes []*yaml.Node
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	for {
+		var node yaml.Node
+		if err := decoder.Decode(&node); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		nodes = append(nodes, &node)
+	}
+	if len(nodes) != 1 || len(nodes[0].Content) != 1 || nodes[0].Content[0].Kind != yaml.MappingNode {
+		return content, nil
+	}
+	node := nodes[0]
+	root := node.Content[0]
+	apiVersion, kind := mappingValue(root, "apiVersion"), mappingValue(root, "kind")
+	if apiVersion == nil || kind == nil {
+		return content, nil
+	}
+	gv, err := schema.ParseGroupVersion(apiVersion.Value)
+	if err != nil {
+		return content, nil
+	}
+	t, ok := types[gv.WithKind(kind.Value)]
+	if !ok {
+		return content, nil
+	}
+	normalize(node)
+	// reordering keys could move an alias before its anchor
+	if !hasAlias(node) {
+		// the head comment of the first key usually belongs to the document (schema modeline for example)
+		var headComment string
+		if len(root.Content) != 0 {
+			headComment, root.Content[0].HeadComment = root.Content[0].HeadComment, ""
+		}
+		order(root, t, "")
+		if len(root.Content) != 0 {
+			root.Content[0].HeadComment = join(headComment, root.Content[0].HeadComment)
+		}
+	}
+	var out bytes.Buffer
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(node); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	formatted := compactSequences(out.Bytes())
+	// formatting must never change the document data
+	if equal, err := sameData(content, formatted); err != nil {
+		return nil, err
+	} else if !equal {
+		return nil, errors.New("formatting changed the document data")
+	}
+	return formatted, nil
+}
+
+// normalize converts flow collections to block collections and removes unnecessary quotes.
+func normalize(node *yaml.Node) {
+	node.Style &^= yaml.FlowStyle
+	// merge keys are decoded with an explicit tag the encoder would write back
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i < len(node.Content); i += 2 {
+			if key := node.Content[i]; key.Kind == yaml.ScalarNode && key.Value == "<<" && key.Tag == "!!merge" {
+				key.Tag = ""
+			}
+		}
+	}
+	if node.Kind == yaml.ScalarNode && node.ShortTag() == "!!str" && node.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) != 0 && isPlainString(node.Value) {
+		node.Style &^= yaml.DoubleQuotedStyle | yaml.SingleQuotedStyle
+	}
+	for _, child := range node.Content {
+		normalize(child)
+	}
+}
+
+// isPlainString returns true if the value is read back as the same string when written without quotes.
+// Documents are loaded with YAML 1.1 semantics where values like yes or on are booleans.
+func isPlainString(value string) bool {
+	if value == "" || strings.ContainsAny(value, "\n\t") || strings.TrimSpace(value) != value {
+		return false
+	}
+	var out any
+	if err := sigsyaml.Unmarshal([]byte(value), &out); err != nil {
+		return false
+	}
+	return out == value
+}
+
+func hasAlias(node *yaml.Node) bool {
+	if node.Kind == yaml.AliasNode {
+		return true
+	}
+	for _, child := range node.Content {
+		if hasAlias(child) {
+			return true
+		}
+	}
+	return false
+}
+
+func join(comments ...string) string {
+	var out []string
+	for _, comment := range comments {
+		if comment != "" {
+			out = append(out, comment)
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+func sameData(original []byte, formatted []byte) (bool, error) {
+	var before, after any
+	if err := sigsyaml.Unmarshal(original, &before); err != nil {
+		return false, err
+	}
+	if err := sigsyaml.Unmarshal(formatted, &after); err != nil {
+		return false, err
+	}
+	return reflect.DeepEqual(before, after), nil
+}
@@ -0,0 +1,359 @@
+package formatter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+		wantErr bool
+	}{{
+		name: "canonical",
+		content: `apiVersion: chainsaw.kyverno.io/v1alpha1
+kind: Test
+metadata:
+  name: foo
+spec:
+  steps:
+  - try:
+    - apply:
+        file: configmap.yaml
+`,
+		want: `apiVersion: chainsaw.kyverno.io/v1alpha1
+kind: Test
+metadata:
+  name: foo
+spec:
+  steps:
+  - try:
+    - apply:
+        file: configmap.yaml
+`,
+	}, {
+		name: "test",
+		content: `# yaml-language-server: $schema=test.json
+spec:
+    steps:
+        -   try:
+            # apply the config map
+            -   apply: {resource: {data: {key: 'value', enabled: 'yes', count: "1"}, kind: ConfigMap, apiVersion: v1, metadata: {name: "foo"}}}
+            name: "step-1"
+    timeouts: {exec: 10s, apply: 5s}
+kind: Test # the test kind
+metadata: {name: foo}
+apiVersion: "chainsaw.kyverno.io/v1alpha1"
+`,
+		want: `# yaml-language-server: $schema=test.json
+apiVersion: chainsaw.kyverno.io/v1alpha1
+kind: Test # the test kind
+metadata:
+  name: foo
+spec:
+  timeouts:
+    apply: 5s
+    exec: 10s
+  steps:
+  - name: step-1
+    try:
+    # apply the config map
+    - apply:
+        resource:
+          apiVersion: v1
+          kind: ConfigMap
+          metadata:
+            name: foo
+          data:
+            key: value
+            enabled: 'yes'
+            count: "1"
+`,
+	}, {
+		name: "step template",
+		content: `kind: StepTemplate
+apiVersion: chainsaw.kyverno.io/v1alpha1
+metadata:
+  name: foo
+spec:
+  try:
+  - script:
+      content: |
+        echo "foo"
+      env:
+      - value: bar
+        name: FOO
+`,
+		want: `apiVersion: chainsaw.kyverno.io/v1alpha1
+kind: StepTemplate
+metadata:
+  name: foo
+spec:
+  try:
+  - script:
+      env:
+      - name: FOO
+        value: bar
+      content: |
+        echo "foo"
+`,
+	}, {
+		name: "configuration",
+		content: `apiVersion: chainsaw.kyverno.io/v1alpha2
+kind: Configuration
+metadata:
+  name: foo
+spec:
+  execution:
+    parallel: 4
+    failFast: true
+`,
+		want: `apiVersion: chainsaw.kyverno.io/v1alpha2
+kind: Configuration
+metadata:
+  name: foo
+spec:
+  execution:
+    failFast: true
+    parallel: 4
+`,
+	}, {
+		name: "multiple documents",
+		content: `apiVersion: v1
+kind: ConfigMap
+metadata: {name: foo}
+---
+kind: Test
+apiVersion: chainsaw.kyverno.io/v1alpha1
+metadata:
+  name: foo
+--- # another test
+apiVersion: chainsaw.kyverno.io/v1alpha1
+kind: Test
+metadata:
+    name: bar
+`,
+		want: `apiVersion: v1
+kind: ConfigMap
+metadata: {name: foo}
+---
+apiVersion: chainsaw.kyverno.io/v1alpha1
+kind: Test
+metadata:
+  name: foo
+--- # another test
+apiVersion: chainsaw.kyverno.io/v1alpha1
+kind: Test
+metadata:
+  name: bar
+`,
+	}, {
+		name: "aliases",
+		content: `kind: Test
+apiVersion: chainsaw.kyverno.io/v1alpha1
+metadata:
+  name: &name foo
+spec:
+  description: *name
+`,
+		want: `kind: Test
+apiVersion: chainsaw.kyverno.io/v1alpha1
+metadata:
+  name: &name foo
+spec:
+  description: *name
+`,
+	}, {
+		name: "merge keys",
+		content: `apiVersion: chainsaw.kyverno.io/v1alpha1
+kind: Test
+metadata:
+  name: foo
+spec:
+  steps:
+  - try:
+    - apply:
+        resource: &resource
+          apiVersion: v1
+          kind: ConfigMap
+          metadata:
+            name: foo
+    - assert:
+        resource:
+          <<: *resource
+          data:
+            key: value
+`,
+		want: `apiVersion: chainsaw.kyverno.io/v1alpha1
+kind: Test
+metadata:
+  name: foo
+spec:
+  steps:
+  - try:
+    - apply:
+        resource: &resource
+          apiVersion: v1
+          kind: ConfigMap
+          metadata:
+            name: foo
+    - assert:
+        resource:
+          <<: *resource
+          data:
+            key: value
+`,
+	}, {
+		name: "indentation",
+		content: `apiVersion: chainsaw.kyverno.io/v1alpha1
+kind: Test
+metadata:
+  name: foo
+spec:
+  steps:
+      # first step
+      - try:
+            - script:
+                  content: |
+                      - foo
+                      bar:
+                  env:
+                      - name: FOO
+                        value: |-
+                            - bar
+                      - name: BAR
+                        value: "- baz"
+            - assert:
+                  resource:
+                      items: &items
+                          - - a
+                            - b
+                          - |
+                            c
+      # last step
+      - catch: []
+`,
+		want: `apiVersion: chainsaw.kyverno.io/v1alpha1
+kind: Test
+metadata:
+  name: foo
+spec:
+  steps:
+  # first step
+  - try:
+    - script:
+        env:
+        - name: FOO
+          value: |-
+            - bar
+        - name: BAR
+          value: "- baz"
+        content: |
+          - foo
+          bar:
+    - assert:
+        resource:
+          items: &items
+          - - a
+            - b
+          - |
+            c
+  # last step
+  - catch: []
+`,
+	}, {
+		name: "unknown fields",
+		content: `apiVersion: chainsaw.kyverno.io/v1alpha1
+kind: Test
+spec:
+  foo: bar
+  description: foo
+metadata:
+  name: foo
+`,
+		want: `apiVersion: chainsaw.kyverno.io/v1alpha1
+kind: Test
+metadata:
+  name: foo
+spec:
+  description: foo
+  foo: bar
+`,
+	}, {
+		name:    "invalid yaml",
+		content: "apiVersion: chainsaw.kyverno.io/v1alpha1\nkind: Test\nspec: [\n",
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Format([]byte(tt.content))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+			// formatting is idempotent
+			again, err := Format(got)
+			assert.NoError(t, err)
+			assert.Equal(t, string(got), string(again))
+		})
+	}
+}
+
+func Test_isPlainString(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"foo", true},
+		{"($foo)", true},
+		{"", false},
+		{"1", false},
+		{"1.5", false},
+		{"yes", false},
+		{"on", false},
+		{"true", false},
+		{"null", false},
+		{"~", false},
+		{"foo: bar", false},
+		{"# foo", false},
+		{" foo", false},
+		{"foo\nbar", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			assert.Equal(t, tt.want, isPlainString(tt.value))
+		})
+	}
+}
+
+func Test_parseLine(t *testing.T) {
+	tests := []struct {
+		line   string
+		column int
+		value  string
+		isKey  bool
+	}{
+		{"steps:", 0, "", true},
+		{"  - try: # comment", 4, "", true},
+		{"    - - a", 8, "a", false},
+		{"  content: | # comment", 2, "| # comment", true},
+		{`  "foo: bar": baz`, 2, "baz", true},
+		{"  'it''s:': baz", 2, "baz", true},
+		{"  - http://localhost:8080", 4, "http://localhost:8080", false},
+		{"  # comment:", 2, "", false},
+		{"  -", 4, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			column, value, isKey := parseLine(tt.line)
+			assert.Equal(t, tt.column, column)
+			assert.Equal(t, tt.value, value)
+			assert.Equal(t, tt.isKey, isKey)
+		})
+	}
+}
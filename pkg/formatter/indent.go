@@ -0,0 +1,147 @@
+package formatter
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+)
+
+// blockScalarHeader matches the header of a literal or folded block scalar, optionally followed by a comment.
+var blockScalarHeader = regexp.MustCompile(`^[|>][0-9+-]*(\s+#.*)?$`)
+
+// compactSequences removes the extra indentation the encoder adds to block sequences nested in mappings,
+// sequence items are written at the same indentation as the key they belong to:
+//
+//	steps:
+//	- try:
+//	  - apply:
+func compactSequences(content []byte) []byte {
+	lines := strings.SplitAfter(string(content), "\n")
+	var out bytes.Buffer
+	// stack contains the (original) indentation of the sequences being compacted
+	var stack []int
+	// scalar is the column of the node owning the block scalar being written, -1 outside of block scalars
+	scalar := -1
+	for i, line := range lines {
+		trimmed := strings.TrimLeft(line, " ")
+		indent := len(line) - len(trimmed)
+		if strings.TrimSpace(trimmed) == "" {
+			out.WriteString(dedent(line, 2*len(stack)))
+			continue
+		}
+		if scalar >= 0 && indent > scalar {
+			out.WriteString(dedent(line, 2*len(stack)))
+			continue
+		}
+		scalar = -1
+		for len(stack) != 0 && indent < stack[len(stack)-1] {
+			stack = stack[:len(stack)-1]
+		}
+		out.WriteString(dedent(line, 2*len(stack)))
+		column, value, isKey := parseLine(line)
+		if blockScalarHeader.MatchString(value) {
+			// the content of a block scalar is indented relatively to the key or the sequence item owning it
+			scalar = column
+			if !isKey {
+				scalar -= 2
+			}
+		} else if isKey && properties(value) {
+			if next := nextNode(lines[i+1:]); strings.HasPrefix(next, strings.Repeat(" ", column+2)+"-") && isItem(next[column+2:]) {
+				stack = append(stack, column+2)
+			}
+		}
+	}
+	return out.Bytes()
+}
+
+// parseLine returns the column of the last node starting on the line (after sequence indicators),
+// the value following the key (comments excluded) and whether the node is a mapping key.
+func parseLine(line string) (int, string, bool) {
+	line = strings.TrimRight(line, "\r\n")
+	column := len(line) - len(strings.TrimLeft(line, " "))
+	for isItem(line[column:]) {
+		column += 2
+		if column >= len(line) {
+			return column, "", false
+		}
+	}
+	rest := line[column:]
+	if strings.HasPrefix(rest, "#") {
+		return column, "", false
+	}
+	end := keyEnd(rest)
+	if end < 0 {
+		return column, trimComment(rest), false
+	}
+	return column, trimComment(strings.TrimSpace(rest[end+1:])), true
+}
+
+// keyEnd returns the position of the colon ending the key at the beginning of the given string, -1 if there is no key.
+func keyEnd(s string) int {
+	i := 0
+	if s != "" && (s[0] == '"' || s[0] == '\'') {
+		quote := s[0]
+		for i = 1; i < len(s); i++ {
+			if s[i] == '\\' && quote == '"' {
+				i++
+			} else if s[i] == quote {
+				if quote == '\'' && i+1 < len(s) && s[i+1] == '\'' {
+					i++
+					continue
+				}
+				break
+			}
+		}
+		i++
+	}
+	for ; i < len(s); i++ {
+		if s[i] == ':' && (i+1 == len(s) || s[i+1] == ' ') {
+			return i
+		}
+		if s[i] == ' ' && i+1 < len(s) && s[i+1] == '#' {
+			return -1
+		}
+	}
+	return -1
+}
+
+func trimComment(value string) string {
+	if strings.HasPrefix(value, "#") {
+		return ""
+	}
+	return value
+}
+
+// properties returns true if the value is empty or only contains node properties (anchor or tag).
+func properties(value string) bool {
+	value, _, _ = strings.Cut(value, " #")
+	for _, field := range strings.Fields(value) {
+		if !strings.HasPrefix(field, "&") && !strings.HasPrefix(field, "!") {
+			return false
+		}
+	}
+	return true
+}
+
+// isItem returns true if the string starts with a sequence item indicator.
+func isItem(s string) bool {
+	return s == "-" || strings.HasPrefix(s, "- ")
+}
+
+// nextNode returns the next line that is neither empty nor a comment.
+func nextNode(lines []string) string {
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+			return strings.TrimRight(line, "\r\n")
+		}
+	}
+	return ""
+}
+
+func dedent(line string, n int) string {
+	for i := 0; i < n && strings.HasPrefix(line, " "); i++ {
+		line = line[1:]
+	}
+	return line
+}
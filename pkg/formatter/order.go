@@ -0,0 +1,149 @@
+package formatter
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"gopkg.in/yaml.v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var (
+	typeMetaType     = reflect.TypeFor[metav1.TypeMeta]()
+	objectMetaType   = reflect.TypeFor[metav1.ObjectMeta]()
+	unstructuredType = reflect.TypeFor[unstructured.Unstructured]()
+	projectionType   = reflect.TypeFor[v1alpha1.Projection]()
+)
+
+// resourceKeys are the keys placed first in kubernetes resources.
+var resourceKeys = []string{"apiVersion", "kind", "metadata"}
+
+type field struct {
+	name string
+	typ  reflect.Type
+}
+
+// fields returns the json fields of a struct type in declaration order, inline structs are flattened.
+func fields(t reflect.Type) []field {
+	// type meta declares kind before apiVersion, use the conventional order instead
+	if t == typeMetaType {
+		return []field{
+			{name: "apiVersion", typ: reflect.TypeFor[string]()},
+			{name: "kind", typ: reflect.TypeFor[string]()},
+		}
+	}
+	var out []field
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		if name == "" && (f.Anonymous || strings.Contains(options, "inline")) {
+			embedded := f.Type
+			for embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				out = append(out, fields(embedded)...)
+				continue
+			}
+		}
+		if name == "" {
+			name = f.Name
+		}
+		out = append(out, field{name: name, typ: f.Type})
+	}
+	return out
+}
+
+// order sorts the keys of mapping nodes following the declaration order of the fields of the given type.
+// Unknown keys are placed after known ones and keep their relative order.
+func order(node *yaml.Node, t reflect.Type, key string) {
+	if node == nil || t == nil {
+		return
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch node.Kind {
+	case yaml.MappingNode:
+		switch {
+		case t == unstructuredType, t == projectionType && key == "resource":
+			sortKeys(node, resourceKeys)
+			order(mappingValue(node, "metadata"), objectMetaType, "metadata")
+		case t.Kind() == reflect.Struct:
+			structFields := fields(t)
+			if len(structFields) == 0 {
+				return
+			}
+			names := make([]string, 0, len(structFields))
+			for _, f := range structFields {
+				names = append(names, f.name)
+			}
+			sortKeys(node, names)
+			for _, f := range structFields {
+				order(mappingValue(node, f.name), f.typ, f.name)
+			}
+		case t.Kind() == reflect.Map:
+			for i := 1; i < len(node.Content); i += 2 {
+				order(node.Content[i], t.Elem(), node.Content[i-1].Value)
+			}
+		}
+	case yaml.SequenceNode:
+		if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+			for _, item := range node.Content {
+				order(item, t.Elem(), key)
+			}
+		}
+	}
+}
+
+// sortKeys moves the given keys first in the mapping node, in the given order.
+func sortKeys(node *yaml.Node, keys []string) {
+	rank := make(map[string]int, len(keys))
+	for i, key := range keys {
+		rank[key] = i
+	}
+	type pair struct {
+		key, value *yaml.Node
+	}
+	var known, unknown []pair
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		p := pair{node.Content[i], node.Content[i+1]}
+		if _, ok := rank[p.key.Value]; ok && p.key.Kind == yaml.ScalarNode {
+			known = append(known, p)
+		} else {
+			unknown = append(unknown, p)
+		}
+	}
+	// stable insertion sort, mappings are small
+	for i := 1; i < len(known); i++ {
+		for j := i; j > 0 && rank[known[j].key.Value] < rank[known[j-1].key.Value]; j-- {
+			known[j], known[j-1] = known[j-1], known[j]
+		}
+	}
+	content := make([]*yaml.Node, 0, len(node.Content))
+	for _, p := range append(known, unknown...) {
+		content = append(content, p.key, p.value)
+	}
+	node.Content = content
+}
+
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
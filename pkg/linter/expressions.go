@@ -0,0 +1,116 @@
+package linter
+
+import (
+	"context"
+	"maps"
+	"regexp"
+	"strings"
+
+	"github.com/jmespath-community/go-jmespath/pkg/parsing"
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/expressions"
+	"github.com/kyverno/kyverno-json/pkg/core/compilers"
+	"github.com/kyverno/kyverno-json/pkg/core/expression"
+)
+
+var celReferenceRegex = regexp.MustCompile(`bindings\s*\.\s*resolve\(\s*['"](\w+)['"]\s*\)`)
+
+// valueExpression returns the statement and compiler of an expression in a string value.
+// It returns false when the value is not evaluated as an expression.
+func valueExpression(value string, defaultCompiler string) (string, string, bool) {
+	parsed := expressions.Parse(context.TODO(), value)
+	if parsed == nil {
+		return "", "", false
+	}
+	compiler, ok := resolveCompiler(parsed.Engine, defaultCompiler)
+	if !ok {
+		return "", "", false
+	}
+	return parsed.Statement, compiler, true
+}
+
+// keyExpression returns the statement and compiler of an expression in an assertion tree key, along with the bindings it defines.
+// It returns false when the key is not evaluated as an expression.
+func keyExpression(key string, defaultCompiler string) (string, string, []string, bool) {
+	parsed := expression.Parse(key)
+	var defined []string
+	if parsed.ForeachName != "" {
+		defined = append(defined, parsed.ForeachName)
+	}
+	if parsed.Binding != "" {
+		defined = append(defined, parsed.Binding)
+	}
+	compiler, ok := resolveCompiler(parsed.Compiler, defaultCompiler)
+	if !ok {
+		return "", "", defined, false
+	}
+	return parsed.Statement, compiler, defined, true
+}
+
+func resolveCompiler(engine string, defaultCompiler string) (string, bool) {
+	switch engine {
+	case compilers.CompilerJP, compilers.CompilerCEL:
+		return engine, true
+	case expression.CompilerDefault:
+		if defaultCompiler == "" {
+			return compilers.CompilerJP, true
+		}
+		return defaultCompiler, true
+	}
+	return "", false
+}
+
+// references compiles the statement and returns the names of the bindings it references (without the $ prefix).
+func references(compiler string, statement string) ([]string, error) {
+	if compiler == compilers.CompilerCEL {
+		if _, err := apis.DefaultCompilers.Cel.Compile(statement); err != nil {
+			return nil, err
+		}
+		var names []string
+		for _, match := range celReferenceRegex.FindAllStringSubmatch(statement, -1) {
+			names = append(names, match[1])
+		}
+		return names, nil
+	}
+	ast, err := parsing.NewParser().Parse(statement)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	variables(ast, nil, &names)
+	return names, nil
+}
+
+// variables collects the variables referenced in a JMESPath AST, variables bound by let expressions are ignored.
+func variables(node parsing.ASTNode, locals map[string]struct{}, names *[]string) {
+	switch node.NodeType {
+	case parsing.ASTVariable:
+		if name, ok := node.Value.(string); ok {
+			if _, local := locals[name]; !local {
+				*names = append(*names, strings.TrimPrefix(name, "$"))
+			}
+		}
+		return
+	case parsing.ASTLetExpression:
+		if len(node.Children) == 2 {
+			scope := maps.Clone(locals)
+			if scope == nil {
+				scope = map[string]struct{}{}
+			}
+			for _, binding := range node.Children[0].Children {
+				if len(binding.Children) != 2 {
+					continue
+				}
+				variables(binding.Children[1], scope, names)
+				if name, ok := binding.Children[0].Value.(string); ok {
+					scope[name] = struct{}{}
+				}
+			}
+			variables(node.Children[1], scope, names)
+			return
+		}
+	}
+	for _, child := range node.Children {
+		variables(child, locals, names)
+	}
+}
@@ -0,0 +1,106 @@
+package linter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_references(t *testing.T) {
+	tests := []struct {
+		name      string
+		compiler  string
+		statement string
+		want      []string
+		wantErr   bool
+	}{{
+		name:      "jp",
+		compiler:  "jp",
+		statement: "[$foo, $bar.baz, $]",
+		want:      []string{"foo", "bar"},
+	}, {
+		name:      "jp let",
+		compiler:  "jp",
+		statement: "let $foo = $bar in [$foo, $baz]",
+		want:      []string{"bar", "baz"},
+	}, {
+		name:      "jp invalid",
+		compiler:  "jp",
+		statement: "$foo[",
+		wantErr:   true,
+	}, {
+		name:      "cel",
+		compiler:  "cel",
+		statement: "bindings.resolve('foo') + bindings.resolve(\"bar\")",
+		want:      []string{"foo", "bar"},
+	}, {
+		name:      "cel invalid",
+		compiler:  "cel",
+		statement: "object.foo ==",
+		wantErr:   true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := references(tt.compiler, tt.statement)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_valueExpression(t *testing.T) {
+	tests := []struct {
+		name         string
+		value        string
+		compiler     string
+		wantOk       bool
+		wantCompiler string
+	}{{
+		name:  "plain",
+		value: "foo",
+	}, {
+		name:         "default",
+		value:        "($foo)",
+		wantOk:       true,
+		wantCompiler: "jp",
+	}, {
+		name:         "default cel",
+		value:        "(object.foo)",
+		compiler:     "cel",
+		wantOk:       true,
+		wantCompiler: "cel",
+	}, {
+		name:         "explicit",
+		value:        "(cel; object.foo)",
+		wantOk:       true,
+		wantCompiler: "cel",
+	}, {
+		name:  "escaped",
+		value: "\\($foo)\\",
+	}, {
+		name:  "unknown engine",
+		value: "(foo; bar)",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, compiler, ok := valueExpression(tt.value, tt.compiler)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.wantCompiler, compiler)
+		})
+	}
+}
+
+func Test_keyExpression(t *testing.T) {
+	statement, compiler, defined, ok := keyExpression("~index.(spec.containers)->container", "")
+	assert.True(t, ok)
+	assert.Equal(t, "spec.containers", statement)
+	assert.Equal(t, "jp", compiler)
+	assert.Equal(t, []string{"index", "container"}, defined)
+	_, _, defined, ok = keyExpression("metadata", "")
+	assert.False(t, ok)
+	assert.Nil(t, defined)
+}
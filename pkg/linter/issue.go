@@ -0,0 +1,56 @@
+package linter
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+type Rule string
+
+const (
+	RuleSchema              Rule = "schema"
+	RuleUndefinedBinding    Rule = "undefined-binding"
+	RuleUnusedOutput        Rule = "unused-output"
+	RuleDuplicateStepName   Rule = "duplicate-step-name"
+	RuleMissingStepTemplate Rule = "missing-step-template"
+	RuleInvalidExpression   Rule = "invalid-expression"
+	RuleMissingFile         Rule = "missing-file"
+	RuleResourceSchema      Rule = "resource-schema"
+)
+
+// Rules lists the rules with their description.
+var Rules = []struct {
+	Rule        Rule
+	Description string
+}{
+	{RuleSchema, "The document must be valid against the JSON schema."},
+	{RuleUndefinedBinding, "Expressions must reference bindings defined in scope."},
+	{RuleUnusedOutput, "Outputs should be referenced by a subsequent operation."},
+	{RuleDuplicateStepName, "Step names should be unique in a test."},
+	{RuleMissingStepTemplate, "Step templates referenced by steps must exist."},
+	{RuleInvalidExpression, "JMESPath and CEL expressions must compile."},
+	{RuleMissingFile, "Files referenced by operations must exist."},
+	{RuleResourceSchema, "Resources referenced by operations must be valid against their OpenAPI schema."},
+}
+
+// Issue is a problem reported by the linter.
+type Issue struct {
+	Rule     Rule     `json:"rule"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+	File     string   `json:"file,omitempty"`
+	Line     int      `json:"line,omitempty"`
+	Column   int      `json:"column,omitempty"`
+}
+
+// HasErrors returns true if at least one issue has the error severity.
+func HasErrors(issues []Issue) bool {
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,556 @@
+package linter
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/expressions"
+	"github.com/kyverno/chainsaw/pkg/loaders/steptemplate"
+	"github.com/kyverno/chainsaw/pkg/loaders/test"
+	"github.com/kyverno/chainsaw/pkg/validation"
+	"github.com/kyverno/kyverno-json/pkg/core/compilers"
+	"gopkg.in/yaml.v3"
+	sigsyaml "sigs.k8s.io/yaml"
+)
+
+// Builtins are the bindings registered by chainsaw when running tests.
+var Builtins = []string{
+	"address",
+	"body",
+	"client",
+	"config",
+	"error",
+	"handle",
+	"headers",
+	"localPort",
+	"namespace",
+	"operation",
+	"outputs",
+	"status",
+	"stderr",
+	"step",
+	"stdout",
+	"test",
+	"timeouts",
+	"values",
+}
+
+// resourceKeys are the operations referencing resources validated against OpenAPI schemas.
+var resourceKeys = map[string]struct{}{
+	"apply":  {},
+	"assert": {},
+	"create": {},
+	"error":  {},
+	"patch":  {},
+	"update": {},
+}
+
+// plainKeys are fields holding plain strings, they are never evaluated as expressions.
+var plainKeys = map[string]struct{}{
+	"args":         {},
+	"cluster":      {},
+	"clusters":     {},
+	"content":      {},
+	"description":  {},
+	"entrypoint":   {},
+	"fieldManager": {},
+	"shell":        {},
+	"shellArgs":    {},
+	"workDir":      {},
+}
+
+type refs map[string]struct{}
+
+type output struct {
+	name string
+	node *yaml.Node
+}
+
+// stepResult holds the outcome of a step analysis.
+type stepResult struct {
+	// scope contains the bindings available at the end of the step
+	scope scope
+	// outputs contains the outputs declared in the step
+	outputs []output
+	// refs contains the bindings referenced in the step
+	refs refs
+	// unknownRefs is true when the references made by the step are not known (the step template couldn't be loaded)
+	unknownRefs bool
+}
+
+type analyzer struct {
+	file      string
+	basePath  string
+	validator *validation.Validator
+	issues    []Issue
+	err       error
+}
+
+// Lint runs the semantic analysis of the tests contained in content.
+// The file is used when reporting issues and the base path is used to resolve relative paths,
+// referenced files and step templates are not checked when the base path is empty.
+// Resources referenced by operations are validated against their OpenAPI schema when a validator is provided.
+func Lint(file string, basePath string, content []byte, validator *validation.Validator) ([]Issue, error) {
+	a := &analyzer{
+		file:      file,
+		basePath:  basePath,
+		validator: validator,
+	}
+	var roots []*yaml.Node
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	for {
+		var document yaml.Node
+		if err := decoder.Decode(&document); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		if len(document.Content) != 0 && document.Content[0].Kind == yaml.MappingNode {
+			roots = append(roots, document.Content[0])
+		}
+	}
+	tests, err := test.Parse(content, true)
+	if err != nil {
+		// loading fails on invalid expressions, report them with their position when possible
+		for _, root := range roots {
+			a.expressions(root, newScope().withDynamic(), "", refs{})
+		}
+		if len(a.issues) != 0 {
+			return a.issues, nil
+		}
+		return nil, err
+	}
+	if len(roots) != len(tests) {
+		return nil, fmt.Errorf("found %d documents but %d tests", len(roots), len(tests))
+	}
+	for i := range tests {
+		a.test(tests[i], roots[i])
+	}
+	if a.err != nil {
+		return nil, a.err
+	}
+	// issues of the linted file come first, followed by issues found in referenced files
+	slices.SortStableFunc(a.issues, func(x, y Issue) int {
+		if x.File != y.File {
+			if x.File == a.file || y.File == a.file {
+				return cmp.Compare(boolToInt(x.File != a.file), boolToInt(y.File != a.file))
+			}
+			return cmp.Compare(x.File, y.File)
+		}
+		if x.Line != y.Line {
+			return cmp.Compare(x.Line, y.Line)
+		}
+		return cmp.Compare(x.Column, y.Column)
+	})
+	return a.issues, nil
+}
+
+func (a *analyzer) report(node *yaml.Node, rule Rule, severity Severity, format string, args ...any) {
+	a.reportIn(a.file, node, rule, severity, format, args...)
+}
+
+func (a *analyzer) reportIn(file string, node *yaml.Node, rule Rule, severity Severity, format string, args ...any) {
+	issue := Issue{
+		Rule:     rule,
+		Severity: severity,
+		Message:  fmt.Sprintf(format, args...),
+		File:     file,
+	}
+	if node != nil {
+		issue.Line = node.Line
+		issue.Column = node.Column
+	}
+	a.issues = append(a.issues, issue)
+}
+
+func (a *analyzer) test(test *v1alpha1.Test, node *yaml.Node) {
+	spec := child(node, "spec")
+	compiler := compilerOf(test.Spec.Compiler, "")
+	testRefs := refs{}
+	scope := newScope(Builtins...)
+	// scenario bindings are registered before the test bindings
+	scenarios := scope
+	for i, scenario := range test.Spec.Scenarios {
+		scenarios = scenarios.merge(a.bindings(scenario.Bindings, lookup(spec, "scenarios", i, "bindings"), scope, compiler, testRefs))
+	}
+	scope = a.bindings(test.Spec.Bindings, child(spec, "bindings"), scenarios, compiler, testRefs)
+	a.expressions(child(spec, "namespaceTemplate"), scope, compiler, testRefs)
+	// the test catch block runs in the context of the failing step
+	catchScope := scope
+	names := map[string]struct{}{}
+	var steps []stepResult
+	for i, step := range test.Spec.Steps {
+		stepNode := lookup(spec, "steps", i)
+		if step.Name != "" {
+			if _, exists := names[step.Name]; exists {
+				a.report(child(stepNode, "name"), RuleDuplicateStepName, SeverityWarning, "step name %s is used by more than one step", step.Name)
+			}
+			names[step.Name] = struct{}{}
+		}
+		result := a.step(step, stepNode, scope, compiler)
+		catchScope = catchScope.merge(result.scope)
+		steps = append(steps, result)
+	}
+	catchRefs := refs{}
+	a.catchFinally(test.Spec.Catch, child(spec, "catch"), catchScope, compiler, catchRefs)
+	for _, step := range steps {
+		if step.unknownRefs {
+			continue
+		}
+		for _, output := range step.outputs {
+			_, used := step.refs[output.name]
+			_, usedByCatch := catchRefs[output.name]
+			if !used && !usedByCatch {
+				a.report(output.node, RuleUnusedOutput, SeverityWarning, "output %s is never used", output.name)
+			}
+		}
+	}
+}
+
+func (a *analyzer) step(step v1alpha1.TestStep, node *yaml.Node, scope scope, compiler string) stepResult {
+	compiler = compilerOf(step.Compiler, compiler)
+	result := stepResult{refs: refs{}}
+	if step.ForEach != nil {
+		a.expressions(child(node, "forEach"), scope, compiler, result.refs)
+		scope = scope.with("item", "index")
+	}
+	a.expressions(child(node, "if"), scope, compiler, result.refs)
+	scope = a.bindings(step.Bindings, child(node, "bindings"), scope, compiler, result.refs)
+	var template *v1alpha1.StepTemplate
+	if step.Use != nil {
+		useNode := child(node, "use")
+		var templateNode *yaml.Node
+		if a.basePath != "" {
+			template, templateNode = a.template(step.Use.Template, child(useNode, "template"))
+		}
+		if template != nil {
+			for _, binding := range template.Spec.Bindings {
+				scope = scope.withBinding(binding.Name)
+			}
+			// references made by the template operations count as usages, issues are reported when linting the template
+			discard := &analyzer{}
+			discard.expressions(child(templateNode, "spec"), newScope().withDynamic(), compiler, result.refs)
+		} else {
+			// bindings, outputs and references of the step template are unknown
+			scope = scope.withDynamic()
+			result.unknownRefs = true
+		}
+		scope = a.bindings(step.Use.With.Bindings, lookup(useNode, "with", "bindings"), scope, compiler, result.refs)
+	}
+	declare := func(node *yaml.Node, declared []v1alpha1.Output) {
+		nodes := outputNodes(node)
+		for _, declared := range declared {
+			if _, _, dynamic := valueExpression(string(declared.Name), compiler); !dynamic {
+				result.outputs = append(result.outputs, output{name: string(declared.Name), node: nodes[string(declared.Name)]})
+			}
+		}
+	}
+	for i, operation := range step.Try {
+		operationNode := lookup(node, "try", i)
+		a.operation(operation, operationNode, scope, compiler, result.refs)
+		declare(operationNode, operation.Outputs())
+		scope = scope.withOutputs(operation.Outputs())
+	}
+	if template != nil {
+		for _, operations := range [][]v1alpha1.CatchFinally{template.Spec.Catch, template.Spec.Finally, template.Spec.Cleanup} {
+			for _, operation := range operations {
+				scope = scope.withOutputs(operation.Outputs())
+			}
+		}
+		for _, operation := range template.Spec.Try {
+			scope = scope.withOutputs(operation.Outputs())
+		}
+	}
+	for _, block := range []struct {
+		key        string
+		operations []v1alpha1.CatchFinally
+	}{{"catch", step.Catch}, {"finally", step.Finally}, {"cleanup", step.Cleanup}} {
+		blockScope := scope
+		for i, operation := range block.operations {
+			operationNode := lookup(node, block.key, i)
+			blockScope = a.catch(operation, operationNode, blockScope, compiler, result.refs)
+			declare(operationNode, operation.Outputs())
+		}
+	}
+	result.scope = scope
+	return result
+}
+
+func (a *analyzer) catchFinally(operations []v1alpha1.CatchFinally, node *yaml.Node, scope scope, compiler string, refs refs) {
+	for i, operation := range operations {
+		scope = a.catch(operation, index(node, i), scope, compiler, refs)
+	}
+}
+
+// operation analyses an operation, the caller is responsible for registering the operation outputs.
+func (a *analyzer) operation(operation v1alpha1.Operation, node *yaml.Node, scope scope, compiler string, refs refs) {
+	compiler = compilerOf(operation.Compiler, compiler)
+	if operation.ForEach != nil {
+		a.expressions(child(node, "forEach"), scope, compiler, refs)
+		scope = scope.with("item", "index")
+	}
+	for i := range operation.Parallel {
+		a.operation(operation.Parallel[i], lookup(node, "parallel", i), scope, compiler, refs)
+	}
+	for _, binding := range operation.Bindings() {
+		scope = scope.withBinding(binding.Name)
+	}
+	a.action(node, scope, compiler, refs)
+}
+
+// catch analyses a catch, finally or cleanup operation and returns the scope including the operation outputs.
+func (a *analyzer) catch(operation v1alpha1.CatchFinally, node *yaml.Node, scope scope, compiler string, refs refs) scope {
+	compiler = compilerOf(operation.Compiler, compiler)
+	for i := range operation.Parallel {
+		a.catch(operation.Parallel[i], lookup(node, "parallel", i), scope, compiler, refs)
+	}
+	operationScope := scope
+	for _, binding := range operation.Bindings() {
+		operationScope = operationScope.withBinding(binding.Name)
+	}
+	a.action(node, operationScope, compiler, refs)
+	return scope.withOutputs(operation.Outputs())
+}
+
+// action analyses the fields of an operation node, loops and parallel groups are expected to be analysed by the caller.
+func (a *analyzer) action(node *yaml.Node, scope scope, compiler string, refs refs) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		switch key.Value {
+		case "forEach", "parallel":
+			continue
+		}
+		files := a.checkFile(child(value, "file"))
+		if _, ok := resourceKeys[key.Value]; ok {
+			a.resources(value, files)
+		}
+		a.expressions(value, scope, compiler, refs)
+	}
+}
+
+func (a *analyzer) bindings(bindings []v1alpha1.Binding, node *yaml.Node, scope scope, compiler string, refs refs) scope {
+	for i, binding := range bindings {
+		a.expressions(index(node, i), scope, compiler, refs)
+		scope = scope.withBinding(binding.Name)
+	}
+	return scope
+}
+
+// checkFile reports files that don't exist and returns the matching files, paths containing expressions and URLs are not checked.
+func (a *analyzer) checkFile(node *yaml.Node) []string {
+	if a.basePath == "" || node == nil || node.Kind != yaml.ScalarNode || node.Value == "" {
+		return nil
+	}
+	parsed := expressions.Parse(context.TODO(), node.Value)
+	if parsed == nil || parsed.Engine != "" {
+		return nil
+	}
+	if _, err := url.ParseRequestURI(parsed.Statement); err == nil {
+		return nil
+	}
+	pattern := filepath.Join(a.basePath, parsed.Statement)
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		a.report(node, RuleMissingFile, SeverityError, "invalid file pattern %s: %s", parsed.Statement, err)
+	} else if len(matches) == 0 {
+		a.report(node, RuleMissingFile, SeverityError, "no file found matching %s", parsed.Statement)
+	}
+	return matches
+}
+
+// resources validates the inline resource or the resources loaded from files of an operation against their OpenAPI schema.
+func (a *analyzer) resources(node *yaml.Node, files []string) {
+	if a.validator == nil {
+		return
+	}
+	if resource := child(node, "resource"); resource != nil {
+		a.resource(a.file, resource)
+		return
+	}
+	for _, file := range files {
+		content, err := os.ReadFile(filepath.Clean(file))
+		if err != nil {
+			continue
+		}
+		decoder := yaml.NewDecoder(bytes.NewReader(content))
+		for {
+			var document yaml.Node
+			if err := decoder.Decode(&document); err != nil {
+				// invalid files are reported when the test runs
+				break
+			}
+			if len(document.Content) != 0 {
+				a.resource(file, document.Content[0])
+			}
+		}
+	}
+}
+
+func (a *analyzer) resource(file string, node *yaml.Node) {
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+	content, err := yaml.Marshal(node)
+	if err != nil {
+		return
+	}
+	content, err = sigsyaml.YAMLToJSON(content)
+	if err != nil {
+		return
+	}
+	var resource map[string]any
+	if err := json.Unmarshal(content, &resource); err != nil {
+		return
+	}
+	violations, err := a.validator.Validate(resource)
+	if err != nil {
+		if a.err == nil {
+			a.err = err
+		}
+		return
+	}
+	for _, violation := range violations {
+		a.reportIn(file, locateField(node, violation.Path), RuleResourceSchema, SeverityError, "%s", violation)
+	}
+}
+
+func (a *analyzer) template(path string, node *yaml.Node) (*v1alpha1.StepTemplate, *yaml.Node) {
+	path = filepath.Join(a.basePath, path)
+	templates, err := steptemplate.Load(path, true)
+	if err != nil {
+		a.report(node, RuleMissingStepTemplate, SeverityError, "failed to load step template: %s", err)
+		return nil, nil
+	}
+	if len(templates) != 1 {
+		a.report(node, RuleMissingStepTemplate, SeverityError, "step template not found or multiple templates exist in %s", path)
+		return nil, nil
+	}
+	content, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return templates[0], nil
+	}
+	var document yaml.Node
+	if err := yaml.Unmarshal(content, &document); err != nil || len(document.Content) == 0 {
+		return templates[0], nil
+	}
+	return templates[0], document.Content[0]
+}
+
+// expressions walks the node and analyses the expressions it contains, referenced bindings are added to refs.
+func (a *analyzer) expressions(node *yaml.Node, scope scope, compiler string, refs refs) {
+	if node == nil {
+		return
+	}
+	switch node.Kind {
+	case yaml.ScalarNode:
+		if node.Tag == "!!str" {
+			if statement, compiler, ok := valueExpression(node.Value, compiler); ok {
+				a.expression(node, statement, compiler, scope, refs)
+			}
+		}
+	case yaml.AliasNode:
+		a.expressions(node.Alias, scope, compiler, refs)
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			a.expressions(item, scope, compiler, refs)
+		}
+	case yaml.MappingNode:
+		if value := child(node, "compiler"); value != nil && value.Kind == yaml.ScalarNode {
+			compiler = compilerOf((*v1alpha1.Compiler)(&value.Value), compiler)
+		}
+		// bindings created by assertion tree keys are available in the whole tree
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if _, _, defined, _ := keyExpression(node.Content[i].Value, compiler); len(defined) != 0 {
+				scope = scope.with(defined...)
+			}
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if _, plain := plainKeys[key.Value]; plain {
+				continue
+			}
+			// the command of an exec operation is a list of plain strings
+			if key.Value == "command" && value.Kind == yaml.SequenceNode {
+				continue
+			}
+			if statement, compiler, _, ok := keyExpression(key.Value, compiler); ok {
+				a.expression(key, statement, compiler, scope, refs)
+			}
+			a.expressions(value, scope, compiler, refs)
+		}
+	}
+}
+
+func (a *analyzer) expression(node *yaml.Node, statement string, compiler string, scope scope, refs refs) {
+	names, err := references(compiler, statement)
+	if err != nil {
+		a.report(node, RuleInvalidExpression, SeverityError, "invalid %s expression %s: %s", compiler, statement, err)
+		return
+	}
+	reported := map[string]struct{}{}
+	for _, name := range names {
+		refs[name] = struct{}{}
+		if _, done := reported[name]; done || scope.has(name) {
+			continue
+		}
+		reported[name] = struct{}{}
+		a.report(node, RuleUndefinedBinding, SeverityWarning, "binding $%s is not defined", name)
+	}
+}
+
+// outputNodes returns the nodes of the outputs declared in an operation node, indexed by name.
+func outputNodes(node *yaml.Node) map[string]*yaml.Node {
+	nodes := map[string]*yaml.Node{}
+	var walk func(*yaml.Node)
+	walk = func(node *yaml.Node) {
+		if node == nil {
+			return
+		}
+		if node.Kind == yaml.MappingNode {
+			if outputs := child(node, "outputs"); outputs != nil && outputs.Kind == yaml.SequenceNode {
+				for _, output := range outputs.Content {
+					if name := child(output, "name"); name != nil {
+						nodes[name.Value] = name
+					}
+				}
+			}
+		}
+		for _, content := range node.Content {
+			walk(content)
+		}
+	}
+	walk(node)
+	return nodes
+}
+
+func compilerOf(compiler *v1alpha1.Compiler, defaultCompiler string) string {
+	if compiler == nil {
+		return defaultCompiler
+	}
+	switch *compiler {
+	case compilers.CompilerJP, compilers.CompilerCEL:
+		return string(*compiler)
+	}
+	return defaultCompiler
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,426 @@
+package linter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kyverno/chainsaw/pkg/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLint(t *testing.T) {
+	basePath := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(basePath, "configmap.yaml"), []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: foo
+`), 0o600))
+	assert.NoError(t, os.WriteFile(filepath.Join(basePath, "template.yaml"), []byte(`apiVersion: chainsaw.kyverno.io/v1alpha1
+kind: StepTemplate
+metadata:
+  name: template
+spec:
+  bindings:
+  - name: fromTemplate
+    value: foo
+  try:
+  - script:
+      content: echo $INPUT
+      env:
+      - name: INPUT
+        value: ($input)
+      outputs:
+      - name: templateOutput
+        value: ($stdout)
+`), 0o600))
+	tests := []struct {
+		name     string
+		content  string
+		basePath string
+		want     []Issue
+		wantErr  bool
+	}{{
+		name:     "valid",
+		basePath: basePath,
+		content: `apiVersion: chainsaw.kyverno.io/v1alpha1
+kind: Test
+metadata:
+  name: valid
+spec:
+  bindings:
+  - name: foo
+    value: bar
+  - name: baz
+    value: (concat($foo, $namespace))
+  steps:
+  - name: first
+    try:
+    - apply:
+        file: configmap.yaml
+    - script:
+        content: echo $FOO
+        env:
+        - name: FOO
+          value: ($foo)
+        outputs:
+        - name: greeting
+          value: ($stdout)
+    - assert:
+        resource:
+          apiVersion: v1
+          kind: ConfigMap
+          metadata:
+            name: ($greeting)
+          ~.(data)->entry:
+            (let $length = length($entry) in $length > ` + "`0`" + `): true
+  - name: second
+    forEach:
+      items: [1, 2]
+    use:
+      template: template.yaml
+      with:
+        bindings:
+        - name: input
+          value: ($item)
+  - name: third
+    try:
+    - compiler: cel
+      assert:
+        resource:
+          data:
+            key: (bindings.resolve('baz') != '')
+`,
+	}, {
+		name:     "undefined binding",
+		basePath: basePath,
+		content: `apiVersion: chainsaw.kyverno.io/v1alpha1
+kind: Test
+metadata:
+  name: test
+spec:
+  steps:
+  - try:
+    - assert:
+        resource:
+          apiVersion: v1
+          kind: ConfigMap
+          metadata:
+            name: ($missing)
+    - sleep:
+        duration: 1s
+      if: ($item == ` + "`1`" + `)
+`,
+		want: []Issue{{
+			Rule:     RuleUndefinedBinding,
+			Severity: SeverityWarning,
+			Message:  "binding $missing is not defined",
+			File:     "test.yaml",
+			Line:     13,
+			Column:   19,
+		}, {
+			Rule:     RuleUndefinedBinding,
+			Severity: SeverityWarning,
+			Message:  "binding $item is not defined",
+			File:     "test.yaml",
+			Line:     16,
+			Column:   11,
+		}},
+	}, {
+		name:     "unused output",
+		basePath: basePath,
+		content: `apiVersion: chainsaw.kyverno.io/v1alpha1
+kind: Test
+metadata:
+  name: test
+spec:
+  steps:
+  - try:
+    - script:
+        content: echo hello
+        outputs:
+        - name: greeting
+          value: ($stdout)
+  - try:
+    - assert:
+        resource:
+          apiVersion: v1
+          kind: ConfigMap
+          metadata:
+            name: ($greeting)
+`,
+		want: []Issue{{
+			Rule:     RuleUnusedOutput,
+			Severity: SeverityWarning,
+			Message:  "output greeting is never used",
+			File:     "test.yaml",
+			Line:     11,
+			Column:   17,
+		}, {
+			Rule:     RuleUndefinedBinding,
+			Severity: SeverityWarning,
+			Message:  "binding $greeting is not defined",
+			File:     "test.yaml",
+			Line:     19,
+			Column:   19,
+		}},
+	}, {
+		name:     "output used by catch",
+		basePath: basePath,
+		content: `apiVersion: chainsaw.kyverno.io/v1alpha1
+kind: Test
+metadata:
+  name: test
+spec:
+  steps:
+  - try:
+    - script:
+        content: echo hello
+        outputs:
+        - name: greeting
+          value: ($stdout)
+  catch:
+  - podLogs:
+      name: ($greeting)
+`,
+	}, {
+		name:     "duplicate step name",
+		basePath: basePath,
+		content: `apiVersion: chainsaw.kyverno.io/v1alpha1
+kind: Test
+metadata:
+  name: test
+spec:
+  steps:
+  - name: foo
+    try:
+    - sleep:
+        duration: 1s
+  - name: foo
+    try:
+    - sleep:
+        duration: 1s
+`,
+		want: []Issue{{
+			Rule:     RuleDuplicateStepName,
+			Severity: SeverityWarning,
+			Message:  "step name foo is used by more than one step",
+			File:     "test.yaml",
+			Line:     11,
+			Column:   11,
+		}},
+	}, {
+		name:     "missing step template",
+		basePath: basePath,
+		content: `apiVersion: chainsaw.kyverno.io/v1alpha1
+kind: Test
+metadata:
+  name: test
+spec:
+  steps:
+  - use:
+      template: missing.yaml
+      with:
+        bindings:
+        - name: input
+          value: ($unknown)
+`,
+		want: []Issue{{
+			Rule:     RuleMissingStepTemplate,
+			Severity: SeverityError,
+			Message:  "failed to load step template: open " + filepath.Join(basePath, "missing.yaml") + ": no such file or directory",
+			File:     "test.yaml",
+			Line:     8,
+			Column:   17,
+		}},
+	}, {
+		name:     "invalid expression",
+		basePath: basePath,
+		content: `apiVersion: chainsaw.kyverno.io/v1alpha1
+kind: Test
+metadata:
+  name: test
+spec:
+  steps:
+  - try:
+    - assert:
+        resource:
+          apiVersion: v1
+          kind: ConfigMap
+          (data.foo ==): bar
+    - compiler: cel
+      assert:
+        resource:
+          data:
+            key: (object.foo ==)
+`,
+		want: []Issue{{
+			Rule:     RuleInvalidExpression,
+			Severity: SeverityError,
+			Message:  "invalid jp expression data.foo ==: SyntaxError: Incomplete expression",
+			File:     "test.yaml",
+			Line:     12,
+			Column:   11,
+		}, {
+			Rule:     RuleInvalidExpression,
+			Severity: SeverityError,
+			File:     "test.yaml",
+			Line:     17,
+			Column:   18,
+		}},
+	}, {
+		name:     "invalid expression in expression field",
+		basePath: basePath,
+		content: `apiVersion: chainsaw.kyverno.io/v1alpha1
+kind: Test
+metadata:
+  name: test
+spec:
+  steps:
+  - try:
+    - if: ($foo[)
+      sleep:
+        duration: 1s
+`,
+		want: []Issue{{
+			Rule:     RuleInvalidExpression,
+			Severity: SeverityError,
+			Message:  "invalid jp expression $foo[: SyntaxError: Expected TOKStar, received: TOKEOF",
+			File:     "test.yaml",
+			Line:     8,
+			Column:   11,
+		}},
+	}, {
+		name:     "missing file",
+		basePath: basePath,
+		content: `apiVersion: chainsaw.kyverno.io/v1alpha1
+kind: Test
+metadata:
+  name: test
+spec:
+  steps:
+  - try:
+    - apply:
+        file: missing/*.yaml
+    - apply:
+        file: ($file)
+    - apply:
+        file: https://raw.githubusercontent.com/kyverno/chainsaw/main/testdata/resource/valid.yaml
+`,
+		want: []Issue{{
+			Rule:     RuleMissingFile,
+			Severity: SeverityError,
+			Message:  "no file found matching missing/*.yaml",
+			File:     "test.yaml",
+			Line:     9,
+			Column:   15,
+		}, {
+			Rule:     RuleUndefinedBinding,
+			Severity: SeverityWarning,
+			Message:  "binding $file is not defined",
+			File:     "test.yaml",
+			Line:     11,
+			Column:   15,
+		}},
+	}, {
+		name: "without base path",
+		content: `apiVersion: chainsaw.kyverno.io/v1alpha1
+kind: Test
+metadata:
+  name: test
+spec:
+  steps:
+  - use:
+      template: missing.yaml
+      with:
+        bindings:
+        - name: input
+          value: ($unknown)
+  - try:
+    - apply:
+        file: missing.yaml
+    - assert:
+        resource:
+          apiVersion: v1
+          kind: ConfigMap
+          metadata:
+            name: foo
+`,
+	}, {
+		name:     "not a test",
+		basePath: basePath,
+		content: `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: foo
+`,
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Lint("test.yaml", tt.basePath, []byte(tt.content), nil)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, got, len(tt.want))
+			if len(got) != len(tt.want) {
+				t.Log(got)
+				return
+			}
+			for i := range tt.want {
+				if tt.want[i].Message == "" {
+					got[i].Message = ""
+				}
+				assert.Equal(t, tt.want[i], got[i])
+			}
+		})
+	}
+}
+
+func TestLint_resources(t *testing.T) {
+	basePath := filepath.Join("..", "..", "testdata", "validation", "test")
+	client, err := validation.NewClient(nil, filepath.Join("..", "..", "testdata", "validation", "crds"))
+	require.NoError(t, err)
+	file := filepath.Join(basePath, "chainsaw-test.yaml")
+	content, err := os.ReadFile(file)
+	require.NoError(t, err)
+	got, err := Lint(file, basePath, content, validation.New(client))
+	require.NoError(t, err)
+	deployment := filepath.Join(basePath, "deployment.yaml")
+	assert.Equal(t, []Issue{{
+		Rule:     RuleResourceSchema,
+		Severity: SeverityError,
+		Message:  `spec.replcas: unknown field "replcas"`,
+		File:     file,
+		Line:     18,
+		Column:   13,
+	}, {
+		Rule:     RuleResourceSchema,
+		Severity: SeverityError,
+		Message:  "spec.size: invalid type, expected integer but got string",
+		File:     file,
+		Line:     29,
+		Column:   15,
+	}, {
+		Rule:     RuleResourceSchema,
+		Severity: SeverityError,
+		Message:  "spec.replicas: invalid type, expected integer but got string",
+		File:     deployment,
+		Line:     6,
+		Column:   3,
+	}, {
+		Rule:     RuleResourceSchema,
+		Severity: SeverityError,
+		Message:  `spec.template.spec.containers[0].port: unknown field "port"`,
+		File:     deployment,
+		Line:     19,
+		Column:   9,
+	}}, got)
+	// resources are not validated without a validator
+	got, err = Lint(file, basePath, content, nil)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
@@ -0,0 +1,100 @@
+package linter
+
+import (
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// child returns the value node of the given key in a mapping node.
+func child(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// index returns the node at the given index in a sequence node.
+func index(node *yaml.Node, i int) *yaml.Node {
+	if node == nil || node.Kind != yaml.SequenceNode || i < 0 || i >= len(node.Content) {
+		return nil
+	}
+	return node.Content[i]
+}
+
+// lookup walks down the node following the given path, string elements are mapping keys and int elements are sequence indices.
+func lookup(node *yaml.Node, path ...any) *yaml.Node {
+	for _, element := range path {
+		switch element := element.(type) {
+		case string:
+			node = child(node, element)
+		case int:
+			node = index(node, element)
+		}
+		if node == nil {
+			return nil
+		}
+	}
+	return node
+}
+
+// locateField returns the node designated by a field path, the key node is returned when the path ends with a mapping key
+// and the deepest existing node is returned when the field doesn't exist.
+func locateField(node *yaml.Node, path []any) *yaml.Node {
+	for i, element := range path {
+		var next *yaml.Node
+		switch element := element.(type) {
+		case string:
+			if node.Kind == yaml.MappingNode {
+				for j := 0; j+1 < len(node.Content); j += 2 {
+					if node.Content[j].Value == element {
+						next = node.Content[j+1]
+						if i == len(path)-1 {
+							next = node.Content[j]
+						}
+						break
+					}
+				}
+			}
+		case int:
+			next = index(node, element)
+		}
+		if next == nil {
+			return node
+		}
+		node = next
+	}
+	return node
+}
+
+// Locate returns the position of a field in the first document of the content.
+// The field uses the dotted notation reported by json schema validation (spec.steps.0.try), the position of the
+// deepest existing parent is returned when the field doesn't exist, zero values are returned when the content can't be parsed.
+func Locate(content []byte, field string) (int, int) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(content, &document); err != nil || len(document.Content) == 0 {
+		return 0, 0
+	}
+	node := document.Content[0]
+	if field != "" && field != "(root)" {
+		for _, element := range strings.Split(field, ".") {
+			var next *yaml.Node
+			if i, err := strconv.Atoi(element); err == nil && node.Kind == yaml.SequenceNode {
+				next = index(node, i)
+			} else {
+				next = child(node, element)
+			}
+			if next == nil {
+				break
+			}
+			node = next
+		}
+	}
+	return node.Line, node.Column
+}
@@ -0,0 +1,49 @@
+package linter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocate(t *testing.T) {
+	content := []byte(`apiVersion: chainsaw.kyverno.io/v1alpha1
+kind: Test
+spec:
+  steps:
+  - try:
+    - sleep:
+        duration: 1s
+`)
+	tests := []struct {
+		name       string
+		field      string
+		wantLine   int
+		wantColumn int
+	}{{
+		name:       "root",
+		field:      "(root)",
+		wantLine:   1,
+		wantColumn: 1,
+	}, {
+		name:       "nested",
+		field:      "spec.steps.0.try.0.sleep",
+		wantLine:   7,
+		wantColumn: 9,
+	}, {
+		name:       "missing",
+		field:      "spec.steps.0.foo",
+		wantLine:   5,
+		wantColumn: 5,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			line, column := Locate(content, tt.field)
+			assert.Equal(t, tt.wantLine, line)
+			assert.Equal(t, tt.wantColumn, column)
+		})
+	}
+	line, column := Locate([]byte("{"), "spec")
+	assert.Equal(t, 0, line)
+	assert.Equal(t, 0, column)
+}
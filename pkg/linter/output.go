@@ -0,0 +1,146 @@
+package linter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type Format string
+
+const (
+	FormatText  Format = "text"
+	FormatJSON  Format = "json"
+	FormatSARIF Format = "sarif"
+)
+
+// Write writes issues to the writer in the given format.
+func Write(writer io.Writer, format Format, issues []Issue) error {
+	switch Format(strings.ToLower(string(format))) {
+	case "", FormatText:
+		return writeText(writer, issues)
+	case FormatJSON:
+		return writeJSON(writer, issues)
+	case FormatSARIF:
+		return writeSARIF(writer, issues)
+	default:
+		return fmt.Errorf("unsupported output format: %s", format)
+	}
+}
+
+func writeText(writer io.Writer, issues []Issue) error {
+	for _, issue := range issues {
+		location := issue.File
+		if issue.Line != 0 {
+			location = fmt.Sprintf("%s:%d:%d", location, issue.Line, issue.Column)
+		}
+		if _, err := fmt.Fprintf(writer, "- %s: %s: %s (%s)\n", location, issue.Severity, issue.Message, issue.Rule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeJSON(writer io.Writer, issues []Issue) error {
+	if issues == nil {
+		issues = []Issue{}
+	}
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(issues)
+}
+
+func writeSARIF(writer io.Writer, issues []Issue) error {
+	type Message struct {
+		Text string `json:"text"`
+	}
+	type ReportingDescriptor struct {
+		ID               string  `json:"id"`
+		ShortDescription Message `json:"shortDescription"`
+	}
+	type Driver struct {
+		Name           string                `json:"name"`
+		InformationURI string                `json:"informationUri"`
+		Rules          []ReportingDescriptor `json:"rules"`
+	}
+	type Tool struct {
+		Driver Driver `json:"driver"`
+	}
+	type ArtifactLocation struct {
+		URI string `json:"uri"`
+	}
+	type Region struct {
+		StartLine   int `json:"startLine"`
+		StartColumn int `json:"startColumn,omitempty"`
+	}
+	type PhysicalLocation struct {
+		ArtifactLocation ArtifactLocation `json:"artifactLocation"`
+		Region           *Region          `json:"region,omitempty"`
+	}
+	type Location struct {
+		PhysicalLocation PhysicalLocation `json:"physicalLocation"`
+	}
+	type Result struct {
+		RuleID    string     `json:"ruleId"`
+		RuleIndex int        `json:"ruleIndex"`
+		Level     string     `json:"level"`
+		Message   Message    `json:"message"`
+		Locations []Location `json:"locations,omitempty"`
+	}
+	type Run struct {
+		Tool    Tool     `json:"tool"`
+		Results []Result `json:"results"`
+	}
+	type Log struct {
+		Schema  string `json:"$schema"`
+		Version string `json:"version"`
+		Runs    []Run  `json:"runs"`
+	}
+	driver := Driver{
+		Name:           "chainsaw",
+		InformationURI: "https://kyverno.github.io/chainsaw",
+	}
+	ruleIndex := map[Rule]int{}
+	for i, rule := range Rules {
+		driver.Rules = append(driver.Rules, ReportingDescriptor{
+			ID:               string(rule.Rule),
+			ShortDescription: Message{Text: rule.Description},
+		})
+		ruleIndex[rule.Rule] = i
+	}
+	results := []Result{}
+	for _, issue := range issues {
+		result := Result{
+			RuleID:    string(issue.Rule),
+			RuleIndex: ruleIndex[issue.Rule],
+			Level:     string(issue.Severity),
+			Message:   Message{Text: issue.Message},
+		}
+		if issue.File != "" {
+			location := Location{
+				PhysicalLocation: PhysicalLocation{
+					ArtifactLocation: ArtifactLocation{URI: issue.File},
+				},
+			}
+			if issue.Line != 0 {
+				location.PhysicalLocation.Region = &Region{
+					StartLine:   issue.Line,
+					StartColumn: issue.Column,
+				}
+			}
+			result.Locations = append(result.Locations, location)
+		}
+		results = append(results, result)
+	}
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(Log{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []Run{{
+			Tool:    Tool{Driver: driver},
+			Results: results,
+		}},
+	})
+}
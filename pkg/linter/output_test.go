@@ -0,0 +1,63 @@
+package linter
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrite(t *testing.T) {
+	issues := []Issue{{
+		Rule:     RuleUnusedOutput,
+		Severity: SeverityWarning,
+		Message:  "output foo is never used",
+		File:     "chainsaw-test.yaml",
+		Line:     12,
+		Column:   17,
+	}, {
+		Rule:     RuleSchema,
+		Severity: SeverityError,
+		Message:  "spec: steps is required",
+		File:     "chainsaw-test.yaml",
+	}}
+	t.Run("text", func(t *testing.T) {
+		var out bytes.Buffer
+		assert.NoError(t, Write(&out, FormatText, issues))
+		assert.Equal(t, "- chainsaw-test.yaml:12:17: warning: output foo is never used (unused-output)\n- chainsaw-test.yaml: error: spec: steps is required (schema)\n", out.String())
+	})
+	t.Run("json", func(t *testing.T) {
+		var out bytes.Buffer
+		assert.NoError(t, Write(&out, FormatJSON, issues))
+		var got []Issue
+		assert.NoError(t, json.Unmarshal(out.Bytes(), &got))
+		assert.Equal(t, issues, got)
+		out.Reset()
+		assert.NoError(t, Write(&out, FormatJSON, nil))
+		assert.Equal(t, "[]\n", out.String())
+	})
+	t.Run("sarif", func(t *testing.T) {
+		var out bytes.Buffer
+		assert.NoError(t, Write(&out, FormatSARIF, issues))
+		var got map[string]any
+		assert.NoError(t, json.Unmarshal(out.Bytes(), &got))
+		assert.Equal(t, "2.1.0", got["version"])
+		runs := got["runs"].([]any)
+		assert.Len(t, runs, 1)
+		results := runs[0].(map[string]any)["results"].([]any)
+		assert.Len(t, results, 2)
+		first := results[0].(map[string]any)
+		assert.Equal(t, "unused-output", first["ruleId"])
+		assert.Equal(t, "warning", first["level"])
+		assert.Equal(t, float64(2), first["ruleIndex"])
+		region := first["locations"].([]any)[0].(map[string]any)["physicalLocation"].(map[string]any)["region"].(map[string]any)
+		assert.Equal(t, float64(12), region["startLine"])
+		assert.Equal(t, float64(17), region["startColumn"])
+		second := results[1].(map[string]any)
+		assert.Nil(t, second["locations"].([]any)[0].(map[string]any)["physicalLocation"].(map[string]any)["region"])
+	})
+	t.Run("unsupported", func(t *testing.T) {
+		assert.Error(t, Write(&bytes.Buffer{}, "xml", issues))
+	})
+}
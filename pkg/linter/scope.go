@@ -0,0 +1,67 @@
+package linter
+
+import (
+	"maps"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+)
+
+// scope holds the names of the bindings available at some point of a test.
+// A scope is dynamic when a binding with a name computed at runtime was registered, any binding is considered available then.
+type scope struct {
+	names   map[string]struct{}
+	dynamic bool
+}
+
+func newScope(names ...string) scope {
+	return scope{}.with(names...)
+}
+
+func (s scope) has(name string) bool {
+	if s.dynamic {
+		return true
+	}
+	_, ok := s.names[name]
+	return ok
+}
+
+func (s scope) with(names ...string) scope {
+	out := scope{
+		names:   maps.Clone(s.names),
+		dynamic: s.dynamic,
+	}
+	if out.names == nil {
+		out.names = map[string]struct{}{}
+	}
+	for _, name := range names {
+		out.names[name] = struct{}{}
+	}
+	return out
+}
+
+func (s scope) withDynamic() scope {
+	out := s.with()
+	out.dynamic = true
+	return out
+}
+
+func (s scope) withBinding(name v1alpha1.Expression) scope {
+	if _, _, ok := valueExpression(string(name), ""); ok {
+		return s.withDynamic()
+	}
+	return s.with(string(name))
+}
+
+func (s scope) withOutputs(outputs []v1alpha1.Output) scope {
+	for _, output := range outputs {
+		s = s.withBinding(output.Name)
+	}
+	return s
+}
+
+func (s scope) merge(other scope) scope {
+	out := s.with()
+	maps.Copy(out.names, other.names)
+	out.dynamic = out.dynamic || other.dynamic
+	return out
+}
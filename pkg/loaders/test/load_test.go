@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
 	tloader "github.com/kyverno/chainsaw/pkg/loaders/testing"
@@ -170,6 +171,39 @@ func TestLoad(t *testing.T) {
 				Steps: []v1alpha1.TestStep{},
 			},
 		}},
+	}, {
+		name: "boolean conditions",
+		path: filepath.Join(basePath, "boolean-conditions.yaml"),
+		want: []*v1alpha1.Test{{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "chainsaw.kyverno.io/v1alpha1",
+				Kind:       "Test",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test",
+			},
+			Spec: v1alpha1.TestSpec{
+				Steps: []v1alpha1.TestStep{{
+					If: "false",
+					TestStepSpec: v1alpha1.TestStepSpec{
+						Try: []v1alpha1.Operation{{
+							OperationBase: v1alpha1.OperationBase{
+								If: "true",
+							},
+							Sleep: &v1alpha1.Sleep{
+								Duration: metav1.Duration{Duration: time.Second},
+							},
+						}},
+						Finally: []v1alpha1.CatchFinally{{
+							If: "false",
+							Sleep: &v1alpha1.Sleep{
+								Duration: metav1.Duration{Duration: time.Second},
+							},
+						}},
+					},
+				}},
+			},
+		}},
 	}, {
 		name: "raw",
 		path: filepath.Join(basePath, "raw-resource.yaml"),
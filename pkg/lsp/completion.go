@@ -0,0 +1,232 @@
+package lsp
+
+import (
+	"fmt"
+	"maps"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	jpfunctions "github.com/jmespath-community/go-jmespath/pkg/functions"
+	"github.com/kyverno/chainsaw/pkg/engine/functions"
+	"github.com/kyverno/kyverno-json/pkg/core/compilers"
+	"gopkg.in/yaml.v3"
+)
+
+var engineRegex = regexp.MustCompile(`^(\w+);`)
+
+// complete returns the completion items at a position of a text document.
+// The character is a byte offset in the line, the file is used to resolve step templates and can be empty.
+func complete(text string, file string, line int, character int) []CompletionItem {
+	lines := splitLines(text)
+	if line < 0 || line >= len(lines) {
+		return nil
+	}
+	lineText := lines[line]
+	character = min(character, len(lineText))
+	replace := func(start int, newText string) *TextEdit {
+		return &TextEdit{
+			Range: Range{
+				Start: Position{Line: line, Character: utf16Offset(lineText, start)},
+				End:   Position{Line: line, Character: utf16Offset(lineText, character)},
+			},
+			NewText: newText,
+		}
+	}
+	doc, offset := document(lines, line)
+	c := analyze(doc, line-offset, character)
+	if c.block {
+		return nil
+	}
+	apiVersion, kind := rootValue(doc, "apiVersion"), rootValue(doc, "kind")
+	if schemaFile(apiVersion, kind) == nil {
+		return nil
+	}
+	if engine, statement, ok := expressionText(c.text); ok {
+		var basePath string
+		if file != "" {
+			basePath = filepath.Dir(file)
+		}
+		s := scopeAt(parse(doc, line-offset), kind, c.path, basePath)
+		if engine == "" {
+			engine = s.compiler
+		}
+		if engine != "" && engine != compilers.CompilerJP {
+			return nil
+		}
+		word := len(statement)
+		for word > 0 && isIdentifier(statement[word-1]) {
+			word--
+		}
+		start := character - (len(statement) - word)
+		switch {
+		case word > 0 && statement[word-1] == '$':
+			return bindingItems(s.sorted(), func(text string) *TextEdit { return replace(start-1, text) })
+		case word == 0 || statement[word-1] != '.':
+			return functionItems(func(text string) *TextEdit { return replace(start, text) })
+		}
+		return nil
+	}
+	if c.onKey {
+		return keyItems(schemaAt(apiVersion, kind, c.path), c.siblings, func(text string) *TextEdit { return replace(c.start, text) })
+	}
+	return valueItems(schemaAt(apiVersion, kind, append(c.path, c.key)), func(text string) *TextEdit { return replace(c.start, text) })
+}
+
+// expressionText returns the engine and the statement of the expression being written in a key or a value,
+// it returns false when the text is not an expression.
+func expressionText(text string) (string, string, bool) {
+	text = strings.TrimLeft(text, `"'`)
+	// foreach keys declare the expression after a ~ prefix (~.(...) or ~name.(...))
+	if strings.HasPrefix(text, "~") {
+		_, after, ok := strings.Cut(text, ".")
+		if !ok {
+			return "", "", false
+		}
+		text = after
+	}
+	if !strings.HasPrefix(text, "(") {
+		return "", "", false
+	}
+	statement := text[1:]
+	var engine string
+	if match := engineRegex.FindStringSubmatch(statement); match != nil {
+		engine = match[1]
+		statement = statement[len(match[0]):]
+	}
+	return engine, statement, true
+}
+
+// parse parses the lines of a document, the line being edited is ignored when it makes the document invalid.
+func parse(lines []string, line int) *yaml.Node {
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(strings.Join(lines, "\n")), &document); err != nil {
+		if line < 0 || line >= len(lines) {
+			return nil
+		}
+		lines = slices.Clone(lines)
+		lines[line] = ""
+		document = yaml.Node{}
+		if err := yaml.Unmarshal([]byte(strings.Join(lines, "\n")), &document); err != nil {
+			return nil
+		}
+	}
+	if len(document.Content) == 0 {
+		return nil
+	}
+	return document.Content[0]
+}
+
+func isIdentifier(c byte) bool {
+	return c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+}
+
+func bindingItems(names []string, edit func(string) *TextEdit) []CompletionItem {
+	items := make([]CompletionItem, 0, len(names))
+	for _, name := range names {
+		items = append(items, CompletionItem{
+			Label:    "$" + name,
+			Kind:     completionItemKindVariable,
+			TextEdit: edit("$" + name),
+		})
+	}
+	return items
+}
+
+func functionItems(edit func(string) *TextEdit) []CompletionItem {
+	// functions registered later override the ones with the same name
+	entries := map[string]jpfunctions.FunctionEntry{}
+	for _, entry := range functions.GetAllFunctions() {
+		entries[entry.Name] = entry
+	}
+	items := make([]CompletionItem, 0, len(entries))
+	for _, name := range slices.Sorted(maps.Keys(entries)) {
+		entry := entries[name]
+		item := CompletionItem{
+			Label:    entry.Name,
+			Kind:     completionItemKindFunction,
+			Detail:   signature(entry),
+			TextEdit: edit(entry.Name),
+		}
+		if entry.Description != "" {
+			item.Documentation = &MarkupContent{Kind: markupKindMarkdown, Value: entry.Description}
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// signature returns the signature of a function, optional arguments are suffixed with ? and variadic ones with ...
+func signature(entry jpfunctions.FunctionEntry) string {
+	args := make([]string, 0, len(entry.Arguments))
+	for _, arg := range entry.Arguments {
+		types := make([]string, 0, len(arg.Types))
+		for _, t := range arg.Types {
+			types = append(types, string(t))
+		}
+		text := strings.Join(types, "|")
+		if arg.Optional {
+			text += "?"
+		}
+		if arg.Variadic {
+			text += "..."
+		}
+		args = append(args, text)
+	}
+	return fmt.Sprintf("%s(%s)", entry.Name, strings.Join(args, ", "))
+}
+
+func keyItems(s jsonSchema, siblings []string, edit func(string) *TextEdit) []CompletionItem {
+	props := properties(s)
+	names := make([]string, 0, len(props))
+	for name := range props {
+		if !slices.Contains(siblings, name) {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	items := make([]CompletionItem, 0, len(names))
+	for _, name := range names {
+		prop, _ := props[name].(jsonSchema)
+		types := schemaTypes(prop)
+		text := name + ": "
+		if slices.Contains(types, "object") || slices.Contains(types, "array") {
+			text = name + ":"
+		}
+		item := CompletionItem{
+			Label:    name,
+			Kind:     completionItemKindField,
+			Detail:   strings.Join(types, "|"),
+			TextEdit: edit(text),
+		}
+		if description, _ := prop["description"].(string); description != "" {
+			item.Documentation = &MarkupContent{Kind: markupKindMarkdown, Value: description}
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// valueItems returns the values allowed by enum and boolean schemas.
+func valueItems(s jsonSchema, edit func(string) *TextEdit) []CompletionItem {
+	var values []string
+	if enum, ok := s["enum"].([]any); ok {
+		for _, value := range enum {
+			if value, ok := value.(string); ok {
+				values = append(values, value)
+			}
+		}
+	} else if slices.Contains(schemaTypes(s), "boolean") {
+		values = []string{"false", "true"}
+	}
+	items := make([]CompletionItem, 0, len(values))
+	for _, value := range values {
+		items = append(items, CompletionItem{
+			Label:    value,
+			Kind:     completionItemKindValue,
+			TextEdit: edit(value),
+		})
+	}
+	return items
+}
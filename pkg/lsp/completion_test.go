@@ -0,0 +1,300 @@
+package lsp
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testFile = "../../testdata/lsp/chainsaw-test.yaml"
+
+func labels(items []CompletionItem) []string {
+	out := make([]string, 0, len(items))
+	for _, item := range items {
+		out = append(out, item.Label)
+	}
+	return out
+}
+
+func Test_complete(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		file    string
+		want    []string
+		notWant []string
+		exact   bool
+	}{{
+		name: "not a chainsaw document",
+		text: "apiVersion: v1\nkind: ConfigMap\n§",
+	}, {
+		name:    "test fields",
+		text:    "apiVersion: chainsaw.kyverno.io/v1alpha1\nkind: Test\nmetadata:\n  name: foo\n§",
+		want:    []string{"spec"},
+		notWant: []string{"apiVersion", "kind", "metadata"},
+	}, {
+		name: "operation fields",
+		text: `apiVersion: chainsaw.kyverno.io/v1alpha1
+kind: Test
+spec:
+  steps:
+  - try:
+    - ap§
+`,
+		want: []string{"apply", "assert", "script", "wait"},
+	}, {
+		name: "action fields",
+		text: `apiVersion: chainsaw.kyverno.io/v1alpha1
+kind: Test
+spec:
+  steps:
+  - try:
+    - apply:
+        file: foo.yaml
+        §
+`,
+		want:    []string{"bindings", "outputs", "template"},
+		notWant: []string{"file"},
+	}, {
+		name: "step template fields",
+		text: `apiVersion: chainsaw.kyverno.io/v1alpha1
+kind: StepTemplate
+spec:
+  §
+`,
+		want:    []string{"bindings", "catch", "try"},
+		notWant: []string{"steps"},
+	}, {
+		name: "configuration fields",
+		text: `apiVersion: chainsaw.kyverno.io/v1alpha2
+kind: Configuration
+spec:
+  execution:
+    §
+`,
+		want: []string{"failFast", "parallel"},
+	}, {
+		name:  "enum values",
+		text:  "apiVersion: chainsaw.kyverno.io/v1alpha1\nkind: Test\nspec:\n  compiler: §\n",
+		want:  []string{"jp", "cel"},
+		exact: true,
+	}, {
+		name:  "boolean values",
+		text:  "apiVersion: chainsaw.kyverno.io/v1alpha1\nkind: Test\nspec:\n  skip: §\n",
+		want:  []string{"false", "true"},
+		exact: true,
+	}, {
+		name: "resource fields are not known",
+		text: `apiVersion: chainsaw.kyverno.io/v1alpha1
+kind: Test
+spec:
+  steps:
+  - try:
+    - apply:
+        resource:
+          §
+`,
+	}, {
+		name: "bindings",
+		text: `apiVersion: chainsaw.kyverno.io/v1alpha1
+kind: Test
+spec:
+  bindings:
+  - name: foo
+    value: bar
+  steps:
+  - bindings:
+    - name: first
+      value: ($§)
+    - name: second
+      value: 2
+`,
+		want:    []string{"$foo", "$namespace", "$values"},
+		notWant: []string{"$first", "$second"},
+	}, {
+		name: "binding fields are not known",
+		text: `apiVersion: chainsaw.kyverno.io/v1alpha1
+kind: Test
+spec:
+  steps:
+  - try:
+    - assert:
+        resource:
+          value: ($namespace.§
+`,
+	}, {
+		name: "outputs of previous operations",
+		text: `apiVersion: chainsaw.kyverno.io/v1alpha1
+kind: Test
+spec:
+  steps:
+  - try:
+    - script:
+        content: echo foo
+        outputs:
+        - name: first
+          value: ($stdout)
+    - assert:
+        resource:
+          (items[?name == 'foo'])->matches:
+            data:
+              value: ($f§
+    - script:
+        content: echo bar
+        outputs:
+        - name: second
+          value: ($stdout)
+  catch:
+  - script:
+      content: echo ($second)
+`,
+		want:    []string{"$first", "$matches", "$stdout"},
+		notWant: []string{"$second"},
+	}, {
+		name: "catch sees step outputs",
+		text: `apiVersion: chainsaw.kyverno.io/v1alpha1
+kind: Test
+spec:
+  steps:
+  - try:
+    - script:
+        content: echo bar
+        outputs:
+        - name: second
+          value: ($stdout)
+    catch:
+    - script:
+        env:
+        - name: FOO
+          value: ($§)
+`,
+		want: []string{"$second"},
+	}, {
+		name: "foreach",
+		text: `apiVersion: chainsaw.kyverno.io/v1alpha1
+kind: Test
+spec:
+  steps:
+  - try:
+    - forEach:
+        range: (range(0, 3))
+      apply:
+        resource:
+          metadata:
+            name: ($§
+`,
+		want: []string{"$item", "$index"},
+	}, {
+		name: "step template bindings",
+		file: testFile,
+		text: `apiVersion: chainsaw.kyverno.io/v1alpha1
+kind: Test
+spec:
+  steps:
+  - use:
+      template: step-template.yaml
+    try:
+    - assert:
+        resource:
+          value: ($§
+`,
+		want: []string{"$replicas", "$greeting"},
+	}, {
+		name: "functions",
+		text: `apiVersion: chainsaw.kyverno.io/v1alpha1
+kind: Test
+spec:
+  steps:
+  - try:
+    - assert:
+        resource:
+          (length§
+`,
+		want:    []string{"length", "env", "x_k8s_get", "trim_space"},
+		notWant: []string{"$namespace"},
+	}, {
+		name: "no functions after a dot",
+		text: `apiVersion: chainsaw.kyverno.io/v1alpha1
+kind: Test
+spec:
+  steps:
+  - try:
+    - assert:
+        resource:
+          (foo.le§
+`,
+	}, {
+		name: "cel expressions",
+		text: `apiVersion: chainsaw.kyverno.io/v1alpha1
+kind: Test
+spec:
+  compiler: cel
+  steps:
+  - try:
+    - assert:
+        resource:
+          value: ($§
+`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, line, character := position(t, tt.text)
+			file := tt.file
+			if file != "" {
+				file = filepath.Clean(file)
+			}
+			got := labels(complete(text, file, line, character))
+			assert.Len(t, slices.Compact(slices.Clone(got)), len(got))
+			if tt.exact {
+				assert.Equal(t, tt.want, got)
+				return
+			}
+			if len(tt.want) == 0 && tt.notWant == nil {
+				assert.Empty(t, got)
+			}
+			for _, want := range tt.want {
+				assert.Contains(t, got, want)
+			}
+			for _, notWant := range tt.notWant {
+				assert.NotContains(t, got, notWant)
+			}
+		})
+	}
+}
+
+func Test_complete_textEdit(t *testing.T) {
+	text, line, character := position(t, `apiVersion: chainsaw.kyverno.io/v1alpha1
+kind: Test
+spec:
+  steps:
+  - try:
+    - assert:
+        resource:
+          value: ($nam§e)
+`)
+	items := complete(text, "", line, character)
+	i := slices.IndexFunc(items, func(item CompletionItem) bool { return item.Label == "$namespace" })
+	if assert.GreaterOrEqual(t, i, 0) {
+		assert.Equal(t, &TextEdit{
+			Range: Range{
+				Start: Position{Line: 7, Character: 18},
+				End:   Position{Line: 7, Character: 22},
+			},
+			NewText: "$namespace",
+		}, items[i].TextEdit)
+	}
+	text, line, character = position(t, `apiVersion: chainsaw.kyverno.io/v1alpha1
+kind: Test
+spec:
+  descr§
+`)
+	items = complete(text, "", line, character)
+	i = slices.IndexFunc(items, func(item CompletionItem) bool { return item.Label == "description" })
+	if assert.GreaterOrEqual(t, i, 0) {
+		assert.Equal(t, "description: ", items[i].TextEdit.NewText)
+		assert.Equal(t, Range{Start: Position{Line: 3, Character: 2}, End: Position{Line: 3, Character: 7}}, items[i].TextEdit.Range)
+		assert.NotNil(t, items[i].Documentation)
+	}
+}
@@ -0,0 +1,62 @@
+package lsp
+
+import (
+	"context"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/kyverno/chainsaw/pkg/expressions"
+)
+
+// definition returns the locations of the files referenced at a position of a text document (use.template and file: values).
+// The character is a byte offset in the line, nothing is returned when the file is empty.
+func definition(text string, file string, line int, character int) []Location {
+	lines := splitLines(text)
+	if file == "" || line < 0 || line >= len(lines) {
+		return nil
+	}
+	doc, offset := document(lines, line)
+	// analyze the whole line to get the complete value
+	c := analyze(doc, line-offset, len(lines[line]))
+	if c.block || c.onKey || character < c.start {
+		return nil
+	}
+	switch {
+	case c.key == "template" && len(c.path) != 0 && c.path[len(c.path)-1] == "use":
+	case c.key == "file":
+	default:
+		return nil
+	}
+	value := scalarValue(c.text)
+	parsed := expressions.Parse(context.TODO(), value)
+	if parsed == nil || parsed.Engine != "" {
+		return nil
+	}
+	if _, err := url.ParseRequestURI(parsed.Statement); err == nil {
+		return nil
+	}
+	matches, err := filepath.Glob(filepath.Join(filepath.Dir(file), parsed.Statement))
+	if err != nil {
+		return nil
+	}
+	locations := make([]Location, 0, len(matches))
+	for _, match := range matches {
+		locations = append(locations, Location{URI: pathToURI(match)})
+	}
+	return locations
+}
+
+// scalarValue returns the value of a scalar written on a single line, quotes and trailing comments are removed.
+func scalarValue(text string) string {
+	text = strings.TrimSpace(text)
+	if len(text) > 1 && (text[0] == '"' || text[0] == '\'') {
+		if end := strings.LastIndexByte(text, text[0]); end > 0 {
+			return unquote(text[:end+1])
+		}
+	}
+	if i := strings.Index(text, " #"); i >= 0 {
+		text = text[:i]
+	}
+	return strings.TrimSpace(text)
+}
@@ -0,0 +1,88 @@
+package lsp
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_definition(t *testing.T) {
+	content, err := os.ReadFile(testFile)
+	require.NoError(t, err)
+	dir, err := filepath.Abs(filepath.Dir(testFile))
+	require.NoError(t, err)
+	tests := []struct {
+		name      string
+		text      string
+		file      string
+		line      int
+		character int
+		want      []Location
+	}{{
+		name:      "step template",
+		text:      string(content),
+		file:      testFile,
+		line:      7,
+		character: 20,
+		want:      []Location{{URI: pathToURI(filepath.Join(dir, "step-template.yaml"))}},
+	}, {
+		name:      "file",
+		text:      string(content),
+		file:      testFile,
+		line:      10,
+		character: 16,
+		want:      []Location{{URI: pathToURI(filepath.Join(dir, "configmap.yaml"))}},
+	}, {
+		name:      "on key",
+		text:      string(content),
+		file:      testFile,
+		line:      10,
+		character: 9,
+	}, {
+		name:      "no file",
+		text:      string(content),
+		line:      10,
+		character: 16,
+	}, {
+		name:      "glob",
+		text:      "spec:\n  steps:\n  - try:\n    - apply:\n        file: '*-template.yaml'\n",
+		file:      testFile,
+		line:      4,
+		character: 16,
+		want:      []Location{{URI: pathToURI(filepath.Join(dir, "step-template.yaml"))}},
+	}, {
+		name:      "expression",
+		text:      "spec:\n  steps:\n  - try:\n    - apply:\n        file: ($file)\n",
+		file:      testFile,
+		line:      4,
+		character: 16,
+	}, {
+		name:      "url",
+		text:      "spec:\n  steps:\n  - try:\n    - apply:\n        file: https://example.com/configmap.yaml\n",
+		file:      testFile,
+		line:      4,
+		character: 16,
+	}, {
+		name:      "missing",
+		text:      "spec:\n  steps:\n  - try:\n    - apply:\n        file: missing.yaml\n",
+		file:      testFile,
+		line:      4,
+		character: 16,
+		want:      []Location{},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, definition(tt.text, tt.file, tt.line, tt.character))
+		})
+	}
+}
+
+func Test_scalarValue(t *testing.T) {
+	assert.Equal(t, "foo.yaml", scalarValue("foo.yaml"))
+	assert.Equal(t, "foo.yaml", scalarValue("foo.yaml # comment"))
+	assert.Equal(t, "foo # bar.yaml", scalarValue(`"foo # bar.yaml" # comment`))
+	assert.Equal(t, "it's.yaml", scalarValue(`'it''s.yaml'`))
+}
@@ -0,0 +1,125 @@
+package lsp
+
+import (
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/kyverno/chainsaw/pkg/linter"
+	"github.com/xeipuuv/gojsonschema"
+	"sigs.k8s.io/yaml"
+)
+
+const diagnosticSource = "chainsaw"
+
+var yamlErrorLineRegex = regexp.MustCompile(`line (\d+)`)
+
+// diagnose returns the issues found in a text document.
+// Chainsaw documents are validated against their JSON schema, tests are analyzed by the linter when their schema is valid.
+// The file is used to resolve referenced files and step templates, they are not checked when it is empty.
+func diagnose(text string, file string) []Diagnostic {
+	lines := splitLines(text)
+	diagnostics := []Diagnostic{}
+	report := func(line int, column int, severity int, code string, message string) {
+		line = max(0, min(line, len(lines)-1))
+		start := runeOffset(lines[line], column)
+		end := len(strings.TrimRight(lines[line], " \t"))
+		if end <= start {
+			end = len(lines[line])
+		}
+		diagnostics = append(diagnostics, Diagnostic{
+			Range: Range{
+				Start: Position{Line: line, Character: utf16Offset(lines[line], start)},
+				End:   Position{Line: line, Character: utf16Offset(lines[line], end)},
+			},
+			Severity: severity,
+			Code:     code,
+			Source:   diagnosticSource,
+			Message:  message,
+		})
+	}
+	// the linter runs when the text contains valid tests only
+	tests, lint := 0, true
+	for _, doc := range splitDocuments(lines) {
+		apiVersion, kind := rootValue(doc.lines, "apiVersion"), rootValue(doc.lines, "kind")
+		schema := schemaFile(apiVersion, kind)
+		if schema == nil {
+			if strings.TrimSpace(strings.Join(doc.lines, "")) != "" {
+				lint = false
+			}
+			continue
+		}
+		if kind == "Test" {
+			tests++
+		} else {
+			lint = false
+		}
+		content := []byte(strings.Join(doc.lines, "\n"))
+		jsonContent, err := yaml.YAMLToJSON(content)
+		if err != nil {
+			line := 0
+			if match := yamlErrorLineRegex.FindStringSubmatch(err.Error()); match != nil {
+				line, _ = strconv.Atoi(match[1])
+				line--
+			}
+			report(doc.start+line, 0, diagnosticSeverityError, string(linter.RuleSchema), err.Error())
+			lint = false
+			continue
+		}
+		result, err := gojsonschema.Validate(gojsonschema.NewBytesLoader(schema), gojsonschema.NewBytesLoader(jsonContent))
+		if err != nil {
+			report(doc.start, 0, diagnosticSeverityError, string(linter.RuleSchema), err.Error())
+			lint = false
+			continue
+		}
+		for _, desc := range result.Errors() {
+			line, column := linter.Locate(content, desc.Field())
+			report(doc.start+line-1, column-1, diagnosticSeverityError, string(linter.RuleSchema), desc.String())
+			lint = false
+		}
+	}
+	if !lint || tests == 0 {
+		return diagnostics
+	}
+	var basePath string
+	if file != "" {
+		basePath = filepath.Dir(file)
+	}
+	issues, err := linter.Lint(file, basePath, []byte(text), nil)
+	if err != nil {
+		report(0, 0, diagnosticSeverityError, "", err.Error())
+		return diagnostics
+	}
+	for _, issue := range issues {
+		if issue.File != file {
+			continue
+		}
+		severity := diagnosticSeverityWarning
+		if issue.Severity == linter.SeverityError {
+			severity = diagnosticSeverityError
+		}
+		report(issue.Line-1, issue.Column-1, severity, string(issue.Rule), issue.Message)
+	}
+	return diagnostics
+}
+
+type chunk struct {
+	start int
+	lines []string
+}
+
+// splitDocuments splits lines into documents.
+func splitDocuments(lines []string) []chunk {
+	var chunks []chunk
+	current := chunk{}
+	for i, line := range lines {
+		if isSeparator(line) {
+			chunks = append(chunks, current)
+			current = chunk{start: i + 1}
+			continue
+		}
+		current.lines = append(current.lines, line)
+	}
+	return append(chunks, current)
+}
@@ -0,0 +1,114 @@
+package lsp
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_diagnose(t *testing.T) {
+	content, err := os.ReadFile(testFile)
+	require.NoError(t, err)
+	tests := []struct {
+		name string
+		text string
+		file string
+		want []Diagnostic
+	}{{
+		name: "valid test",
+		text: string(content),
+		file: testFile,
+		want: []Diagnostic{},
+	}, {
+		name: "not a chainsaw document",
+		text: "apiVersion: v1\nkind: ConfigMap\ndata:\n  foo: 1\n",
+		want: []Diagnostic{},
+	}, {
+		name: "schema",
+		text: `apiVersion: chainsaw.kyverno.io/v1alpha1
+kind: Test
+metadata:
+  name: foo
+spec:
+  steps:
+  - try:
+    - apply:
+        file: foo.yaml
+      unknown: true
+`,
+		want: []Diagnostic{{
+			Range:    Range{Start: Position{Line: 7, Character: 6}, End: Position{Line: 7, Character: 12}},
+			Severity: diagnosticSeverityError,
+			Code:     "schema",
+			Source:   "chainsaw",
+			Message:  "spec.steps.0.try.0: Additional property unknown is not allowed",
+		}},
+	}, {
+		name: "multiple documents",
+		text: `apiVersion: v1
+kind: ConfigMap
+---
+apiVersion: chainsaw.kyverno.io/v1alpha1
+kind: StepTemplate
+metadata:
+  name: foo
+spec:
+  try: foo
+`,
+		want: []Diagnostic{{
+			Range:    Range{Start: Position{Line: 8, Character: 7}, End: Position{Line: 8, Character: 10}},
+			Severity: diagnosticSeverityError,
+			Code:     "schema",
+			Source:   "chainsaw",
+			Message:  "spec.try: Invalid type. Expected: array, given: string",
+		}},
+	}, {
+		name: "invalid yaml",
+		text: "apiVersion: chainsaw.kyverno.io/v1alpha1\nkind: Test\nspec:\n  steps: [\n",
+		want: []Diagnostic{{
+			Range:    Range{Start: Position{Line: 3, Character: 0}, End: Position{Line: 3, Character: 10}},
+			Severity: diagnosticSeverityError,
+			Code:     "schema",
+			Source:   "chainsaw",
+			Message:  "yaml: line 4: did not find expected node content",
+		}},
+	}, {
+		name: "semantic",
+		text: `apiVersion: chainsaw.kyverno.io/v1alpha1
+kind: Test
+metadata:
+  name: foo
+spec:
+  steps:
+  - try:
+    - script:
+        content: echo foo
+        env:
+        - name: FOO
+          value: ($foo)
+    - apply:
+        file: missing.yaml
+`,
+		file: testFile,
+		want: []Diagnostic{{
+			Range:    Range{Start: Position{Line: 11, Character: 17}, End: Position{Line: 11, Character: 23}},
+			Severity: diagnosticSeverityWarning,
+			Code:     "undefined-binding",
+			Source:   "chainsaw",
+			Message:  "binding $foo is not defined",
+		}, {
+			Range:    Range{Start: Position{Line: 13, Character: 14}, End: Position{Line: 13, Character: 26}},
+			Severity: diagnosticSeverityError,
+			Code:     "missing-file",
+			Source:   "chainsaw",
+			Message:  "no file found matching missing.yaml",
+		}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, diagnose(tt.text, tt.file))
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// json-rpc error codes used by the server.
+const (
+	codeParseError     = -32700
+	codeInvalidParams  = -32602
+	codeMethodNotFound = -32601
+	codeInternalError  = -32603
+)
+
+// message is a json-rpc request, notification or response.
+// Requests have an id and a method, notifications have a method only and responses have an id only.
+type message struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id,omitempty"`
+	Method  string           `json:"method,omitempty"`
+	Params  json.RawMessage  `json:"params,omitempty"`
+	Result  json.RawMessage  `json:"result,omitempty"`
+	Error   *responseError   `json:"error,omitempty"`
+}
+
+type responseError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *responseError) Error() string {
+	return e.Message
+}
+
+// conn reads and writes json-rpc messages using the base protocol framing (Content-Length headers).
+type conn struct {
+	reader *bufio.Reader
+	writer io.Writer
+	lock   sync.Mutex
+}
+
+func newConn(reader io.Reader, writer io.Writer) *conn {
+	return &conn{
+		reader: bufio.NewReader(reader),
+		writer: writer,
+	}
+}
+
+func (c *conn) read() (*message, error) {
+	headers, err := textproto.NewReader(c.reader).ReadMIMEHeader()
+	if err != nil {
+		if len(headers) == 0 && err == io.EOF {
+			return nil, io.EOF
+		}
+		return nil, err
+	}
+	length, err := strconv.Atoi(strings.TrimSpace(headers.Get("Content-Length")))
+	if err != nil || length < 0 {
+		return nil, fmt.Errorf("invalid Content-Length header: %q", headers.Get("Content-Length"))
+	}
+	content := make([]byte, length)
+	if _, err := io.ReadFull(c.reader, content); err != nil {
+		return nil, err
+	}
+	var msg message
+	if err := json.Unmarshal(content, &msg); err != nil {
+		return nil, &responseError{Code: codeParseError, Message: err.Error()}
+	}
+	return &msg, nil
+}
+
+func (c *conn) write(msg *message) error {
+	msg.JSONRPC = "2.0"
+	content, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if _, err := fmt.Fprintf(c.writer, "Content-Length: %d\r\n\r\n", len(content)); err != nil {
+		return err
+	}
+	_, err = c.writer.Write(content)
+	return err
+}
+
+// reply writes the response of a request, the result is ignored when err is not nil.
+// The id is null when the request could not be parsed.
+func (c *conn) reply(id *json.RawMessage, result any, err error) error {
+	if id == nil {
+		null := json.RawMessage("null")
+		id = &null
+	}
+	msg := &message{ID: id}
+	if err != nil {
+		rpcErr, ok := err.(*responseError)
+		if !ok {
+			rpcErr = &responseError{Code: codeInternalError, Message: err.Error()}
+		}
+		msg.Error = rpcErr
+	} else {
+		content, err := json.Marshal(result)
+		if err != nil {
+			return err
+		}
+		msg.Result = content
+	}
+	return c.write(msg)
+}
+
+func (c *conn) notify(method string, params any) error {
+	content, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	return c.write(&message{Method: method, Params: content})
+}
@@ -0,0 +1,112 @@
+package lsp
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"strings"
+	"sync"
+
+	"github.com/kyverno/chainsaw/pkg/data"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const chainsawGroup = "chainsaw.kyverno.io"
+
+type jsonSchema = map[string]any
+
+var schemas = sync.OnceValues(func() (map[string][]byte, error) {
+	schemasFs, err := data.Schemas()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := fs.ReadDir(schemasFs, ".")
+	if err != nil {
+		return nil, err
+	}
+	out := map[string][]byte{}
+	for _, entry := range entries {
+		content, err := fs.ReadFile(schemasFs, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		out[entry.Name()] = content
+	}
+	return out, nil
+})
+
+// schemaFile returns the JSON schema of a chainsaw document, nil is returned for other documents.
+func schemaFile(apiVersion string, kind string) []byte {
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil || gv.Group != chainsawGroup || kind == "" {
+		return nil
+	}
+	files, err := schemas()
+	if err != nil {
+		return nil
+	}
+	return files[fmt.Sprintf("%s-chainsaw-%s.json", strings.ToLower(kind), gv.Version)]
+}
+
+// schemaAt returns the JSON schema of the node designated by the path in a chainsaw document.
+func schemaAt(apiVersion string, kind string, path []any) jsonSchema {
+	content := schemaFile(apiVersion, kind)
+	if content == nil {
+		return nil
+	}
+	var s jsonSchema
+	if err := json.Unmarshal(content, &s); err != nil {
+		return nil
+	}
+	for _, element := range path {
+		switch element := element.(type) {
+		case string:
+			s, _ = properties(s)[element].(jsonSchema)
+		case int:
+			s, _ = s["items"].(jsonSchema)
+		}
+		if s == nil {
+			return nil
+		}
+	}
+	return s
+}
+
+// properties returns the properties of an object schema, including the ones declared in sub schemas.
+func properties(s jsonSchema) jsonSchema {
+	out := jsonSchema{}
+	if props, ok := s["properties"].(jsonSchema); ok {
+		for name, prop := range props {
+			out[name] = prop
+		}
+	}
+	for _, key := range []string{"allOf", "anyOf", "oneOf"} {
+		subs, _ := s[key].([]any)
+		for _, sub := range subs {
+			if sub, ok := sub.(jsonSchema); ok {
+				for name, prop := range properties(sub) {
+					if _, exists := out[name]; !exists {
+						out[name] = prop
+					}
+				}
+			}
+		}
+	}
+	return out
+}
+
+// schemaTypes returns the types allowed by a schema, null is ignored.
+func schemaTypes(s jsonSchema) []string {
+	var out []string
+	switch t := s["type"].(type) {
+	case string:
+		out = append(out, t)
+	case []any:
+		for _, t := range t {
+			if t, ok := t.(string); ok && t != "null" {
+				out = append(out, t)
+			}
+		}
+	}
+	return out
+}
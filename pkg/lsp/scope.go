@@ -0,0 +1,291 @@
+package lsp
+
+import (
+	"path/filepath"
+	"slices"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/linter"
+	"github.com/kyverno/chainsaw/pkg/loaders/steptemplate"
+	"github.com/kyverno/kyverno-json/pkg/core/compilers"
+	"github.com/kyverno/kyverno-json/pkg/core/expression"
+	"gopkg.in/yaml.v3"
+)
+
+// scope collects the bindings available at some point of a test or step template, following the evaluation order of chainsaw.
+type scope struct {
+	basePath string
+	names    map[string]struct{}
+	// compiler is the compiler used by default to evaluate expressions, empty means jp
+	compiler string
+}
+
+// scopeAt returns the scope at the given path of a test or step template document.
+// The base path is used to load step templates, they are not loaded when it is empty.
+func scopeAt(root *yaml.Node, kind string, path []any, basePath string) *scope {
+	s := &scope{
+		basePath: basePath,
+		names:    map[string]struct{}{},
+	}
+	s.add(linter.Builtins...)
+	if head(path) != "spec" {
+		return s
+	}
+	spec := child(root, "spec")
+	s.compilerOf(spec)
+	path = path[1:]
+	switch kind {
+	case "Test":
+		scenarios := child(spec, "scenarios")
+		if scenarios != nil && scenarios.Kind == yaml.SequenceNode {
+			for _, scenario := range scenarios.Content {
+				s.bindings(child(scenario, "bindings"), nil)
+			}
+		}
+		s.bindings(child(spec, "bindings"), path)
+		switch head(path) {
+		case "steps":
+			if i, ok := at(path, 1); ok {
+				s.step(index(child(spec, "steps"), i), path[2:])
+			}
+		case "catch":
+			// the test catch block runs in the context of the failing step
+			s.outputs(child(spec, "steps"))
+			s.block(child(spec, "catch"), path[1:])
+		}
+	case "StepTemplate":
+		s.step(spec, path)
+	}
+	return s
+}
+
+// sorted returns the names of the bindings in the scope.
+func (s *scope) sorted() []string {
+	names := make([]string, 0, len(s.names))
+	for name := range s.names {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func (s *scope) add(names ...string) {
+	for _, name := range names {
+		// names computed at runtime are not known
+		if name != "" && expression.Parse(name).Compiler == "" {
+			s.names[name] = struct{}{}
+		}
+	}
+}
+
+func (s *scope) compilerOf(node *yaml.Node) {
+	if compiler := child(node, "compiler"); compiler != nil {
+		switch compiler.Value {
+		case compilers.CompilerJP, compilers.CompilerCEL:
+			s.compiler = compiler.Value
+		}
+	}
+}
+
+// bindings adds the bindings declared in the node, only the bindings declared before the path are added when the path
+// designates a binding.
+func (s *scope) bindings(node *yaml.Node, path []any) {
+	if node == nil || node.Kind != yaml.SequenceNode {
+		return
+	}
+	limit := len(node.Content)
+	if head(path) == "bindings" {
+		if i, ok := at(path, 1); ok {
+			limit = min(i, limit)
+		}
+	}
+	for _, binding := range node.Content[:limit] {
+		if name := child(binding, "name"); name != nil {
+			s.add(name.Value)
+		}
+	}
+}
+
+// outputs adds the outputs declared in the nodes, outputs of nested operations included.
+func (s *scope) outputs(nodes ...*yaml.Node) {
+	for _, node := range nodes {
+		if node == nil {
+			continue
+		}
+		if node.Kind == yaml.MappingNode {
+			if outputs := child(node, "outputs"); outputs != nil && outputs.Kind == yaml.SequenceNode {
+				for _, output := range outputs.Content {
+					if name := child(output, "name"); name != nil {
+						s.add(name.Value)
+					}
+				}
+			}
+		}
+		s.outputs(node.Content...)
+	}
+}
+
+func (s *scope) step(node *yaml.Node, path []any) {
+	s.compilerOf(node)
+	if head(path) == "forEach" {
+		return
+	}
+	if child(node, "forEach") != nil {
+		s.add("item", "index")
+	}
+	if head(path) == "if" {
+		return
+	}
+	s.bindings(child(node, "bindings"), path)
+	if use := child(node, "use"); use != nil {
+		s.template(child(use, "template"))
+		if head(path) == "use" {
+			if len(path) > 2 && path[1] == "with" {
+				s.bindings(lookup(use, "with", "bindings"), path[2:])
+			}
+			return
+		}
+		s.bindings(lookup(use, "with", "bindings"), nil)
+	}
+	i, ok := at(path, 1)
+	if !ok {
+		return
+	}
+	switch head(path) {
+	case "try":
+		operations := child(node, "try")
+		if operations != nil && operations.Kind == yaml.SequenceNode && i <= len(operations.Content) {
+			s.outputs(operations.Content[:i]...)
+		}
+		s.operation(index(operations, i), path[2:])
+	case "catch", "finally", "cleanup":
+		s.outputs(child(node, "try"))
+		s.block(child(node, head(path).(string)), path[1:])
+	}
+}
+
+// block adds the bindings available in a catch, finally or cleanup operation, the path starts with the operation index.
+func (s *scope) block(node *yaml.Node, path []any) {
+	i, ok := at(path, 0)
+	if !ok || node == nil || node.Kind != yaml.SequenceNode {
+		return
+	}
+	s.outputs(node.Content[:min(i, len(node.Content))]...)
+	s.operation(index(node, i), path[1:])
+}
+
+func (s *scope) operation(node *yaml.Node, path []any) {
+	s.compilerOf(node)
+	if node == nil || len(path) == 0 || head(path) == "forEach" {
+		return
+	}
+	if child(node, "forEach") != nil {
+		s.add("item", "index")
+	}
+	if head(path) == "parallel" {
+		if i, ok := at(path, 1); ok {
+			s.operation(index(child(node, "parallel"), i), path[2:])
+		}
+		return
+	}
+	key, ok := head(path).(string)
+	if !ok {
+		return
+	}
+	action := child(node, key)
+	s.compilerOf(action)
+	s.bindings(child(action, "bindings"), path[1:])
+	// bindings created by assertion tree keys are available in the whole tree
+	current := action
+	for _, element := range path[1:] {
+		if current == nil {
+			break
+		}
+		if current.Kind == yaml.MappingNode {
+			for i := 0; i+1 < len(current.Content); i += 2 {
+				parsed := expression.Parse(current.Content[i].Value)
+				s.add(parsed.ForeachName, parsed.Binding)
+			}
+		}
+		current = lookup(current, element)
+	}
+}
+
+// template adds the bindings and outputs declared in the step template referenced by the node.
+func (s *scope) template(node *yaml.Node) {
+	if s.basePath == "" || node == nil || node.Value == "" {
+		return
+	}
+	templates, err := steptemplate.Load(filepath.Join(s.basePath, node.Value), true)
+	if err != nil || len(templates) != 1 {
+		return
+	}
+	spec := templates[0].Spec
+	for _, binding := range spec.Bindings {
+		s.add(string(binding.Name))
+	}
+	for _, operation := range spec.Try {
+		for _, output := range operation.Outputs() {
+			s.add(string(output.Name))
+		}
+	}
+	for _, operations := range [][]v1alpha1.CatchFinally{spec.Catch, spec.Finally, spec.Cleanup} {
+		for _, operation := range operations {
+			for _, output := range operation.Outputs() {
+				s.add(string(output.Name))
+			}
+		}
+	}
+}
+
+func head(path []any) any {
+	if len(path) == 0 {
+		return nil
+	}
+	return path[0]
+}
+
+func at(path []any, i int) (int, bool) {
+	if i >= len(path) {
+		return 0, false
+	}
+	index, ok := path[i].(int)
+	return index, ok
+}
+
+// child returns the value node of the given key in a mapping node.
+func child(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// index returns the node at the given index in a sequence node.
+func index(node *yaml.Node, i int) *yaml.Node {
+	if node == nil || node.Kind != yaml.SequenceNode || i < 0 || i >= len(node.Content) {
+		return nil
+	}
+	return node.Content[i]
+}
+
+// lookup walks down the node following the given path, string elements are mapping keys and int elements are sequence indices.
+func lookup(node *yaml.Node, path ...any) *yaml.Node {
+	for _, element := range path {
+		switch element := element.(type) {
+		case string:
+			node = child(node, element)
+		case int:
+			node = index(node, element)
+		}
+		if node == nil {
+			return nil
+		}
+	}
+	return node
+}
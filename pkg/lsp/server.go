@@ -0,0 +1,166 @@
+package lsp
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/kyverno/chainsaw/pkg/version"
+)
+
+// Server is a language server for chainsaw tests, step templates and configuration files.
+// It provides completion of fields, bindings and JMESPath functions, navigation to referenced files and diagnostics.
+type Server struct {
+	conn      *conn
+	documents map[string]string
+}
+
+// NewServer creates a server communicating with a client over the given reader and writer (usually stdin and stdout).
+func NewServer(reader io.Reader, writer io.Writer) *Server {
+	return &Server{
+		conn:      newConn(reader, writer),
+		documents: map[string]string{},
+	}
+}
+
+// Serve processes messages until the client sends the exit notification or closes the connection.
+func (s *Server) Serve() error {
+	for {
+		msg, err := s.conn.read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			var rpcErr *responseError
+			if errors.As(err, &rpcErr) {
+				if err := s.conn.reply(nil, nil, rpcErr); err != nil {
+					return err
+				}
+				continue
+			}
+			return err
+		}
+		if msg.Method == "exit" {
+			return nil
+		}
+		result, err := s.handle(msg)
+		// notifications don't get a response
+		if msg.ID == nil {
+			continue
+		}
+		if err := s.conn.reply(msg.ID, result, err); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Server) handle(msg *message) (any, error) {
+	switch msg.Method {
+	case "initialize":
+		return InitializeResult{
+			Capabilities: ServerCapabilities{
+				TextDocumentSync: textDocumentSyncFull,
+				CompletionProvider: CompletionOptions{
+					TriggerCharacters: []string{"$", "("},
+				},
+				DefinitionProvider: true,
+			},
+			ServerInfo: ServerInfo{
+				Name:    "chainsaw",
+				Version: version.Version(),
+			},
+		}, nil
+	case "shutdown":
+		return nil, nil
+	case "textDocument/didOpen":
+		var params DidOpenTextDocumentParams
+		if err := decode(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		s.documents[params.TextDocument.URI] = params.TextDocument.Text
+		return nil, s.publish(params.TextDocument.URI)
+	case "textDocument/didChange":
+		var params DidChangeTextDocumentParams
+		if err := decode(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		// documents are synchronized in full, the last change holds the whole content
+		if len(params.ContentChanges) != 0 {
+			s.documents[params.TextDocument.URI] = params.ContentChanges[len(params.ContentChanges)-1].Text
+		}
+		return nil, s.publish(params.TextDocument.URI)
+	case "textDocument/didSave":
+		var params DidSaveTextDocumentParams
+		if err := decode(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		// referenced files may have changed
+		return nil, s.publish(params.TextDocument.URI)
+	case "textDocument/didClose":
+		var params DidCloseTextDocumentParams
+		if err := decode(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		delete(s.documents, params.TextDocument.URI)
+		return nil, s.conn.notify("textDocument/publishDiagnostics", PublishDiagnosticsParams{
+			URI:         params.TextDocument.URI,
+			Diagnostics: []Diagnostic{},
+		})
+	case "textDocument/completion":
+		var params TextDocumentPositionParams
+		if err := decode(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		text, line, character := s.position(params)
+		items := complete(text, uriToPath(params.TextDocument.URI), line, character)
+		if items == nil {
+			items = []CompletionItem{}
+		}
+		return CompletionList{Items: items}, nil
+	case "textDocument/definition":
+		var params TextDocumentPositionParams
+		if err := decode(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		text, line, character := s.position(params)
+		locations := definition(text, uriToPath(params.TextDocument.URI), line, character)
+		if locations == nil {
+			locations = []Location{}
+		}
+		return locations, nil
+	}
+	if msg.ID == nil {
+		// unsupported notifications are ignored
+		return nil, nil
+	}
+	return nil, &responseError{Code: codeMethodNotFound, Message: "method not supported: " + msg.Method}
+}
+
+// position returns the content of the document and the position converted to a byte offset.
+func (s *Server) position(params TextDocumentPositionParams) (string, int, int) {
+	text := s.documents[params.TextDocument.URI]
+	lines := splitLines(text)
+	line, character := params.Position.Line, params.Position.Character
+	if line >= 0 && line < len(lines) {
+		character = byteOffset(lines[line], character)
+	}
+	return text, line, character
+}
+
+func (s *Server) publish(uri string) error {
+	text, ok := s.documents[uri]
+	if !ok {
+		return nil
+	}
+	return s.conn.notify("textDocument/publishDiagnostics", PublishDiagnosticsParams{
+		URI:         uri,
+		Diagnostics: diagnose(text, uriToPath(uri)),
+	})
+}
+
+func decode(params json.RawMessage, out any) error {
+	if err := json.Unmarshal(params, out); err != nil {
+		return &responseError{Code: codeInvalidParams, Message: err.Error()}
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package lsp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encode(t *testing.T, msg map[string]any) string {
+	t.Helper()
+	msg["jsonrpc"] = "2.0"
+	content, err := json.Marshal(msg)
+	require.NoError(t, err)
+	return fmt.Sprintf("Content-Length: %d\r\n\r\n%s", len(content), content)
+}
+
+func readAll(t *testing.T, out io.Reader) []*message {
+	t.Helper()
+	c := newConn(out, nil)
+	var messages []*message
+	for {
+		msg, err := c.read()
+		if err == io.EOF {
+			return messages
+		}
+		require.NoError(t, err)
+		messages = append(messages, msg)
+	}
+}
+
+func TestServer_Serve(t *testing.T) {
+	content, err := os.ReadFile(testFile)
+	require.NoError(t, err)
+	uri := pathToURI(testFile)
+	var in bytes.Buffer
+	for _, msg := range []map[string]any{
+		{"id": 1, "method": "initialize", "params": map[string]any{}},
+		{"method": "initialized", "params": map[string]any{}},
+		{"method": "textDocument/didOpen", "params": map[string]any{
+			"textDocument": map[string]any{"uri": uri, "languageId": "yaml", "version": 1, "text": string(content)},
+		}},
+		{"id": 2, "method": "textDocument/completion", "params": map[string]any{
+			"textDocument": map[string]any{"uri": uri},
+			"position":     map[string]any{"line": 5, "character": 2},
+		}},
+		{"id": 3, "method": "textDocument/definition", "params": map[string]any{
+			"textDocument": map[string]any{"uri": uri},
+			"position":     map[string]any{"line": 7, "character": 20},
+		}},
+		{"method": "textDocument/didChange", "params": map[string]any{
+			"textDocument":   map[string]any{"uri": uri, "version": 2},
+			"contentChanges": []any{map[string]any{"text": "apiVersion: chainsaw.kyverno.io/v1alpha1\nkind: Test\nspec: foo\n"}},
+		}},
+		{"id": 4, "method": "textDocument/hover", "params": map[string]any{}},
+		{"id": 5, "method": "shutdown"},
+		{"method": "exit"},
+		{"id": 6, "method": "initialize", "params": map[string]any{}},
+	} {
+		in.WriteString(encode(t, msg))
+	}
+	var out bytes.Buffer
+	require.NoError(t, NewServer(&in, &out).Serve())
+	messages := readAll(t, &out)
+	require.Len(t, messages, 7)
+	// initialize
+	assert.Equal(t, "1", string(*messages[0].ID))
+	var initialize InitializeResult
+	require.NoError(t, json.Unmarshal(messages[0].Result, &initialize))
+	assert.Equal(t, "chainsaw", initialize.ServerInfo.Name)
+	assert.True(t, initialize.Capabilities.DefinitionProvider)
+	// diagnostics of the opened document
+	assert.Equal(t, "textDocument/publishDiagnostics", messages[1].Method)
+	var diagnostics PublishDiagnosticsParams
+	require.NoError(t, json.Unmarshal(messages[1].Params, &diagnostics))
+	assert.Equal(t, uri, diagnostics.URI)
+	assert.Empty(t, diagnostics.Diagnostics)
+	// completion
+	assert.Equal(t, "2", string(*messages[2].ID))
+	var completion CompletionList
+	require.NoError(t, json.Unmarshal(messages[2].Result, &completion))
+	assert.Contains(t, labels(completion.Items), "description")
+	// definition
+	assert.Equal(t, "3", string(*messages[3].ID))
+	var locations []Location
+	require.NoError(t, json.Unmarshal(messages[3].Result, &locations))
+	assert.Len(t, locations, 1)
+	// diagnostics of the changed document
+	require.NoError(t, json.Unmarshal(messages[4].Params, &diagnostics))
+	assert.Len(t, diagnostics.Diagnostics, 1)
+	// unsupported method
+	assert.Equal(t, "4", string(*messages[5].ID))
+	assert.Equal(t, codeMethodNotFound, messages[5].Error.Code)
+	// shutdown, messages after exit are not processed
+	assert.Equal(t, "5", string(*messages[6].ID))
+	assert.Equal(t, "null", string(messages[6].Result))
+}
+
+func TestServer_Serve_invalidMessage(t *testing.T) {
+	in := bytes.NewBufferString("Content-Length: 3\r\n\r\n{]}")
+	var out bytes.Buffer
+	require.NoError(t, NewServer(in, &out).Serve())
+	assert.Contains(t, out.String(), `"id":null`)
+	messages := readAll(t, &out)
+	require.Len(t, messages, 1)
+	assert.Nil(t, messages[0].ID)
+	assert.Equal(t, codeParseError, messages[0].Error.Code)
+	in = bytes.NewBufferString("Content-Length: foo\r\n\r\n")
+	assert.Error(t, NewServer(in, &out).Serve())
+}
+
+func Test_utf16(t *testing.T) {
+	line := "a: é😀b"
+	assert.Equal(t, 3, byteOffset(line, 3))
+	assert.Equal(t, 5, byteOffset(line, 4))
+	assert.Equal(t, 9, byteOffset(line, 6))
+	assert.Equal(t, len(line), byteOffset(line, 100))
+	assert.Equal(t, 6, utf16Offset(line, 9))
+	assert.Equal(t, 4, utf16Offset(line, 5))
+	assert.Equal(t, 9, runeOffset(line, 5))
+}
@@ -0,0 +1,312 @@
+package lsp
+
+import (
+	"strconv"
+	"strings"
+)
+
+// The structure of documents being edited is computed from the text, line by line, because documents are often
+// not valid YAML while a user is typing. Only block style constructs are supported (flow collections are scalars).
+
+type tokenKind int
+
+const (
+	tokenItem tokenKind = iota
+	tokenKey
+	tokenScalar
+)
+
+// token is a structural element of a line: a sequence item marker, a mapping key or a scalar.
+type token struct {
+	kind tokenKind
+	col  int
+	// key is the unquoted key of key tokens
+	key string
+	// value is the text following the key of key tokens or the text of scalar tokens
+	value    string
+	valueCol int
+}
+
+// tokenize returns the tokens of a line, a line contains item markers optionally followed by a key or a scalar.
+func tokenize(line string) []token {
+	var tokens []token
+	i := indentation(line)
+	for i < len(line) {
+		rest := line[i:]
+		if rest == "-" || strings.HasPrefix(rest, "- ") {
+			tokens = append(tokens, token{kind: tokenItem, col: i})
+			i++
+			for i < len(line) && line[i] == ' ' {
+				i++
+			}
+			continue
+		}
+		if strings.HasPrefix(rest, "#") {
+			break
+		}
+		if key, end, ok := splitKey(rest); ok {
+			valueCol := i + end
+			for valueCol < len(line) && line[valueCol] == ' ' {
+				valueCol++
+			}
+			tokens = append(tokens, token{kind: tokenKey, col: i, key: key, value: line[valueCol:], valueCol: valueCol})
+		} else {
+			tokens = append(tokens, token{kind: tokenScalar, col: i, value: rest, valueCol: i})
+		}
+		break
+	}
+	return tokens
+}
+
+// splitKey returns the key at the beginning of the text and the offset following the colon.
+func splitKey(text string) (string, int, bool) {
+	if text == "" {
+		return "", 0, false
+	}
+	switch text[0] {
+	case '{', '[':
+		return "", 0, false
+	case '"', '\'':
+		quote := text[0]
+		for j := 1; j < len(text); j++ {
+			switch {
+			case quote == '"' && text[j] == '\\':
+				j++
+			case quote == '\'' && text[j] == '\'' && j+1 < len(text) && text[j+1] == '\'':
+				j++
+			case text[j] == quote:
+				if j+1 < len(text) && text[j+1] == ':' && (j+2 == len(text) || text[j+2] == ' ') {
+					return unquote(text[:j+1]), j + 2, true
+				}
+				return "", 0, false
+			}
+		}
+		return "", 0, false
+	}
+	for j := range len(text) {
+		if text[j] == ':' && (j+1 == len(text) || text[j+1] == ' ') {
+			return strings.TrimSpace(text[:j]), j + 1, true
+		}
+		if text[j] == ' ' && j+1 < len(text) && text[j+1] == '#' {
+			break
+		}
+	}
+	return "", 0, false
+}
+
+func unquote(text string) string {
+	if strings.HasPrefix(text, "'") {
+		return strings.ReplaceAll(text[1:len(text)-1], "''", "'")
+	}
+	if unquoted, err := strconv.Unquote(text); err == nil {
+		return unquoted
+	}
+	return text[1 : len(text)-1]
+}
+
+func indentation(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// skipped returns the lines that don't contain structural elements (blank lines, comments and block scalars content)
+// and the lines belonging to block scalars.
+func skipped(lines []string) ([]bool, []bool) {
+	skip := make([]bool, len(lines))
+	blocks := make([]bool, len(lines))
+	block := -1
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if block >= 0 {
+			if trimmed == "" || indentation(line) > block {
+				skip[i], blocks[i] = true, true
+				continue
+			}
+			block = -1
+		}
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			skip[i] = true
+			continue
+		}
+		if tokens := tokenize(line); len(tokens) != 0 {
+			last := tokens[len(tokens)-1]
+			if value := strings.TrimSpace(last.value); strings.HasPrefix(value, "|") || strings.HasPrefix(value, ">") {
+				block = last.col
+				// the content of a block scalar in a sequence item is indented relatively to the item marker
+				if last.kind == tokenScalar && len(tokens) > 1 {
+					block = tokens[len(tokens)-2].col
+				}
+			}
+		}
+	}
+	return skip, blocks
+}
+
+type frame struct {
+	token
+	// index is the index of item frames in their sequence
+	index int
+	// items is the number of items found in the sequence held by key frames
+	items int
+}
+
+// isParent returns true if the frame contains the token, sequences under a key can be written at the key indentation.
+func (f *frame) isParent(t token) bool {
+	return f.col < t.col || (f.col == t.col && f.kind == tokenKey && t.kind == tokenItem)
+}
+
+// cursor describes the structural context of a position in a document.
+type cursor struct {
+	// path contains the keys (string) and indices (int) leading to the node containing the position
+	path []any
+	// key is the key whose value contains the position, it is empty when the position is on a key or a sequence item
+	key string
+	// onKey is true when the position is on a key being written (or on a scalar sequence item)
+	onKey bool
+	// text is the text of the key or value being written, up to the position
+	text string
+	// start is the character where the text starts
+	start int
+	// siblings contains the other keys of the mapping when the position is on a key
+	siblings []string
+	// block is true when the position is in the content of a block scalar, other fields are not set then
+	block bool
+}
+
+// analyze returns the context of a position in the lines of a document.
+func analyze(lines []string, line int, character int) cursor {
+	skip, blocks := skipped(lines)
+	if line < len(blocks) && blocks[line] && strings.TrimSpace(lines[line]) != "" {
+		return cursor{block: true}
+	}
+	stack := []*frame{{token: token{col: -1}}}
+	parents := func(t token) {
+		for !stack[len(stack)-1].isParent(t) {
+			stack = stack[:len(stack)-1]
+		}
+	}
+	push := func(t token) {
+		parents(t)
+		f := &frame{token: t}
+		if t.kind == tokenItem {
+			parent := stack[len(stack)-1]
+			f.index = parent.items
+			parent.items++
+		}
+		stack = append(stack, f)
+	}
+	for i := 0; i < line && i < len(lines); i++ {
+		if skip[i] {
+			continue
+		}
+		for _, t := range tokenize(lines[i]) {
+			if t.kind != tokenScalar {
+				push(t)
+			}
+		}
+	}
+	var prefix string
+	if line < len(lines) {
+		prefix = lines[line][:min(character, len(lines[line]))]
+	}
+	tokens := tokenize(prefix)
+	// the last token is the one being written
+	var last *token
+	if len(tokens) != 0 && tokens[len(tokens)-1].kind != tokenItem {
+		last = &tokens[len(tokens)-1]
+		tokens = tokens[:len(tokens)-1]
+	}
+	for _, t := range tokens {
+		push(t)
+	}
+	var c cursor
+	switch {
+	case last == nil:
+		c.onKey, c.start = true, len(prefix)
+		parents(token{kind: tokenKey, col: c.start})
+	case last.kind == tokenScalar:
+		c.onKey, c.text, c.start = true, last.value, last.col
+		parents(*last)
+	default:
+		c.key, c.text, c.start = last.key, last.value, last.valueCol
+		parents(*last)
+	}
+	for _, f := range stack[1:] {
+		if f.kind == tokenItem {
+			c.path = append(c.path, f.index)
+		} else {
+			c.path = append(c.path, f.key)
+		}
+	}
+	if c.onKey {
+		c.siblings = siblings(lines, skip, line, c.start, len(tokens) != 0)
+	}
+	return c
+}
+
+// siblings returns the keys written at the given column in the mapping containing the line.
+// The mapping starts on the line when the line begins with a sequence item.
+func siblings(lines []string, skip []bool, line int, col int, item bool) []string {
+	var keys []string
+	collect := func(i int) bool {
+		tokens := tokenize(lines[i])
+		if len(tokens) == 0 || tokens[0].col > col {
+			return true
+		}
+		for _, t := range tokens {
+			if t.col == col && t.kind == tokenKey {
+				keys = append(keys, t.key)
+			}
+		}
+		return tokens[0].col == col
+	}
+	if !item {
+		for i := line - 1; i >= 0; i-- {
+			if !skip[i] && !collect(i) {
+				break
+			}
+		}
+	}
+	for i := line + 1; i < len(lines); i++ {
+		if skip[i] {
+			continue
+		}
+		if tokens := tokenize(lines[i]); len(tokens) != 0 && tokens[0].col < col {
+			break
+		}
+		collect(i)
+	}
+	return keys
+}
+
+// document returns the lines of the document containing the line, along with the index of its first line.
+func document(lines []string, line int) ([]string, int) {
+	start, end := 0, len(lines)
+	for i, l := range lines {
+		if isSeparator(l) {
+			if i >= line {
+				end = i
+				break
+			}
+			start = i + 1
+		}
+	}
+	if start > end {
+		return nil, start
+	}
+	return lines[start:end], start
+}
+
+func isSeparator(line string) bool {
+	line = strings.TrimRight(line, "\r")
+	return line == "---" || strings.HasPrefix(line, "--- ") || strings.HasPrefix(line, "---\t")
+}
+
+// rootValue returns the value of a root key of a document.
+func rootValue(lines []string, key string) string {
+	for _, line := range lines {
+		if tokens := tokenize(line); len(tokens) == 1 && tokens[0].col == 0 && tokens[0].kind == tokenKey && tokens[0].key == key {
+			return scalarValue(tokens[0].value)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,160 @@
+package lsp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// cursorMarker designates the position in test documents.
+const cursorMarker = "§"
+
+// position removes the cursor marker from the text and returns the text along with the marker position (byte offset).
+func position(t *testing.T, text string) (string, int, int) {
+	t.Helper()
+	for i, line := range strings.Split(text, "\n") {
+		if character := strings.Index(line, cursorMarker); character >= 0 {
+			return strings.Replace(text, cursorMarker, "", 1), i, character
+		}
+	}
+	t.Fatal("cursor marker not found")
+	return "", 0, 0
+}
+
+func Test_tokenize(t *testing.T) {
+	tests := []struct {
+		line string
+		want []token
+	}{{
+		line: "",
+	}, {
+		line: "  # comment",
+	}, {
+		line: "apiVersion: v1",
+		want: []token{{kind: tokenKey, col: 0, key: "apiVersion", value: "v1", valueCol: 12}},
+	}, {
+		line: "  - - name:",
+		want: []token{{kind: tokenItem, col: 2}, {kind: tokenItem, col: 4}, {kind: tokenKey, col: 6, key: "name", valueCol: 11}},
+	}, {
+		line: `  "(foo): bar": baz`,
+		want: []token{{kind: tokenKey, col: 2, key: "(foo): bar", value: "baz", valueCol: 16}},
+	}, {
+		line: "  'it''s':",
+		want: []token{{kind: tokenKey, col: 2, key: "it's", valueCol: 10}},
+	}, {
+		line: "- foo",
+		want: []token{{kind: tokenItem, col: 0}, {kind: tokenScalar, col: 2, value: "foo", valueCol: 2}},
+	}, {
+		line: "  - {name: foo}",
+		want: []token{{kind: tokenItem, col: 2}, {kind: tokenScalar, col: 4, value: "{name: foo}", valueCol: 4}},
+	}, {
+		line: "  url: http://localhost:8080 # comment: foo",
+		want: []token{{kind: tokenKey, col: 2, key: "url", value: "http://localhost:8080 # comment: foo", valueCol: 7}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			assert.Equal(t, tt.want, tokenize(tt.line))
+		})
+	}
+}
+
+func Test_analyze(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want cursor
+	}{{
+		name: "root key",
+		text: "apiVersion: v1\n§",
+		want: cursor{onKey: true, siblings: []string{"apiVersion"}},
+	}, {
+		name: "value",
+		text: "apiVersion: v1\nkind: Te§",
+		want: cursor{key: "kind", text: "Te", start: 6},
+	}, {
+		name: "nested key",
+		text: `spec:
+  steps:
+  - try:
+    - apply:
+        file: foo.yaml
+    - assert:
+        fi§
+        resource: {}
+`,
+		want: cursor{path: []any{"spec", "steps", 0, "try", 1, "assert"}, onKey: true, text: "fi", start: 8, siblings: []string{"resource"}},
+	}, {
+		name: "new item",
+		text: `spec:
+  steps:
+  - name: foo
+  - try:
+    - apply:
+        file: foo.yaml
+    - §
+`,
+		want: cursor{path: []any{"spec", "steps", 1, "try", 1}, onKey: true, start: 6},
+	}, {
+		name: "item siblings",
+		text: `spec:
+  steps:
+  - name: foo
+    §
+    try: []
+  - catch: []
+`,
+		want: cursor{path: []any{"spec", "steps", 0}, onKey: true, start: 4, siblings: []string{"name", "try"}},
+	}, {
+		name: "expression value",
+		text: `spec:
+  steps:
+  - try:
+    - script:
+        content: |
+          - foo: bar
+          echo "hello"
+        outputs:
+        - name: foo
+          value: ($std§
+`,
+		want: cursor{path: []any{"spec", "steps", 0, "try", 0, "script", "outputs", 0}, key: "value", text: "($std", start: 17},
+	}, {
+		name: "block scalar",
+		text: `spec:
+  steps:
+  - try:
+    - script:
+        content: |
+          echo §
+`,
+		want: cursor{block: true},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, line, character := position(t, tt.text)
+			got := analyze(strings.Split(text, "\n"), line, character)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_document(t *testing.T) {
+	lines := strings.Split("a: 1\n---\nb: 2\nc: 3\n--- # comment\nd: 4", "\n")
+	doc, start := document(lines, 0)
+	assert.Equal(t, []string{"a: 1"}, doc)
+	assert.Equal(t, 0, start)
+	doc, start = document(lines, 3)
+	assert.Equal(t, []string{"b: 2", "c: 3"}, doc)
+	assert.Equal(t, 2, start)
+	doc, start = document(lines, 5)
+	assert.Equal(t, []string{"d: 4"}, doc)
+	assert.Equal(t, 5, start)
+}
+
+func Test_rootValue(t *testing.T) {
+	lines := strings.Split("apiVersion: \"chainsaw.kyverno.io/v1alpha1\"\nspec:\n  kind: Foo\nkind: Test # comment", "\n")
+	assert.Equal(t, "chainsaw.kyverno.io/v1alpha1", rootValue(lines, "apiVersion"))
+	assert.Equal(t, "Test", rootValue(lines, "kind"))
+	assert.Equal(t, "", rootValue(lines, "metadata"))
+}
@@ -0,0 +1,71 @@
+package lsp
+
+import (
+	"net/url"
+	"path/filepath"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// splitLines returns the lines of a text, line terminators are removed.
+func splitLines(text string) []string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSuffix(line, "\r")
+	}
+	return lines
+}
+
+// byteOffset converts a character offset expressed in UTF-16 code units (as used by the protocol) into a byte offset in the line.
+func byteOffset(line string, character int) int {
+	units := 0
+	for i, r := range line {
+		if units >= character {
+			return i
+		}
+		units += utf16.RuneLen(r)
+	}
+	return len(line)
+}
+
+// utf16Offset converts a byte offset in the line into a character offset expressed in UTF-16 code units.
+func utf16Offset(line string, offset int) int {
+	units := 0
+	for i, r := range line {
+		if i >= offset {
+			break
+		}
+		units += utf16.RuneLen(r)
+	}
+	return units
+}
+
+// runeOffset converts a rune offset in the line (as reported by the YAML parser) into a byte offset.
+func runeOffset(line string, runes int) int {
+	offset := 0
+	for range runes {
+		if offset >= len(line) {
+			break
+		}
+		_, size := utf8.DecodeRuneInString(line[offset:])
+		offset += size
+	}
+	return offset
+}
+
+// uriToPath returns the path of a file URI, an empty string is returned for other URIs.
+func uriToPath(uri string) string {
+	parsed, err := url.Parse(uri)
+	if err != nil || parsed.Scheme != "file" {
+		return ""
+	}
+	return filepath.FromSlash(parsed.Path)
+}
+
+func pathToURI(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+}
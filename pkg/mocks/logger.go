@@ -3,6 +3,7 @@ package mocks
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/fatih/color"
 	"github.com/kyverno/chainsaw/pkg/client"
@@ -12,14 +13,19 @@ import (
 type Logger struct {
 	Logs     []string
 	numCalls int
+	lock     sync.Mutex
 }
 
 func (f *Logger) Log(_ context.Context, operation logging.Operation, status logging.Status, obj client.Object, color *color.Color, args ...fmt.Stringer) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
 	defer func() { f.numCalls++ }()
 	message := fmt.Sprintf("%s: %s - %v", operation, status, args)
 	f.Logs = append(f.Logs, message)
 }
 
 func (f *Logger) NumCalls() int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
 	return f.numCalls
 }
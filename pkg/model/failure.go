@@ -0,0 +1,55 @@
+package model
+
+import (
+	"errors"
+
+	"go.uber.org/multierr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ResourceReference identifies the resource an operation failed on.
+type ResourceReference struct {
+	APIVersion string
+	Kind       string
+	Namespace  string
+	Name       string
+}
+
+// Failure contains structured details about an operation failure.
+type Failure struct {
+	Message     string
+	Resource    *ResourceReference
+	FieldErrors field.ErrorList
+	Diff        string
+	Stdout      string
+	Stderr      string
+	ExitCode    *int
+}
+
+// FailureError is implemented by errors carrying structured failure details.
+type FailureError interface {
+	error
+	Failure() Failure
+}
+
+// Failures returns the structured details of an error, errors combined with multierr are split.
+// Errors that don't carry structured details produce a failure with the error message only.
+func Failures(err error) []Failure {
+	if err == nil {
+		return nil
+	}
+	var failures []Failure
+	for _, err := range multierr.Errors(err) {
+		var failureErr FailureError
+		if errors.As(err, &failureErr) {
+			failure := failureErr.Failure()
+			failure.Message = err.Error()
+			failures = append(failures, failure)
+		} else {
+			failures = append(failures, Failure{
+				Message: err.Error(),
+			})
+		}
+	}
+	return failures
+}
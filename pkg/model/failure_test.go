@@ -0,0 +1,48 @@
+package model
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/multierr"
+)
+
+type testFailureError struct{}
+
+func (testFailureError) Error() string {
+	return "structured"
+}
+
+func (testFailureError) Failure() Failure {
+	return Failure{
+		Stdout: "out",
+	}
+}
+
+func TestFailures(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want []Failure
+	}{{
+		name: "nil",
+	}, {
+		name: "plain",
+		err:  errors.New("plain"),
+		want: []Failure{{Message: "plain"}},
+	}, {
+		name: "structured",
+		err:  testFailureError{},
+		want: []Failure{{Message: "structured", Stdout: "out"}},
+	}, {
+		name: "combined",
+		err:  multierr.Combine(errors.New("plain"), testFailureError{}),
+		want: []Failure{{Message: "plain"}, {Message: "structured", Stdout: "out"}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Failures(tt.err))
+		})
+	}
+}
@@ -9,16 +9,26 @@ import (
 type OperationType string
 
 const (
-	OperationTypeApply   OperationType = "apply"
-	OperationTypeAssert  OperationType = "assert"
-	OperationTypeCommand OperationType = "command"
-	OperationTypeCreate  OperationType = "create"
-	OperationTypeDelete  OperationType = "delete"
-	OperationTypeError   OperationType = "error"
-	OperationTypePatch   OperationType = "patch"
-	OperationTypeScript  OperationType = "script"
-	OperationTypeSleep   OperationType = "sleep"
-	OperationTypeUpdate  OperationType = "update"
+	OperationTypeApply       OperationType = "apply"
+	OperationTypeAssert      OperationType = "assert"
+	OperationTypeCommand     OperationType = "command"
+	OperationTypeCreate      OperationType = "create"
+	OperationTypeDelete      OperationType = "delete"
+	OperationTypeDescribe    OperationType = "describe"
+	OperationTypeError       OperationType = "error"
+	OperationTypeEvents      OperationType = "events"
+	OperationTypeExec        OperationType = "exec"
+	OperationTypeGet         OperationType = "get"
+	OperationTypeHTTP        OperationType = "http"
+	OperationTypePatch       OperationType = "patch"
+	OperationTypePodLogs     OperationType = "podLogs"
+	OperationTypePortForward OperationType = "portForward"
+	OperationTypeProcess     OperationType = "process"
+	OperationTypeProxy       OperationType = "proxy"
+	OperationTypeScript      OperationType = "script"
+	OperationTypeSleep       OperationType = "sleep"
+	OperationTypeUpdate      OperationType = "update"
+	OperationTypeWait        OperationType = "wait"
 )
 
 type Report struct {
@@ -38,14 +48,18 @@ func (r *Report) Add(report *TestReport) {
 }
 
 type TestReport struct {
-	BasePath   string
-	Name       string
-	Concurrent *bool
-	StartTime  time.Time
-	EndTime    time.Time
-	Namespace  string
-	Skipped    bool
-	Steps      []*StepReport
+	BasePath    string
+	Name        string
+	Concurrent  *bool
+	StartTime   time.Time
+	EndTime     time.Time
+	Namespace   string
+	Skipped     bool
+	SkipReason  string
+	Interrupted bool
+	Diagnostics string
+	Err         error
+	Steps       []*StepReport
 }
 
 func (r *TestReport) Add(report *StepReport) {
@@ -61,7 +75,10 @@ type StepReport struct {
 	Name       string
 	StartTime  time.Time
 	EndTime    time.Time
+	Skipped    bool
 	Operations []*OperationReport
+	// Attempts of a retried step, operations are the ones of the last attempt.
+	Attempts []*AttemptReport
 }
 
 func (r *StepReport) Add(report *OperationReport) {
@@ -73,6 +90,33 @@ func (r *StepReport) Add(report *OperationReport) {
 	}
 }
 
+func (r *StepReport) AddAttempt(report *AttemptReport) {
+	if report != nil {
+		r.Attempts = append(r.Attempts, report)
+	}
+}
+
+// ReportedAttempts returns the attempts to report, attempts are only reported when the step was retried.
+func (r *StepReport) ReportedAttempts() []*AttemptReport {
+	if len(r.Attempts) > 1 {
+		return r.Attempts
+	}
+	return nil
+}
+
+// AddGroup adds the reports of an operation run as part of a group (a parallel group for example).
+// Reports are named after the position in the group, followed by their name in the group when there are several of them.
+func (r *StepReport) AddGroup(name string, group *StepReport) {
+	for _, report := range group.Operations {
+		if len(group.Operations) == 1 {
+			report.Name = name
+		} else {
+			report.Name = name + "/" + report.Name
+		}
+		r.Add(report)
+	}
+}
+
 func (r *StepReport) Failed() bool {
 	for _, operation := range r.Operations {
 		if operation.Err != nil {
@@ -83,6 +127,9 @@ func (r *StepReport) Failed() bool {
 }
 
 func (r *TestReport) Failed() bool {
+	if r.Interrupted || r.Err != nil {
+		return true
+	}
 	for _, step := range r.Steps {
 		if step.Failed() {
 			return true
@@ -97,4 +144,34 @@ type OperationReport struct {
 	StartTime time.Time
 	EndTime   time.Time
 	Err       error
+	Failures  []Failure
+	Skipped   bool
+	Attempts  []*AttemptReport
+}
+
+func (r *OperationReport) Add(report *AttemptReport) {
+	if report != nil {
+		if len(r.Attempts) == 0 {
+			r.StartTime = report.StartTime
+		}
+		r.Attempts = append(r.Attempts, report)
+		r.EndTime = report.EndTime
+		r.Err = report.Err
+		r.Failures = report.Failures
+	}
+}
+
+// ReportedAttempts returns the attempts to report, attempts are only reported when the operation was retried.
+func (r *OperationReport) ReportedAttempts() []*AttemptReport {
+	if len(r.Attempts) > 1 {
+		return r.Attempts
+	}
+	return nil
+}
+
+type AttemptReport struct {
+	StartTime time.Time
+	EndTime   time.Time
+	Err       error
+	Failures  []Failure
 }
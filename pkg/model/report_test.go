@@ -3,6 +3,7 @@ package model
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -118,6 +119,104 @@ func TestStepReport_Add(t *testing.T) {
 	}
 }
 
+func TestStepReport_AddGroup(t *testing.T) {
+	r := &StepReport{}
+	r.Add(&OperationReport{})
+	single := &StepReport{}
+	single.Add(&OperationReport{})
+	r.AddGroup("parallel[0]", single)
+	several := &StepReport{}
+	several.Add(&OperationReport{})
+	several.Add(&OperationReport{Name: "foo"})
+	r.AddGroup("parallel[1]", several)
+	r.AddGroup("parallel[2]", &StepReport{})
+	var names []string
+	for _, operation := range r.Operations {
+		names = append(names, operation.Name)
+	}
+	assert.Equal(t, []string{"operation 1", "parallel[0]", "parallel[1]/operation 1", "parallel[1]/foo"}, names)
+}
+
+func TestOperationReport_Add(t *testing.T) {
+	first := time.Date(2009, 11, 17, 20, 34, 58, 0, time.UTC)
+	tests := []struct {
+		name      string
+		attempts  []*AttemptReport
+		report    *AttemptReport
+		wantLen   int
+		wantStart time.Time
+		wantEnd   time.Time
+		wantErr   error
+	}{{
+		name:    "add nil",
+		wantLen: 0,
+	}, {
+		name:      "first attempt",
+		report:    &AttemptReport{StartTime: first, EndTime: first.Add(time.Second), Err: errors.New("dummy")},
+		wantLen:   1,
+		wantStart: first,
+		wantEnd:   first.Add(time.Second),
+		wantErr:   errors.New("dummy"),
+	}, {
+		name:      "last attempt",
+		attempts:  []*AttemptReport{{StartTime: first, EndTime: first.Add(time.Second), Err: errors.New("dummy")}},
+		report:    &AttemptReport{StartTime: first.Add(2 * time.Second), EndTime: first.Add(3 * time.Second)},
+		wantLen:   2,
+		wantStart: first,
+		wantEnd:   first.Add(3 * time.Second),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &OperationReport{}
+			for _, attempt := range tt.attempts {
+				r.Add(attempt)
+			}
+			r.Add(tt.report)
+			assert.Equal(t, tt.wantLen, len(r.Attempts))
+			assert.Equal(t, tt.wantStart, r.StartTime)
+			assert.Equal(t, tt.wantEnd, r.EndTime)
+			assert.Equal(t, tt.wantErr, r.Err)
+		})
+	}
+}
+
+func TestOperationReport_ReportedAttempts(t *testing.T) {
+	attempt := &AttemptReport{Err: errors.New("dummy")}
+	tests := []struct {
+		name     string
+		attempts []*AttemptReport
+		want     []*AttemptReport
+	}{{
+		name: "no attempt",
+	}, {
+		name:     "single attempt",
+		attempts: []*AttemptReport{attempt},
+	}, {
+		name:     "retried",
+		attempts: []*AttemptReport{attempt, {}},
+		want:     []*AttemptReport{attempt, {}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &OperationReport{Attempts: tt.attempts}
+			assert.Equal(t, tt.want, r.ReportedAttempts())
+		})
+	}
+}
+
+func TestStepReport_AddAttempt(t *testing.T) {
+	r := &StepReport{}
+	r.AddAttempt(nil)
+	assert.Empty(t, r.Attempts)
+	first := &AttemptReport{Err: errors.New("dummy")}
+	r.AddAttempt(first)
+	assert.Equal(t, []*AttemptReport{first}, r.Attempts)
+	assert.Nil(t, r.ReportedAttempts())
+	second := &AttemptReport{}
+	r.AddAttempt(second)
+	assert.Equal(t, []*AttemptReport{first, second}, r.ReportedAttempts())
+}
+
 func TestStepReport_Failed(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -177,6 +276,21 @@ func TestTestReport_Failed(t *testing.T) {
 			},
 		},
 		want: true,
+	}, {
+		name: "interrupted",
+		report: TestReport{
+			Interrupted: true,
+			Steps: []*StepReport{
+				{Operations: []*OperationReport{{}}},
+			},
+		},
+		want: true,
+	}, {
+		name: "error",
+		report: TestReport{
+			Err: errors.New("boom"),
+		},
+		want: true,
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
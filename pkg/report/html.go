@@ -0,0 +1,186 @@
+package report
+
+import (
+	_ "embed"
+	"html/template"
+	"os"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/model"
+)
+
+//go:embed templates/report.html
+var htmlTemplate string
+
+const (
+	statusPassed  = "passed"
+	statusFailed  = "failed"
+	statusSkipped = "skipped"
+)
+
+type htmlTimeline struct {
+	Offset   float64
+	Width    float64
+	Duration time.Duration
+}
+
+type htmlAttempt struct {
+	Status   string
+	Duration time.Duration
+	Err      string
+}
+
+type htmlOperation struct {
+	Name     string
+	Type     model.OperationType
+	Status   string
+	Timeline htmlTimeline
+	Err      string
+	Details  string
+	Attempts []htmlAttempt
+}
+
+type htmlStep struct {
+	Name       string
+	Status     string
+	Timeline   htmlTimeline
+	Operations []htmlOperation
+	Attempts   []htmlAttempt
+}
+
+type htmlTest struct {
+	Name        string
+	BasePath    string
+	Namespace   string
+	Status      string
+	SkipReason  string
+	Interrupted bool
+	Diagnostics string
+	Err         string
+	Timeline    htmlTimeline
+	Steps       []htmlStep
+}
+
+type htmlReport struct {
+	Name      string
+	StartTime time.Time
+	Duration  time.Duration
+	Passed    int
+	Failed    int
+	Skipped   int
+	Tests     []htmlTest
+}
+
+func saveHtml(report *model.Report, file string) error {
+	tmpl, err := template.New("report").Parse(htmlTemplate)
+	if err != nil {
+		return err
+	}
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return tmpl.Execute(f, buildHtmlReport(report))
+}
+
+func buildHtmlReport(report *model.Report) htmlReport {
+	start, end := report.StartTime, report.EndTime
+	// tests can end after the report end time if it was not set
+	for _, test := range report.Tests {
+		if test.EndTime.After(end) {
+			end = test.EndTime
+		}
+	}
+	timeline := func(startTime, endTime time.Time) htmlTimeline {
+		// skipped tests may not have been timed
+		if startTime.IsZero() || endTime.IsZero() {
+			return htmlTimeline{}
+		}
+		out := htmlTimeline{
+			Duration: endTime.Sub(startTime).Round(time.Millisecond),
+		}
+		if total := end.Sub(start); total > 0 {
+			out.Offset = 100 * float64(startTime.Sub(start)) / float64(total)
+			out.Width = max(100*float64(endTime.Sub(startTime))/float64(total), 0.5)
+		}
+		return out
+	}
+	attempts := func(reports []*model.AttemptReport) []htmlAttempt {
+		var out []htmlAttempt
+		for _, attempt := range reports {
+			htmlAttempt := htmlAttempt{
+				Status:   statusPassed,
+				Duration: attempt.EndTime.Sub(attempt.StartTime).Round(time.Millisecond),
+			}
+			if attempt.Err != nil {
+				htmlAttempt.Status = statusFailed
+				htmlAttempt.Err = attempt.Err.Error()
+			}
+			out = append(out, htmlAttempt)
+		}
+		return out
+	}
+	out := htmlReport{
+		Name:      report.Name,
+		StartTime: report.StartTime,
+		Duration:  end.Sub(start).Round(time.Millisecond),
+	}
+	for _, test := range report.Tests {
+		htmlTest := htmlTest{
+			Name:        test.Name,
+			BasePath:    test.BasePath,
+			Namespace:   test.Namespace,
+			Status:      statusPassed,
+			SkipReason:  test.SkipReason,
+			Interrupted: test.Interrupted,
+			Diagnostics: test.Diagnostics,
+			Timeline:    timeline(test.StartTime, test.EndTime),
+		}
+		if test.Err != nil {
+			htmlTest.Err = test.Err.Error()
+		}
+		if test.Skipped {
+			htmlTest.Status = statusSkipped
+			out.Skipped++
+		} else if test.Failed() {
+			htmlTest.Status = statusFailed
+			out.Failed++
+		} else {
+			out.Passed++
+		}
+		for _, step := range test.Steps {
+			htmlStep := htmlStep{
+				Name:     step.Name,
+				Status:   statusPassed,
+				Timeline: timeline(step.StartTime, step.EndTime),
+				Attempts: attempts(step.ReportedAttempts()),
+			}
+			if step.Skipped {
+				htmlStep.Status = statusSkipped
+			} else if step.Failed() {
+				htmlStep.Status = statusFailed
+			}
+			for _, operation := range step.Operations {
+				htmlOperation := htmlOperation{
+					Name:     operation.Name,
+					Type:     operation.Type,
+					Status:   statusPassed,
+					Timeline: timeline(operation.StartTime, operation.EndTime),
+					Attempts: attempts(operation.ReportedAttempts()),
+				}
+				if operation.Skipped {
+					htmlOperation.Status = statusSkipped
+				} else if operation.Err != nil {
+					htmlOperation.Status = statusFailed
+					htmlOperation.Err = operation.Err.Error()
+					htmlOperation.Details = failureData(operation.Failures...)
+				}
+				htmlStep.Operations = append(htmlStep.Operations, htmlOperation)
+			}
+			htmlTest.Steps = append(htmlTest.Steps, htmlStep)
+		}
+		out.Tests = append(out.Tests, htmlTest)
+	}
+	return out
+}
@@ -9,8 +9,84 @@ import (
 )
 
 func saveJson(report *model.Report, file string) error {
+	type ResourceReference struct {
+		APIVersion string `json:"apiVersion,omitempty"`
+		Kind       string `json:"kind,omitempty"`
+		Namespace  string `json:"namespace,omitempty"`
+		Name       string `json:"name,omitempty"`
+	}
+	type FieldError struct {
+		Type     string `json:"type,omitempty"`
+		Field    string `json:"field,omitempty"`
+		BadValue any    `json:"badValue,omitempty"`
+		Detail   string `json:"detail,omitempty"`
+	}
+	type FailureDetails struct {
+		Message     string             `json:"message,omitempty"`
+		Resource    *ResourceReference `json:"resource,omitempty"`
+		FieldErrors []FieldError       `json:"fieldErrors,omitempty"`
+		Diff        string             `json:"diff,omitempty"`
+		Stdout      string             `json:"stdout,omitempty"`
+		Stderr      string             `json:"stderr,omitempty"`
+		ExitCode    *int               `json:"exitCode,omitempty"`
+	}
 	type Failure struct {
-		Err string `json:"error,omitempty"`
+		Err     string           `json:"error,omitempty"`
+		Details []FailureDetails `json:"details,omitempty"`
+	}
+	newFailure := func(err error, failures []model.Failure) *Failure {
+		out := &Failure{
+			Err: err.Error(),
+		}
+		for _, failure := range failures {
+			details := FailureDetails{
+				Message:  failure.Message,
+				Diff:     failure.Diff,
+				Stdout:   failure.Stdout,
+				Stderr:   failure.Stderr,
+				ExitCode: failure.ExitCode,
+			}
+			if failure.Resource != nil {
+				details.Resource = &ResourceReference{
+					APIVersion: failure.Resource.APIVersion,
+					Kind:       failure.Resource.Kind,
+					Namespace:  failure.Resource.Namespace,
+					Name:       failure.Resource.Name,
+				}
+			}
+			for _, fieldErr := range failure.FieldErrors {
+				details.FieldErrors = append(details.FieldErrors, FieldError{
+					Type:     string(fieldErr.Type),
+					Field:    fieldErr.Field,
+					BadValue: fieldErr.BadValue,
+					Detail:   fieldErr.Detail,
+				})
+			}
+			out.Details = append(out.Details, details)
+		}
+		return out
+	}
+	type AttemptReport struct {
+		Status    string    `json:"status"`
+		StartTime time.Time `json:"startTime"`
+		EndTime   time.Time `json:"endTime"`
+		Failure   *Failure  `json:"failure,omitempty"`
+	}
+	newAttempts := func(attempts []*model.AttemptReport) []AttemptReport {
+		var out []AttemptReport
+		for _, attempt := range attempts {
+			attemptReport := AttemptReport{
+				Status:    "passed",
+				StartTime: attempt.StartTime,
+				EndTime:   attempt.EndTime,
+			}
+			if attempt.Err != nil {
+				attemptReport.Status = "failed"
+				attemptReport.Failure = newFailure(attempt.Err, attempt.Failures)
+			}
+			out = append(out, attemptReport)
+		}
+		return out
 	}
 	type OperationReport struct {
 		Name      string              `json:"name,omitempty"`
@@ -19,6 +95,7 @@ func saveJson(report *model.Report, file string) error {
 		StartTime time.Time           `json:"startTime"`
 		EndTime   time.Time           `json:"endTime"`
 		Failure   *Failure            `json:"failure,omitempty"`
+		Attempts  []AttemptReport     `json:"attempts,omitempty"`
 	}
 	type StepReport struct {
 		Name       string            `json:"name,omitempty"`
@@ -26,16 +103,21 @@ func saveJson(report *model.Report, file string) error {
 		StartTime  time.Time         `json:"startTime"`
 		EndTime    time.Time         `json:"endTime"`
 		Operations []OperationReport `json:"operations,omitempty"`
+		Attempts   []AttemptReport   `json:"attempts,omitempty"`
 	}
 	type TestReport struct {
-		BasePath   string       `json:"basePath,omitempty"`
-		Name       string       `json:"name,omitempty"`
-		Concurrent *bool        `json:"concurrent,omitempty"`
-		Status     string       `json:"status"`
-		StartTime  time.Time    `json:"startTime"`
-		EndTime    time.Time    `json:"endTime"`
-		Namespace  string       `json:"namespace,omitempty"`
-		Steps      []StepReport `json:"steps,omitempty"`
+		BasePath    string       `json:"basePath,omitempty"`
+		Name        string       `json:"name,omitempty"`
+		Concurrent  *bool        `json:"concurrent,omitempty"`
+		Status      string       `json:"status"`
+		SkipReason  string       `json:"skipReason,omitempty"`
+		Interrupted bool         `json:"interrupted,omitempty"`
+		Diagnostics string       `json:"diagnostics,omitempty"`
+		Error       string       `json:"error,omitempty"`
+		StartTime   time.Time    `json:"startTime"`
+		EndTime     time.Time    `json:"endTime"`
+		Namespace   string       `json:"namespace,omitempty"`
+		Steps       []StepReport `json:"steps,omitempty"`
 	}
 	type Report struct {
 		Name      string       `json:"name,omitempty"`
@@ -56,17 +138,25 @@ func saveJson(report *model.Report, file string) error {
 			testStatus = "failed"
 		}
 		testReport := TestReport{
-			BasePath:   test.BasePath,
-			Name:       test.Name,
-			Concurrent: test.Concurrent,
-			Status:     testStatus,
-			StartTime:  test.StartTime,
-			EndTime:    test.EndTime,
-			Namespace:  test.Namespace,
+			BasePath:    test.BasePath,
+			Name:        test.Name,
+			Concurrent:  test.Concurrent,
+			Status:      testStatus,
+			SkipReason:  test.SkipReason,
+			Interrupted: test.Interrupted,
+			Diagnostics: test.Diagnostics,
+			StartTime:   test.StartTime,
+			EndTime:     test.EndTime,
+			Namespace:   test.Namespace,
+		}
+		if test.Err != nil {
+			testReport.Error = test.Err.Error()
 		}
 		for _, step := range test.Steps {
 			stepStatus := "passed"
-			if step.Failed() {
+			if step.Skipped {
+				stepStatus = "skipped"
+			} else if step.Failed() {
 				stepStatus = "failed"
 			}
 			stepReport := StepReport{
@@ -74,10 +164,13 @@ func saveJson(report *model.Report, file string) error {
 				Status:    stepStatus,
 				StartTime: step.StartTime,
 				EndTime:   step.EndTime,
+				Attempts:  newAttempts(step.ReportedAttempts()),
 			}
 			for _, operation := range step.Operations {
 				opStatus := "passed"
-				if operation.Err != nil {
+				if operation.Skipped {
+					opStatus = "skipped"
+				} else if operation.Err != nil {
 					opStatus = "failed"
 				}
 				operationReport := OperationReport{
@@ -86,11 +179,10 @@ func saveJson(report *model.Report, file string) error {
 					Status:    opStatus,
 					StartTime: operation.StartTime,
 					EndTime:   operation.EndTime,
+					Attempts:  newAttempts(operation.ReportedAttempts()),
 				}
 				if operation.Err != nil {
-					operationReport.Failure = &Failure{
-						Err: operation.Err.Error(),
-					}
+					operationReport.Failure = newFailure(operation.Err, operation.Failures)
 				}
 				stepReport.Operations = append(stepReport.Operations, operationReport)
 			}
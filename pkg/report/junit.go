@@ -4,9 +4,11 @@ import (
 	"encoding/xml"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/jstemmer/go-junit-report/v2/junit"
+	"github.com/kyverno/chainsaw/pkg/client"
 	"github.com/kyverno/chainsaw/pkg/model"
 	"go.uber.org/multierr"
 )
@@ -16,6 +18,53 @@ func durationInSecondsString(start, end time.Time) string {
 	return fmt.Sprintf("%.6f", duration.Seconds())
 }
 
+// failureData formats structured failure details, it is used as the junit failure content.
+func failureData(failures ...model.Failure) string {
+	var lines []string
+	for _, failure := range failures {
+		if failure.Resource != nil {
+			lines = append(lines, fmt.Sprintf("resource: %s/%s/%s", failure.Resource.APIVersion, failure.Resource.Kind, client.Name(client.ObjectKey{Namespace: failure.Resource.Namespace, Name: failure.Resource.Name})))
+		}
+		if len(failure.FieldErrors) != 0 {
+			lines = append(lines, "field errors:")
+			for _, err := range failure.FieldErrors {
+				lines = append(lines, fmt.Sprintf("- %s", err))
+			}
+		}
+		if failure.Diff != "" {
+			lines = append(lines, "diff:", failure.Diff)
+		}
+		if failure.ExitCode != nil {
+			lines = append(lines, fmt.Sprintf("exit code: %d", *failure.ExitCode))
+		}
+		if failure.Stdout != "" {
+			lines = append(lines, "stdout:", strings.TrimSpace(failure.Stdout))
+		}
+		if failure.Stderr != "" {
+			lines = append(lines, "stderr:", strings.TrimSpace(failure.Stderr))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// attemptsOutput formats the attempts of a retried operation or step, it is used as the junit system output.
+func attemptsOutput(attempts []*model.AttemptReport) *junit.Output {
+	if len(attempts) == 0 {
+		return nil
+	}
+	var lines []string
+	for i, attempt := range attempts {
+		status := "passed"
+		if attempt.Err != nil {
+			status = "failed"
+		}
+		lines = append(lines, fmt.Sprintf("attempt %d: %s (%ss)", i+1, status, durationInSecondsString(attempt.StartTime, attempt.EndTime)))
+	}
+	return &junit.Output{
+		Data: strings.Join(lines, "\n"),
+	}
+}
+
 func saveJUnitTest(report *model.Report, file string) error {
 	testSuites := &junit.Testsuites{
 		Name: report.Name,
@@ -31,19 +80,32 @@ func saveJUnitTest(report *model.Report, file string) error {
 				Time: durationInSecondsString(test.StartTime, test.EndTime),
 			}
 			if test.Skipped {
-				testCase.Skipped = &junit.Result{}
+				testCase.Skipped = &junit.Result{Message: test.SkipReason}
 			} else {
 				var errs []error
+				var failures []model.Failure
+				if test.Err != nil {
+					errs = append(errs, test.Err)
+				}
 				for _, step := range test.Steps {
 					for _, operation := range step.Operations {
 						if operation.Err != nil {
 							errs = append(errs, operation.Err)
+							failures = append(failures, operation.Failures...)
 						}
 					}
 				}
 				if err := multierr.Combine(errs...); err != nil {
 					testCase.Failure = &junit.Result{
 						Message: err.Error(),
+						Data:    failureData(failures...),
+					}
+				} else if test.Interrupted {
+					testCase.Failure = &junit.Result{Message: "test interrupted"}
+				}
+				if test.Diagnostics != "" {
+					testCase.SystemOut = &junit.Output{
+						Data: "diagnostics: " + test.Diagnostics,
 					}
 				}
 			}
@@ -78,28 +140,49 @@ func saveJUnitStep(report *model.Report, file string) error {
 		}
 		testSuite.SetTimestamp(report.StartTime)
 		testSuite.AddProperty("namespace", test.Namespace)
+		if test.Diagnostics != "" {
+			testSuite.AddProperty("diagnostics", test.Diagnostics)
+		}
+		if test.Interrupted {
+			testSuite.AddProperty("interrupted", "true")
+		}
 		if test.Skipped {
 			testCase := junit.Testcase{
 				Name: test.Name,
 				Time: durationInSecondsString(test.StartTime, test.EndTime),
 			}
-			testCase.Skipped = &junit.Result{}
+			testCase.Skipped = &junit.Result{Message: test.SkipReason}
 			testSuite.AddTestcase(testCase)
 		} else {
+			if test.Err != nil {
+				testSuite.AddTestcase(junit.Testcase{
+					Name:    test.Name,
+					Time:    durationInSecondsString(test.StartTime, test.EndTime),
+					Failure: &junit.Result{Message: test.Err.Error()},
+				})
+			}
 			for _, step := range test.Steps {
 				testCase := junit.Testcase{
-					Name: step.Name,
-					Time: durationInSecondsString(step.StartTime, step.EndTime),
+					Name:      step.Name,
+					Time:      durationInSecondsString(step.StartTime, step.EndTime),
+					SystemOut: attemptsOutput(step.ReportedAttempts()),
 				}
-				var errs []error
-				for _, operation := range step.Operations {
-					if operation.Err != nil {
-						errs = append(errs, operation.Err)
+				if step.Skipped {
+					testCase.Skipped = &junit.Result{}
+				} else {
+					var errs []error
+					var failures []model.Failure
+					for _, operation := range step.Operations {
+						if operation.Err != nil {
+							errs = append(errs, operation.Err)
+							failures = append(failures, operation.Failures...)
+						}
 					}
-				}
-				if err := multierr.Combine(errs...); err != nil {
-					testCase.Failure = &junit.Result{
-						Message: err.Error(),
+					if err := multierr.Combine(errs...); err != nil {
+						testCase.Failure = &junit.Result{
+							Message: err.Error(),
+							Data:    failureData(failures...),
+						}
 					}
 				}
 				testSuite.AddTestcase(testCase)
@@ -130,24 +213,41 @@ func saveJUnitOperation(report *model.Report, file string) error {
 		}
 		testSuite.SetTimestamp(report.StartTime)
 		testSuite.AddProperty("namespace", test.Namespace)
+		if test.Diagnostics != "" {
+			testSuite.AddProperty("diagnostics", test.Diagnostics)
+		}
+		if test.Interrupted {
+			testSuite.AddProperty("interrupted", "true")
+		}
 		if test.Skipped {
 			testCase := junit.Testcase{
 				Name: test.Name,
 				Time: durationInSecondsString(test.StartTime, test.EndTime),
 			}
-			testCase.Skipped = &junit.Result{}
+			testCase.Skipped = &junit.Result{Message: test.SkipReason}
 			testSuite.AddTestcase(testCase)
 		} else {
+			if test.Err != nil {
+				testSuite.AddTestcase(junit.Testcase{
+					Name:    test.Name,
+					Time:    durationInSecondsString(test.StartTime, test.EndTime),
+					Failure: &junit.Result{Message: test.Err.Error()},
+				})
+			}
 			for _, step := range test.Steps {
 				for _, operation := range step.Operations {
 					testCase := junit.Testcase{
 						Name:      fmt.Sprintf("%s / %s", step.Name, operation.Name),
 						Classname: string(operation.Type),
 						Time:      durationInSecondsString(operation.StartTime, operation.EndTime),
+						SystemOut: attemptsOutput(operation.ReportedAttempts()),
 					}
-					if err := operation.Err; err != nil {
+					if operation.Skipped {
+						testCase.Skipped = &junit.Result{}
+					} else if err := operation.Err; err != nil {
 						testCase.Failure = &junit.Result{
 							Message: err.Error(),
+							Data:    failureData(operation.Failures...),
 						}
 					}
 					testSuite.AddTestcase(testCase)
@@ -30,6 +30,8 @@ func Save(report *model.Report, format v1alpha2.ReportFormatType, path, name str
 		return saveJUnitOperation(report, getFile(path, name, "xml"))
 	case v1alpha2.JSONFormat:
 		return saveJson(report, getFile(path, name, "json"))
+	case v1alpha2.HTMLFormat:
+		return saveHtml(report, getFile(path, name, "html"))
 	default:
 		return fmt.Errorf("unknown report format: %s", format)
 	}
@@ -1,6 +1,7 @@
 package report
 
 import (
+	"encoding/json"
 	"errors"
 	"os"
 	"path/filepath"
@@ -10,6 +11,7 @@ import (
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha2"
 	"github.com/kyverno/chainsaw/pkg/model"
 	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 func Test_getFile(t *testing.T) {
@@ -124,6 +126,10 @@ func TestSave(t *testing.T) {
 		report: report,
 		format: v1alpha2.JUnitOperationFormat,
 		out:    "JUNIT-OPERATION.xml",
+	}, {
+		report: report,
+		format: v1alpha2.HTMLFormat,
+		out:    "HTML.html",
 	}, {
 		report:  report,
 		format:  v1alpha2.ReportFormatType("xyz"),
@@ -148,3 +154,205 @@ func TestSave(t *testing.T) {
 		})
 	}
 }
+
+func Test_buildHtmlReport(t *testing.T) {
+	start := time.Date(2009, 11, 17, 20, 0, 0, 0, time.UTC)
+	report := &model.Report{
+		Name:      "report",
+		StartTime: start,
+		EndTime:   start.Add(100 * time.Second),
+		Tests: []*model.TestReport{{
+			Name:      "passed",
+			StartTime: start,
+			EndTime:   start.Add(50 * time.Second),
+		}, {
+			Name:      "failed",
+			StartTime: start.Add(50 * time.Second),
+			EndTime:   start.Add(100 * time.Second),
+			Steps: []*model.StepReport{{
+				Name:      "step",
+				StartTime: start.Add(50 * time.Second),
+				EndTime:   start.Add(75 * time.Second),
+				Attempts: []*model.AttemptReport{{
+					StartTime: start.Add(40 * time.Second),
+					EndTime:   start.Add(45 * time.Second),
+					Err:       errors.New("previous"),
+				}, {
+					StartTime: start.Add(50 * time.Second),
+					EndTime:   start.Add(75 * time.Second),
+					Err:       errors.New("last"),
+				}},
+				Operations: []*model.OperationReport{{
+					Name: "assert",
+					Type: model.OperationTypeAssert,
+					Attempts: []*model.AttemptReport{{
+						StartTime: start.Add(50 * time.Second),
+						EndTime:   start.Add(60 * time.Second),
+						Err:       errors.New("first"),
+					}, {
+						StartTime: start.Add(60 * time.Second),
+						EndTime:   start.Add(75 * time.Second),
+						Err:       errors.New("<diff>"),
+					}},
+					Failures: []model.Failure{{
+						Message: "<diff>",
+						Diff:    "-  key: <baz>\n+  key: bar",
+					}},
+				}},
+			}},
+		}, {
+			Name:       "skipped",
+			Skipped:    true,
+			SkipReason: "dependencies didn't pass: failed",
+		}},
+	}
+	for _, test := range report.Tests {
+		for _, step := range test.Steps {
+			for _, operation := range step.Operations {
+				operation.StartTime = operation.Attempts[0].StartTime
+				operation.EndTime = operation.Attempts[len(operation.Attempts)-1].EndTime
+				operation.Err = operation.Attempts[len(operation.Attempts)-1].Err
+			}
+		}
+	}
+	got := buildHtmlReport(report)
+	assert.Equal(t, 1, got.Passed)
+	assert.Equal(t, 1, got.Failed)
+	assert.Equal(t, 1, got.Skipped)
+	assert.Equal(t, 100*time.Second, got.Duration)
+	assert.Equal(t, htmlTimeline{Offset: 50, Width: 50, Duration: 50 * time.Second}, got.Tests[1].Timeline)
+	assert.Equal(t, "failed", got.Tests[1].Steps[0].Status)
+	assert.Equal(t, []htmlAttempt{
+		{Status: "failed", Duration: 5 * time.Second, Err: "previous"},
+		{Status: "failed", Duration: 25 * time.Second, Err: "last"},
+	}, got.Tests[1].Steps[0].Attempts)
+	operation := got.Tests[1].Steps[0].Operations[0]
+	assert.Equal(t, "failed", operation.Status)
+	assert.Equal(t, "<diff>", operation.Err)
+	assert.Equal(t, "diff:\n-  key: <baz>\n+  key: bar", operation.Details)
+	assert.Equal(t, []htmlAttempt{
+		{Status: "failed", Duration: 10 * time.Second, Err: "first"},
+		{Status: "failed", Duration: 15 * time.Second, Err: "<diff>"},
+	}, operation.Attempts)
+	assert.Equal(t, "skipped", got.Tests[2].Status)
+	file := filepath.Join(t.TempDir(), "report.html")
+	assert.NoError(t, saveHtml(report, file))
+	data, err := os.ReadFile(file)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "&lt;diff&gt;")
+	assert.Contains(t, string(data), "-  key: &lt;baz&gt;")
+	assert.Contains(t, string(data), "dependencies didn&#39;t pass: failed")
+}
+
+func Test_saveJson_failures(t *testing.T) {
+	start := time.Date(2009, 11, 17, 20, 0, 0, 0, time.UTC)
+	failures := []model.Failure{{
+		Message: "failed",
+		Resource: &model.ResourceReference{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+			Namespace:  "default",
+			Name:       "foo",
+		},
+		FieldErrors: field.ErrorList{
+			field.Invalid(field.NewPath("data", "key"), "bar", "Expected value: \"baz\""),
+		},
+		Diff:     "-  key: baz\n+  key: bar",
+		Stderr:   "boom",
+		ExitCode: new(1),
+	}}
+	report := &model.Report{
+		StartTime: start,
+		EndTime:   start,
+		Tests: []*model.TestReport{{
+			Name:      "test",
+			StartTime: start,
+			EndTime:   start,
+			Steps: []*model.StepReport{{
+				StartTime: start,
+				EndTime:   start,
+				Operations: []*model.OperationReport{{
+					StartTime: start,
+					EndTime:   start,
+					Err:       errors.New("failed"),
+					Failures:  failures,
+				}},
+			}},
+		}},
+	}
+	file := filepath.Join(t.TempDir(), "report.json")
+	assert.NoError(t, saveJson(report, file))
+	data, err := os.ReadFile(file)
+	assert.NoError(t, err)
+	var got struct {
+		Tests []struct {
+			Steps []struct {
+				Operations []struct {
+					Failure struct {
+						Err     string           `json:"error"`
+						Details []map[string]any `json:"details"`
+					} `json:"failure"`
+				} `json:"operations"`
+			} `json:"steps"`
+		} `json:"tests"`
+	}
+	assert.NoError(t, json.Unmarshal(data, &got))
+	assert.Equal(t, "failed", got.Tests[0].Steps[0].Operations[0].Failure.Err)
+	assert.Equal(t, []map[string]any{{
+		"message": "failed",
+		"resource": map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"namespace":  "default",
+			"name":       "foo",
+		},
+		"fieldErrors": []any{map[string]any{
+			"type":     "FieldValueInvalid",
+			"field":    "data.key",
+			"badValue": "bar",
+			"detail":   "Expected value: \"baz\"",
+		}},
+		"diff":     "-  key: baz\n+  key: bar",
+		"stderr":   "boom",
+		"exitCode": float64(1),
+	}}, got.Tests[0].Steps[0].Operations[0].Failure.Details)
+	assert.Contains(t, failureData(failures...), "resource: v1/ConfigMap/default/foo")
+	assert.Contains(t, failureData(failures...), "exit code: 1")
+}
+
+func Test_save_interrupted(t *testing.T) {
+	start := time.Date(2009, 11, 17, 20, 0, 0, 0, time.UTC)
+	report := &model.Report{
+		StartTime: start,
+		EndTime:   start,
+		Tests: []*model.TestReport{{
+			Name:        "test",
+			StartTime:   start,
+			EndTime:     start,
+			Interrupted: true,
+		}},
+	}
+	dir := t.TempDir()
+	{
+		file := filepath.Join(dir, "report.json")
+		assert.NoError(t, saveJson(report, file))
+		data, err := os.ReadFile(file)
+		assert.NoError(t, err)
+		var got struct {
+			Tests []struct {
+				Status      string `json:"status"`
+				Interrupted bool   `json:"interrupted"`
+			} `json:"tests"`
+		}
+		assert.NoError(t, json.Unmarshal(data, &got))
+		assert.Equal(t, "failed", got.Tests[0].Status)
+		assert.True(t, got.Tests[0].Interrupted)
+	}
+	{
+		file := filepath.Join(dir, "report.xml")
+		assert.NoError(t, saveJUnitTest(report, file))
+		data, err := os.ReadFile(file)
+		assert.NoError(t, err)
+		assert.Contains(t, string(data), `message="test interrupted"`)
+	}
+}
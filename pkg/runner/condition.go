@@ -0,0 +1,16 @@
+package runner
+
+import (
+	"context"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	enginecontext "github.com/kyverno/chainsaw/pkg/runner/context"
+)
+
+// checkCondition evaluates an if condition, an empty condition always evaluates to true.
+func checkCondition(ctx context.Context, tc enginecontext.TestContext, condition v1alpha1.Condition) (bool, error) {
+	if condition == "" {
+		return true, nil
+	}
+	return condition.Bool(ctx, tc.Compilers(), tc.Bindings())
+}
@@ -7,8 +7,10 @@ import (
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
 	"github.com/kyverno/chainsaw/pkg/client"
 	"github.com/kyverno/chainsaw/pkg/client/dryrun"
+	"github.com/kyverno/chainsaw/pkg/diagnostics"
 	apibindings "github.com/kyverno/chainsaw/pkg/engine/bindings"
 	"github.com/kyverno/chainsaw/pkg/engine/clusters"
+	"github.com/kyverno/chainsaw/pkg/engine/informers"
 	"github.com/kyverno/chainsaw/pkg/engine/namespacer"
 	"github.com/kyverno/chainsaw/pkg/model"
 	"github.com/kyverno/kyverno-json/pkg/core/compilers"
@@ -37,10 +39,14 @@ type TestContext struct {
 	compilers           compilers.Compilers
 	delayBeforeCleanup  *time.Duration
 	deletionPropagation metav1.DeletionPropagation
+	diagnostics         *diagnostics.Collector
+	diagnosticsPath     string
 	dryRun              bool
 	failFast            bool
 	fullName            bool
+	informers           informers.Factory
 	namespacer          namespacer.Namespacer
+	parallel            int
 	quiet               bool
 	skipDelete          bool
 	templating          bool
@@ -102,6 +108,15 @@ func (tc *TestContext) CurrentClusterClient() (*rest.Config, client.Client, erro
 	return config, client, err
 }
 
+// CurrentClusterInformers returns the informers for the given cluster config.
+// Informers are not used in dry run mode as resources are not created in the cluster.
+func (tc *TestContext) CurrentClusterInformers(config *rest.Config) informers.Informers {
+	if tc.informers == nil || tc.dryRun {
+		return nil
+	}
+	return tc.informers.For(config)
+}
+
 func (tc *TestContext) DelayBeforeCleanup() *time.Duration {
 	return tc.delayBeforeCleanup
 }
@@ -110,6 +125,14 @@ func (tc *TestContext) DeletionPropagation() metav1.DeletionPropagation {
 	return tc.deletionPropagation
 }
 
+func (tc *TestContext) Diagnostics() *diagnostics.Collector {
+	return tc.diagnostics
+}
+
+func (tc *TestContext) DiagnosticsPath() string {
+	return tc.diagnosticsPath
+}
+
 func (tc *TestContext) DryRun() bool {
 	return tc.dryRun
 }
@@ -122,10 +145,19 @@ func (tc *TestContext) FullName() bool {
 	return tc.fullName
 }
 
+func (tc *TestContext) Informers() informers.Factory {
+	return tc.informers
+}
+
 func (tc *TestContext) Namespacer() namespacer.Namespacer {
 	return tc.namespacer
 }
 
+// Parallel returns the maximum number of tests running concurrently, zero means the default limit.
+func (tc *TestContext) Parallel() int {
+	return tc.parallel
+}
+
 func (tc *TestContext) Quiet() bool {
 	return tc.quiet
 }
@@ -186,6 +218,16 @@ func (tc TestContext) WithDeletionPropagation(deletionPropagation metav1.Deletio
 	return tc
 }
 
+func (tc TestContext) WithDiagnostics(diagnostics *diagnostics.Collector) TestContext {
+	tc.diagnostics = diagnostics
+	return tc
+}
+
+func (tc TestContext) WithDiagnosticsPath(diagnosticsPath string) TestContext {
+	tc.diagnosticsPath = diagnosticsPath
+	return tc
+}
+
 func (tc TestContext) WithDryRun(dryRun bool) TestContext {
 	tc.dryRun = dryRun
 	return tc
@@ -201,11 +243,21 @@ func (tc TestContext) WithFullName(fullName bool) TestContext {
 	return tc
 }
 
+func (tc TestContext) WithInformers(informers informers.Factory) TestContext {
+	tc.informers = informers
+	return tc
+}
+
 func (tc TestContext) WithNamespacer(namespacer namespacer.Namespacer) TestContext {
 	tc.namespacer = namespacer
 	return tc
 }
 
+func (tc TestContext) WithParallel(parallel int) TestContext {
+	tc.parallel = parallel
+	return tc
+}
+
 func (tc TestContext) WithQuiet(quiet bool) TestContext {
 	tc.quiet = quiet
 	return tc
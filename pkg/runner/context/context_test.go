@@ -238,6 +238,19 @@ func TestTestContext_FullName(t *testing.T) {
 	}
 }
 
+func TestTestContext_Parallel(t *testing.T) {
+	parent := EmptyContext(clock.RealClock{})
+	child := parent.WithParallel(4)
+	{
+		value := parent.Parallel()
+		assert.Equal(t, 0, value)
+	}
+	{
+		value := child.Parallel()
+		assert.Equal(t, 4, value)
+	}
+}
+
 func TestTestContext_SkipDelete(t *testing.T) {
 	parent := EmptyContext(clock.RealClock{})
 	child := parent.WithSkipDelete(true)
@@ -1,8 +1,11 @@
 package context
 
 import (
+	"path/filepath"
+
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
 	"github.com/kyverno/chainsaw/pkg/engine/clusters"
+	"github.com/kyverno/chainsaw/pkg/engine/informers"
 	"github.com/kyverno/chainsaw/pkg/model"
 	"k8s.io/client-go/rest"
 	"k8s.io/utils/clock"
@@ -24,13 +27,23 @@ func InitContext(config model.Configuration, defaultCluster *rest.Config, values
 	tc = tc.WithFullName(config.Discovery.FullName)
 	// execution options
 	tc = tc.WithFailFast(config.Execution.FailFast)
+	if config.Execution.Parallel != nil {
+		tc = tc.WithParallel(*config.Execution.Parallel)
+	}
 	if config.Execution.ForceTerminationGracePeriod != nil {
 		tc = tc.WithTerminationGrace(&config.Execution.ForceTerminationGracePeriod.Duration)
 	}
+	if config.Execution.Watch {
+		tc = tc.WithInformers(informers.NewFactory())
+	}
 	// deletion options
 	tc = tc.WithDeletionPropagation(config.Deletion.Propagation)
 	// error options
 	tc = tc.WithCatch(config.Error.Catch...)
+	// report options
+	if config.Report != nil && config.Report.Diagnostics {
+		tc = tc.WithDiagnosticsPath(filepath.Join(config.Report.Path, config.Report.Name+"-diagnostics"))
+	}
 	// timeouts
 	tc = tc.WithTimeouts(v1alpha1.Timeouts{
 		Apply:   &config.Timeouts.Apply,
@@ -83,6 +83,19 @@ func TestInitContext(t *testing.T) {
 			assert.True(t, tc.Templating())
 			assert.Nil(t, tc.TerminationGrace())
 		},
+	}, {
+		name: "with parallel",
+		config: func(config model.Configuration) model.Configuration {
+			config.Execution.Parallel = new(4)
+			return config
+		}(config.Spec),
+		defaultCluster: nil,
+		values:         nil,
+		wantErr:        false,
+		want: func(t *testing.T, tc TestContext) {
+			t.Helper()
+			assert.Equal(t, 4, tc.Parallel())
+		},
 	}, {
 		name: "with compiler",
 		config: func(config model.Configuration) model.Configuration {
@@ -0,0 +1,168 @@
+package runner
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/kyverno/chainsaw/pkg/discovery"
+)
+
+// dependencyCycles returns the indices of tests that depend on themselves, directly or through other tests.
+// Dependencies on tests that are not part of the run are ignored.
+func dependencyCycles(tests []discovery.Test) []int {
+	indices := map[string][]int{}
+	for i, test := range tests {
+		if test.Test != nil {
+			indices[test.Test.Name] = append(indices[test.Test.Name], i)
+		}
+	}
+	var cycles []int
+	for i := range tests {
+		visited := make([]bool, len(tests))
+		queue := []int{i}
+		for len(queue) != 0 && !visited[i] {
+			current := queue[0]
+			queue = queue[1:]
+			for _, dep := range dependsOn(tests[current]) {
+				for _, j := range indices[dep] {
+					if !visited[j] {
+						visited[j] = true
+						queue = append(queue, j)
+					}
+				}
+			}
+		}
+		if visited[i] {
+			cycles = append(cycles, i)
+		}
+	}
+	return cycles
+}
+
+func dependsOn(test discovery.Test) []string {
+	if test.Test == nil {
+		return nil
+	}
+	return test.Test.Spec.DependsOn
+}
+
+// testResults records the outcome of tests, by name, so that dependent tests can check their prerequisites.
+type testResults struct {
+	lock   sync.Mutex
+	passed map[string]bool
+}
+
+func (r *testResults) Add(name string, passed bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.passed == nil {
+		r.passed = map[string]bool{}
+	}
+	// a test can run multiple times (scenarios), all runs must pass
+	if previous, ok := r.passed[name]; ok {
+		passed = passed && previous
+	}
+	r.passed[name] = passed
+}
+
+// Check returns a reason if one of the dependencies didn't pass.
+func (r *testResults) Check(dependsOn ...string) string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	var notFound, notPassed []string
+	for _, dep := range dependsOn {
+		if passed, ok := r.passed[dep]; !ok {
+			notFound = append(notFound, dep)
+		} else if !passed {
+			notPassed = append(notPassed, dep)
+		}
+	}
+	var reasons []string
+	if len(notPassed) != 0 {
+		slices.Sort(notPassed)
+		reasons = append(reasons, fmt.Sprintf("dependencies didn't pass: %s", strings.Join(slices.Compact(notPassed), ", ")))
+	}
+	if len(notFound) != 0 {
+		slices.Sort(notFound)
+		reasons = append(reasons, fmt.Sprintf("dependencies didn't run: %s", strings.Join(slices.Compact(notFound), ", ")))
+	}
+	return strings.Join(reasons, ", ")
+}
+
+// scheduler starts tests once the tests they depend on are done and enforces the concurrency limit.
+// Tests waiting for their dependencies don't count against the limit, exclusive tests run alone.
+type scheduler struct {
+	lock      sync.Mutex
+	cond      *sync.Cond
+	limit     int
+	running   int
+	exclusive bool
+	pending   map[string]int
+}
+
+func newScheduler(limit int, tests []discovery.Test) *scheduler {
+	s := &scheduler{
+		limit:   limit,
+		pending: map[string]int{},
+	}
+	s.cond = sync.NewCond(&s.lock)
+	for _, test := range tests {
+		if test.Test != nil {
+			// every scenario is a separate run of the test
+			s.pending[test.Test.Name] += max(1, len(test.Test.Spec.Scenarios))
+		}
+	}
+	return s
+}
+
+// Start blocks until all the dependencies are done and the test can run, exclusive tests wait until no other test is running.
+func (s *scheduler) Start(exclusive bool, dependsOn ...string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for !s.ready(exclusive, dependsOn...) {
+		s.cond.Wait()
+	}
+	s.running++
+	s.exclusive = exclusive
+}
+
+// Done records the end of a test run, started must be true if the run was started with Start.
+func (s *scheduler) Done(name string, runs int, started bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if started {
+		s.running--
+		// an exclusive test is the only one running
+		if s.running == 0 {
+			s.exclusive = false
+		}
+	}
+	s.pending[name] -= runs
+	s.cond.Broadcast()
+}
+
+func (s *scheduler) ready(exclusive bool, dependsOn ...string) bool {
+	for _, dep := range dependsOn {
+		if s.pending[dep] > 0 {
+			return false
+		}
+	}
+	if s.exclusive {
+		return false
+	}
+	if exclusive {
+		return s.running == 0
+	}
+	return s.limit <= 0 || s.running < s.limit
+}
+
+func cycleError(tests []discovery.Test, cycles []int) error {
+	var names []string
+	for _, i := range cycles {
+		names = append(names, tests[i].Test.Name)
+	}
+	slices.Sort(names)
+	return fmt.Errorf("dependency cycle detected: %s", strings.Join(slices.Compact(names), ", "))
+}
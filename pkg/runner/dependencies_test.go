@@ -0,0 +1,111 @@
+package runner
+
+import (
+	"testing"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/discovery"
+	"github.com/kyverno/chainsaw/pkg/model"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_dependencyCycles(t *testing.T) {
+	test := func(name string, dependsOn ...string) discovery.Test {
+		return discovery.Test{
+			Test: &model.Test{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Spec:       v1alpha1.TestSpec{DependsOn: dependsOn},
+			},
+		}
+	}
+	tests := []struct {
+		name  string
+		tests []discovery.Test
+		want  []int
+	}{{
+		name:  "no dependencies",
+		tests: []discovery.Test{test("a"), test("b")},
+	}, {
+		name:  "chain",
+		tests: []discovery.Test{test("c", "b"), test("b", "a"), test("a")},
+	}, {
+		name:  "diamond",
+		tests: []discovery.Test{test("a"), test("b", "a"), test("c", "a"), test("d", "b", "c"), test("e")},
+	}, {
+		name:  "missing dependency",
+		tests: []discovery.Test{test("a", "missing"), test("b", "a")},
+	}, {
+		name:  "test with error",
+		tests: []discovery.Test{{}, test("a")},
+	}, {
+		name:  "cycle",
+		tests: []discovery.Test{test("a", "b"), test("b", "a"), test("c"), test("d", "a")},
+		want:  []int{0, 1},
+	}, {
+		name:  "self dependency",
+		tests: []discovery.Test{test("a", "a"), test("b", "a")},
+		want:  []int{0},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := dependencyCycles(tt.tests)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_scheduler(t *testing.T) {
+	test := func(name string, scenarios int, dependsOn ...string) discovery.Test {
+		return discovery.Test{
+			Test: &model.Test{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Spec: v1alpha1.TestSpec{
+					DependsOn: dependsOn,
+					Scenarios: make([]v1alpha1.Scenario, scenarios),
+				},
+			},
+		}
+	}
+	s := newScheduler(2, []discovery.Test{test("a", 0), test("b", 2), test("c", 0, "a", "b")})
+	assert.False(t, s.ready(false, "a"))
+	assert.True(t, s.ready(false, "missing"))
+	s.Start(false)
+	s.Start(false)
+	assert.False(t, s.ready(false))
+	s.Done("a", 1, true)
+	assert.True(t, s.ready(false, "a"))
+	assert.False(t, s.ready(true))
+	s.Done("b", 1, true)
+	assert.False(t, s.ready(false, "a", "b"))
+	s.Done("b", 1, false)
+	assert.True(t, s.ready(false, "a", "b"))
+	assert.True(t, s.ready(true, "a", "b"))
+	done := make(chan struct{})
+	s.Start(true)
+	// no other test starts while an exclusive test runs, even when its dependencies are done
+	assert.False(t, s.ready(false, "a", "b"))
+	assert.False(t, s.ready(true, "a", "b"))
+	go func() {
+		s.Start(false, "c")
+		close(done)
+	}()
+	s.Done("c", 1, true)
+	<-done
+	assert.Equal(t, 1, s.running)
+}
+
+func Test_testResults(t *testing.T) {
+	var results testResults
+	results.Add("a", true)
+	results.Add("b", false)
+	results.Add("c", true)
+	results.Add("c", false)
+	results.Add("d", false)
+	results.Add("d", true)
+	assert.Equal(t, "", results.Check())
+	assert.Equal(t, "", results.Check("a"))
+	assert.Equal(t, "dependencies didn't pass: b", results.Check("a", "b"))
+	assert.Equal(t, "dependencies didn't pass: c, d", results.Check("d", "c"))
+	assert.Equal(t, "dependencies didn't pass: b, dependencies didn't run: e", results.Check("e", "b"))
+}
@@ -0,0 +1,56 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/engine/templating"
+	enginecontext "github.com/kyverno/chainsaw/pkg/runner/context"
+)
+
+// forEachItems evaluates the items of a loop.
+// For a matrix, items are maps containing one value per matrix key, every combination of values produces an item.
+func forEachItems(ctx context.Context, tc enginecontext.TestContext, forEach v1alpha1.ForEach) ([]any, error) {
+	if forEach.Items != nil {
+		return evaluateList(ctx, tc, "items", *forEach.Items)
+	}
+	items := []any{map[string]any{}}
+	for _, key := range slices.Sorted(maps.Keys(forEach.Matrix)) {
+		values, err := evaluateList(ctx, tc, "matrix."+key, forEach.Matrix[key])
+		if err != nil {
+			return nil, err
+		}
+		var combinations []any
+		for _, item := range items {
+			for _, value := range values {
+				combination := maps.Clone(item.(map[string]any))
+				combination[key] = value
+				combinations = append(combinations, combination)
+			}
+		}
+		items = combinations
+	}
+	return items, nil
+}
+
+func evaluateList(ctx context.Context, tc enginecontext.TestContext, path string, projection v1alpha1.Projection) ([]any, error) {
+	value, err := templating.Template(ctx, tc.Compilers(), projection, nil, tc.Bindings())
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return nil, nil
+	}
+	list, ok := value.([]any)
+	if !ok {
+		return nil, fmt.Errorf("forEach %s didn't evaluate to a list (%T)", path, value)
+	}
+	return list, nil
+}
+
+func withItem(tc enginecontext.TestContext, index int, item any) enginecontext.TestContext {
+	return tc.WithBinding("item", item).WithBinding("index", index)
+}
@@ -0,0 +1,217 @@
+package runner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/logging"
+	fakeLogger "github.com/kyverno/chainsaw/pkg/mocks"
+	"github.com/kyverno/chainsaw/pkg/model"
+	enginecontext "github.com/kyverno/chainsaw/pkg/runner/context"
+	"github.com/kyverno/chainsaw/pkg/runner/mocks"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/clock"
+)
+
+func Test_forEachItems(t *testing.T) {
+	tests := []struct {
+		name     string
+		forEach  v1alpha1.ForEach
+		bindings apis.Bindings
+		want     []any
+		wantErr  bool
+	}{{
+		name: "items",
+		forEach: v1alpha1.ForEach{
+			Items: new(v1alpha1.NewProjection([]any{"a", "b"})),
+		},
+		want: []any{"a", "b"},
+	}, {
+		name: "items expression",
+		forEach: v1alpha1.ForEach{
+			Items: new(v1alpha1.NewProjection("($namespaces)")),
+		},
+		bindings: apis.NewBindings().Register("$namespaces", apis.NewBinding([]any{"foo", "bar"})),
+		want:     []any{"foo", "bar"},
+	}, {
+		name: "items null",
+		forEach: v1alpha1.ForEach{
+			Items: new(v1alpha1.NewProjection(nil)),
+		},
+		want: nil,
+	}, {
+		name: "items not a list",
+		forEach: v1alpha1.ForEach{
+			Items: new(v1alpha1.NewProjection("foo")),
+		},
+		wantErr: true,
+	}, {
+		name: "matrix",
+		forEach: v1alpha1.ForEach{
+			Matrix: map[string]v1alpha1.Projection{
+				"version": v1alpha1.NewProjection([]any{"1", "2"}),
+				"arch":    v1alpha1.NewProjection([]any{"amd64", "arm64"}),
+			},
+		},
+		want: []any{
+			map[string]any{"arch": "amd64", "version": "1"},
+			map[string]any{"arch": "amd64", "version": "2"},
+			map[string]any{"arch": "arm64", "version": "1"},
+			map[string]any{"arch": "arm64", "version": "2"},
+		},
+	}, {
+		name: "matrix not a list",
+		forEach: v1alpha1.ForEach{
+			Matrix: map[string]v1alpha1.Projection{
+				"version": v1alpha1.NewProjection("1"),
+			},
+		},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bindings := tt.bindings
+			if bindings == nil {
+				bindings = apis.NewBindings()
+			}
+			tc := enginecontext.MakeContext(clock.RealClock{}, bindings, mocks.Registry{})
+			got, err := forEachItems(context.TODO(), tc, tt.forEach)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_runner_forEach(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "out")
+	script := func(condition v1alpha1.Condition) v1alpha1.Operation {
+		return v1alpha1.Operation{
+			OperationBase: v1alpha1.OperationBase{
+				If: condition,
+				ForEach: &v1alpha1.ForEach{
+					Items: new(v1alpha1.NewProjection([]any{"a", "b", "c"})),
+				},
+			},
+			Script: &v1alpha1.Script{
+				ActionEnv: v1alpha1.ActionEnv{
+					Env: []v1alpha1.Binding{{
+						Name:  "VALUE",
+						Value: v1alpha1.NewProjection("(join('', [$item, to_string($index), to_string($operation.iteration)]))"),
+					}},
+				},
+				Content: "echo -n $VALUE >> " + out,
+			},
+		}
+	}
+	ctx := logging.WithLogger(context.Background(), &fakeLogger.Logger{})
+	tc := enginecontext.MakeContext(clock.RealClock{}, apis.NewBindings(), mocks.Registry{}).
+		WithTimeouts(v1alpha1.Timeouts{Exec: &metav1.Duration{Duration: 5 * time.Second}})
+	t.Run("operation", func(t *testing.T) {
+		assert.NoError(t, os.WriteFile(out, nil, 0o600))
+		report := &model.StepReport{}
+		runner := runner{}
+		_, _, err := runner.runOperation(ctx, tc, script(""), 0, nil, report)
+		assert.NoError(t, err)
+		if assert.Len(t, report.Operations, 3) {
+			assert.Equal(t, "forEach[0]", report.Operations[0].Name)
+			assert.Equal(t, "forEach[1]", report.Operations[1].Name)
+			assert.Equal(t, "forEach[2]", report.Operations[2].Name)
+		}
+		data, err := os.ReadFile(out)
+		assert.NoError(t, err)
+		assert.Equal(t, "a01b12c23", string(data))
+	})
+	t.Run("operation with outputs", func(t *testing.T) {
+		operation := v1alpha1.Operation{
+			OperationBase: v1alpha1.OperationBase{
+				ForEach: &v1alpha1.ForEach{
+					Items: new(v1alpha1.NewProjection([]any{"a", "b", "c"})),
+				},
+			},
+			Script: &v1alpha1.Script{
+				ActionEnv: v1alpha1.ActionEnv{
+					Env: []v1alpha1.Binding{{
+						Name:  "VALUE",
+						Value: v1alpha1.NewProjection("(join('', [$item, $previous]))"),
+					}},
+				},
+				ActionOutputs: v1alpha1.ActionOutputs{
+					Outputs: []v1alpha1.Output{{
+						Binding: v1alpha1.Binding{
+							Name:  "previous",
+							Value: v1alpha1.NewProjection("($stdout)"),
+						},
+					}},
+				},
+				Content: "echo -n $VALUE",
+			},
+		}
+		runner := runner{}
+		_, tc, err := runner.runOperation(ctx, tc.WithBinding("previous", ""), operation, 0, nil, nil)
+		assert.NoError(t, err)
+		// outputs are available to the next iterations and after the loop
+		previous, err := tc.Bindings().Get("$previous")
+		assert.NoError(t, err)
+		value, err := previous.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, "cba", value)
+	})
+	t.Run("operation with condition", func(t *testing.T) {
+		assert.NoError(t, os.WriteFile(out, nil, 0o600))
+		report := &model.StepReport{}
+		runner := runner{}
+		_, _, err := runner.runOperation(ctx, tc, script("($item != 'b')"), 0, nil, report)
+		assert.NoError(t, err)
+		assert.Len(t, report.Operations, 3)
+		assert.True(t, report.Operations[1].Skipped)
+		data, err := os.ReadFile(out)
+		assert.NoError(t, err)
+		assert.Equal(t, "a01c23", string(data))
+	})
+	t.Run("step", func(t *testing.T) {
+		assert.NoError(t, os.WriteFile(out, nil, 0o600))
+		step := v1alpha1.TestStep{
+			Name: "foo",
+			ForEach: &v1alpha1.ForEach{
+				Matrix: map[string]v1alpha1.Projection{
+					"x": v1alpha1.NewProjection([]any{"1", "2"}),
+				},
+			},
+			TestStepSpec: v1alpha1.TestStepSpec{
+				Try: []v1alpha1.Operation{{
+					Script: &v1alpha1.Script{
+						ActionEnv: v1alpha1.ActionEnv{
+							Env: []v1alpha1.Binding{{
+								Name:  "VALUE",
+								Value: v1alpha1.NewProjection("($item.x)"),
+							}},
+						},
+						Content: "echo -n $VALUE >> " + out,
+					},
+				}},
+			},
+		}
+		report := &model.TestReport{}
+		runner := runner{}
+		failed := false
+		stop := runner.runStep(ctx, func(func()) {}, func() { failed = true }, func() bool { return failed }, tc, step, report)
+		assert.False(t, stop)
+		assert.False(t, failed)
+		assert.Len(t, report.Steps, 2)
+		assert.Equal(t, "foo[0]", report.Steps[0].Name)
+		assert.Equal(t, "foo[1]", report.Steps[1].Name)
+		data, err := os.ReadFile(out)
+		assert.NoError(t, err)
+		assert.Equal(t, "12", string(data))
+	})
+}
@@ -0,0 +1,75 @@
+package runner
+
+import (
+	"context"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/logging"
+	"github.com/kyverno/chainsaw/pkg/model"
+	enginecontext "github.com/kyverno/chainsaw/pkg/runner/context"
+	"github.com/kyverno/pkg/ext/output/color"
+)
+
+// Hooks contains the operations executed once before and after all tests.
+type Hooks struct {
+	Setup    *v1alpha1.TestStepSpec
+	Teardown *v1alpha1.TestStepSpec
+}
+
+// runHook runs suite level operations and reports them as a test with the given name.
+// It returns the context with the outputs produced by the try operations registered as bindings, and whether the hook failed.
+func (r *runner) runHook(
+	ctx context.Context,
+	cleanup func(func()),
+	fail func(),
+	tc enginecontext.TestContext,
+	name string,
+	spec v1alpha1.TestStepSpec,
+) (enginecontext.TestContext, bool) {
+	report := &model.TestReport{
+		Name:      name,
+		StartTime: time.Now(),
+	}
+	defer func() {
+		report.EndTime = time.Now()
+		tc.Add(report)
+	}()
+	// failures from tests must not trigger the hook catch block
+	failed := false
+	hookFail := func() {
+		failed = true
+		fail()
+	}
+	step := v1alpha1.TestStep{
+		Name:         name,
+		TestStepSpec: spec,
+	}
+	_, hookTc := r.runStepItem(ctx, cleanup, hookFail, func() bool { return failed }, tc, step, name, report)
+	for _, operation := range spec.Try {
+		// outputs produced by loop iterations are not propagated
+		if operation.ForEach != nil {
+			continue
+		}
+		for _, output := range operation.Outputs() {
+			name, err := output.Name.Value(ctx, hookTc.Compilers(), hookTc.Bindings())
+			if err != nil {
+				hookFail()
+				logging.Log(ctx, logging.Internal, logging.ErrorStatus, nil, color.BoldRed, logging.ErrSection(err))
+				r.onFail()
+				continue
+			}
+			// the binding doesn't exist if the operation didn't run
+			binding, err := hookTc.Bindings().Get("$" + name)
+			if err != nil {
+				continue
+			}
+			value, err := binding.Value()
+			if err != nil {
+				continue
+			}
+			tc = tc.WithBinding(name, value)
+		}
+	}
+	return tc, failed
+}
@@ -17,4 +17,5 @@ type StepInfo struct {
 type OperationInfo struct {
 	Id         int
 	ResourceId int
+	Iteration  int
 }
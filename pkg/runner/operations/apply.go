@@ -39,6 +39,7 @@ func (o applyAction) Execute(ctx context.Context, tc enginecontext.TestContext)
 			tc.Namespacer(),
 			getCleanerOrNil(o.cleaner, tc),
 			tc.Templating(),
+			o.op.ActionServerSide,
 			o.op.Expect,
 			o.op.Outputs,
 		)
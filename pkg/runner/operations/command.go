@@ -4,13 +4,15 @@ import (
 	"context"
 
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/cleanup/cleaner"
 	opcommand "github.com/kyverno/chainsaw/pkg/engine/operations/command"
 	"github.com/kyverno/chainsaw/pkg/engine/outputs"
 	enginecontext "github.com/kyverno/chainsaw/pkg/runner/context"
 )
 
 type commandAction struct {
-	op v1alpha1.Command
+	op      v1alpha1.Command
+	cleaner cleaner.CleanerCollector
 }
 
 func (o commandAction) Execute(ctx context.Context, tc enginecontext.TestContext) (outputs.Outputs, error) {
@@ -34,6 +36,7 @@ func (o commandAction) Execute(ctx context.Context, tc enginecontext.TestContext
 			tc.BasePath(),
 			ns,
 			config,
+			o.cleaner,
 		)
 		ctx, cancel := context.WithTimeout(ctx, tc.Timeouts().Exec)
 		defer cancel()
@@ -41,8 +44,9 @@ func (o commandAction) Execute(ctx context.Context, tc enginecontext.TestContext
 	}
 }
 
-func commandOperation(op v1alpha1.Command) Operation {
+func commandOperation(cleaner cleaner.CleanerCollector, op v1alpha1.Command) Operation {
 	return commandAction{
-		op: op,
+		op:      op,
+		cleaner: cleaner,
 	}
 }
@@ -24,17 +24,21 @@ func (o errorAction) Execute(ctx context.Context, tc enginecontext.TestContext)
 	}
 	if tc, err := enginecontext.SetupContextAndBindings(tc, contextData, o.op.Bindings...); err != nil {
 		return nil, err
-	} else if _, client, err := tc.CurrentClusterClient(); err != nil {
+	} else if config, client, err := tc.CurrentClusterClient(); err != nil {
 		return nil, err
 	} else {
+		window, timeout := consistently(o.op.ActionConsistently, tc.Timeouts().Error)
 		op := operror.New(
 			tc.Compilers(),
 			client,
 			o.resource,
 			tc.Namespacer(),
 			tc.Templating(),
+			tc.CurrentClusterInformers(config),
+			window,
+			o.op.MatchMode,
 		)
-		ctx, cancel := context.WithTimeout(ctx, tc.Timeouts().Error)
+		ctx, cancel := context.WithTimeout(ctx, timeout)
 		defer cancel()
 		return op.Exec(ctx, tc.Bindings())
 	}
@@ -0,0 +1,43 @@
+package operations
+
+import (
+	"context"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	opexec "github.com/kyverno/chainsaw/pkg/engine/operations/exec"
+	"github.com/kyverno/chainsaw/pkg/engine/outputs"
+	enginecontext "github.com/kyverno/chainsaw/pkg/runner/context"
+)
+
+type execAction struct {
+	op v1alpha1.Exec
+}
+
+func (o execAction) Execute(ctx context.Context, tc enginecontext.TestContext) (outputs.Outputs, error) {
+	contextData := enginecontext.ContextData{
+		Cluster:  o.op.Cluster,
+		Clusters: o.op.Clusters,
+		Timeouts: &v1alpha1.Timeouts{Exec: o.op.Timeout},
+	}
+	if tc, err := enginecontext.SetupContextAndBindings(tc, contextData, o.op.Bindings...); err != nil {
+		return nil, err
+	} else if config, _, err := tc.CurrentClusterClient(); err != nil {
+		return nil, err
+	} else {
+		op := opexec.New(
+			tc.Compilers(),
+			config,
+			tc.Namespacer(),
+			o.op,
+		)
+		ctx, cancel := context.WithTimeout(ctx, tc.Timeouts().Exec)
+		defer cancel()
+		return op.Exec(ctx, tc.Bindings())
+	}
+}
+
+func execOperation(op v1alpha1.Exec) Operation {
+	return execAction{
+		op: op,
+	}
+}
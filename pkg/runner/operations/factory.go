@@ -10,31 +10,80 @@ import (
 	enginecontext "github.com/kyverno/chainsaw/pkg/runner/context"
 )
 
+// OperationType returns the type of operation reported for the operation handler.
+func OperationType(handler v1alpha1.Operation) model.OperationType {
+	switch {
+	case handler.Apply != nil:
+		return model.OperationTypeApply
+	case handler.Assert != nil:
+		return model.OperationTypeAssert
+	case handler.Command != nil:
+		return model.OperationTypeCommand
+	case handler.Create != nil:
+		return model.OperationTypeCreate
+	case handler.Delete != nil:
+		return model.OperationTypeDelete
+	case handler.Describe != nil:
+		return model.OperationTypeDescribe
+	case handler.Error != nil:
+		return model.OperationTypeError
+	case handler.Events != nil:
+		return model.OperationTypeEvents
+	case handler.Exec != nil:
+		return model.OperationTypeExec
+	case handler.Get != nil:
+		return model.OperationTypeGet
+	case handler.HTTP != nil:
+		return model.OperationTypeHTTP
+	case handler.Patch != nil:
+		return model.OperationTypePatch
+	case handler.PodLogs != nil:
+		return model.OperationTypePodLogs
+	case handler.PortForward != nil:
+		return model.OperationTypePortForward
+	case handler.Process != nil:
+		return model.OperationTypeProcess
+	case handler.Proxy != nil:
+		return model.OperationTypeProxy
+	case handler.Script != nil:
+		return model.OperationTypeScript
+	case handler.Sleep != nil:
+		return model.OperationTypeSleep
+	case handler.Update != nil:
+		return model.OperationTypeUpdate
+	case handler.Wait != nil:
+		return model.OperationTypeWait
+	default:
+		return ""
+	}
+}
+
 func TryOperation(
 	ctx context.Context,
 	tc enginecontext.TestContext,
 	handler v1alpha1.Operation,
 	cleaner cleaner.CleanerCollector,
 ) (model.OperationType, []Operation, error) {
+	opType := OperationType(handler)
 	if handler.Apply != nil {
 		loaded, err := applyOperation(ctx, tc, cleaner, *handler.Apply)
-		return model.OperationTypeApply, loaded, err
+		return opType, loaded, err
 	} else if handler.Assert != nil {
 		loaded, err := assertOperation(ctx, tc, *handler.Assert)
-		return model.OperationTypeAssert, loaded, err
+		return opType, loaded, err
 	} else if handler.Command != nil {
-		return model.OperationTypeCommand, []Operation{commandOperation(*handler.Command)}, nil
+		return opType, []Operation{commandOperation(cleaner, *handler.Command)}, nil
 	} else if handler.Create != nil {
 		loaded, err := createOperation(ctx, tc, cleaner, *handler.Create)
-		return model.OperationTypeCreate, loaded, err
+		return opType, loaded, err
 	} else if handler.Delete != nil {
 		loaded, err := deleteOperation(ctx, tc, *handler.Delete)
-		return model.OperationTypeDelete, loaded, err
+		return opType, loaded, err
 	} else if handler.Describe != nil {
-		return model.OperationTypeCommand, []Operation{describeOperation(*handler.Describe)}, nil
+		return opType, []Operation{describeOperation(*handler.Describe)}, nil
 	} else if handler.Error != nil {
 		loaded, err := errorOperation(ctx, tc, *handler.Error)
-		return model.OperationTypeError, loaded, err
+		return opType, loaded, err
 	} else if handler.Events != nil {
 		get := v1alpha1.Get{
 			ActionClusters: handler.Events.ActionClusters,
@@ -48,25 +97,33 @@ func TryOperation(
 				ActionObjectSelector: handler.Events.ActionObjectSelector,
 			},
 		}
-		return model.OperationTypeCommand, []Operation{getOperation(get)}, nil
+		return opType, []Operation{getOperation(get)}, nil
+	} else if handler.Exec != nil {
+		return opType, []Operation{execOperation(*handler.Exec)}, nil
 	} else if handler.Get != nil {
-		return model.OperationTypeCommand, []Operation{getOperation(*handler.Get)}, nil
+		return opType, []Operation{getOperation(*handler.Get)}, nil
+	} else if handler.HTTP != nil {
+		return opType, []Operation{httpOperation(*handler.HTTP)}, nil
 	} else if handler.Patch != nil {
 		loaded, err := patchOperation(ctx, tc, *handler.Patch)
-		return model.OperationTypePatch, loaded, err
+		return opType, loaded, err
 	} else if handler.PodLogs != nil {
-		return model.OperationTypeCommand, []Operation{logsOperation(*handler.PodLogs)}, nil
+		return opType, []Operation{logsOperation(*handler.PodLogs)}, nil
+	} else if handler.PortForward != nil {
+		return opType, []Operation{portForwardOperation(cleaner, *handler.PortForward)}, nil
+	} else if handler.Process != nil {
+		return opType, []Operation{processOperation(*handler.Process)}, nil
 	} else if handler.Proxy != nil {
-		return model.OperationTypeCommand, []Operation{proxyOperation(*handler.Proxy)}, nil
+		return opType, []Operation{proxyOperation(*handler.Proxy)}, nil
 	} else if handler.Script != nil {
-		return model.OperationTypeScript, []Operation{scriptOperation(*handler.Script)}, nil
+		return opType, []Operation{scriptOperation(cleaner, *handler.Script)}, nil
 	} else if handler.Sleep != nil {
-		return model.OperationTypeSleep, []Operation{sleepOperation(*handler.Sleep)}, nil
+		return opType, []Operation{sleepOperation(*handler.Sleep)}, nil
 	} else if handler.Update != nil {
 		loaded, err := updateOperation(ctx, tc, *handler.Update)
-		return model.OperationTypeUpdate, loaded, err
+		return opType, loaded, err
 	} else if handler.Wait != nil {
-		return model.OperationTypeCommand, []Operation{waitOperation(*handler.Wait)}, nil
+		return opType, []Operation{waitOperation(*handler.Wait)}, nil
 	} else {
 		return "", nil, errors.New("no operation found")
 	}
@@ -105,9 +162,15 @@ func CatchOperation(
 		}
 		ops = append(ops, loaded...)
 	} else if handler.Command != nil {
-		ops = append(ops, commandOperation(*handler.Command))
+		if handler.Command.Background {
+			return nil, errors.New("background commands are only supported in try operations")
+		}
+		ops = append(ops, commandOperation(nil, *handler.Command))
 	} else if handler.Script != nil {
-		ops = append(ops, scriptOperation(*handler.Script))
+		if handler.Script.Background {
+			return nil, errors.New("background scripts are only supported in try operations")
+		}
+		ops = append(ops, scriptOperation(nil, *handler.Script))
 	} else if handler.Sleep != nil {
 		ops = append(ops, sleepOperation(*handler.Sleep))
 	} else if handler.Wait != nil {
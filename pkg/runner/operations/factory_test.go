@@ -0,0 +1,54 @@
+package operations
+
+import (
+	"testing"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOperationType(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler v1alpha1.Operation
+		want    model.OperationType
+	}{{
+		name:    "command",
+		handler: v1alpha1.Operation{Command: &v1alpha1.Command{}},
+		want:    model.OperationTypeCommand,
+	}, {
+		name:    "describe",
+		handler: v1alpha1.Operation{Describe: &v1alpha1.Describe{}},
+		want:    model.OperationTypeDescribe,
+	}, {
+		name:    "events",
+		handler: v1alpha1.Operation{Events: &v1alpha1.Events{}},
+		want:    model.OperationTypeEvents,
+	}, {
+		name:    "get",
+		handler: v1alpha1.Operation{Get: &v1alpha1.Get{}},
+		want:    model.OperationTypeGet,
+	}, {
+		name:    "pod logs",
+		handler: v1alpha1.Operation{PodLogs: &v1alpha1.PodLogs{}},
+		want:    model.OperationTypePodLogs,
+	}, {
+		name:    "proxy",
+		handler: v1alpha1.Operation{Proxy: &v1alpha1.Proxy{}},
+		want:    model.OperationTypeProxy,
+	}, {
+		name:    "wait",
+		handler: v1alpha1.Operation{Wait: &v1alpha1.Wait{}},
+		want:    model.OperationTypeWait,
+	}, {
+		name:    "parallel",
+		handler: v1alpha1.Operation{Parallel: []v1alpha1.Operation{{Sleep: &v1alpha1.Sleep{}}}},
+		want:    "",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, OperationType(tt.handler))
+		})
+	}
+}
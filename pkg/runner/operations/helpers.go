@@ -5,6 +5,7 @@ import (
 	"errors"
 	"net/url"
 	"path/filepath"
+	"time"
 
 	"github.com/kyverno/chainsaw/pkg/apis"
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
@@ -85,7 +86,19 @@ func getCleanerOrNil(cleaner cleaner.CleanerCollector, tc enginecontext.TestCont
 		return nil
 	}
 	if tc.SkipDelete() {
-		return nil
+		cleaner = nil
+	}
+	// objects are tracked for diagnostics even if they are not deleted
+	if diagnostics := tc.Diagnostics(); diagnostics != nil {
+		return diagnostics.Track(cleaner)
 	}
 	return cleaner
 }
+
+// consistently returns the consistency window and the timeout extended by this window.
+func consistently(op v1alpha1.ActionConsistently, timeout time.Duration) (time.Duration, time.Duration) {
+	if op.Consistently == nil {
+		return 0, timeout
+	}
+	return op.Consistently.Duration, timeout + op.Consistently.Duration
+}
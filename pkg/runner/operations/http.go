@@ -0,0 +1,45 @@
+package operations
+
+import (
+	"context"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	ophttp "github.com/kyverno/chainsaw/pkg/engine/operations/http"
+	"github.com/kyverno/chainsaw/pkg/engine/outputs"
+	enginecontext "github.com/kyverno/chainsaw/pkg/runner/context"
+)
+
+type httpAction struct {
+	op v1alpha1.HTTP
+}
+
+func (o httpAction) Execute(ctx context.Context, tc enginecontext.TestContext) (outputs.Outputs, error) {
+	contextData := enginecontext.ContextData{
+		Cluster:  o.op.Cluster,
+		Clusters: o.op.Clusters,
+		Timeouts: &v1alpha1.Timeouts{Exec: o.op.Timeout},
+	}
+	if tc, err := enginecontext.SetupContextAndBindings(tc, contextData, o.op.Bindings...); err != nil {
+		return nil, err
+	} else if config, client, err := tc.CurrentClusterClient(); err != nil {
+		return nil, err
+	} else {
+		op := ophttp.New(
+			tc.Compilers(),
+			client,
+			config,
+			tc.Namespacer(),
+			tc.BasePath(),
+			o.op,
+		)
+		ctx, cancel := context.WithTimeout(ctx, tc.Timeouts().Exec)
+		defer cancel()
+		return op.Exec(ctx, tc.Bindings())
+	}
+}
+
+func httpOperation(op v1alpha1.HTTP) Operation {
+	return httpAction{
+		op: op,
+	}
+}
@@ -39,6 +39,8 @@ func (o patchAction) Execute(ctx context.Context, tc enginecontext.TestContext)
 			o.op.Expect,
 			o.op.Outputs,
 			o.op.Subresource,
+			o.op.Type,
+			o.op.JSONPatch,
 		)
 		ctx, cancel := context.WithTimeout(ctx, tc.Timeouts().Apply)
 		defer cancel()
@@ -0,0 +1,48 @@
+package operations
+
+import (
+	"context"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/cleanup/cleaner"
+	opportforward "github.com/kyverno/chainsaw/pkg/engine/operations/portforward"
+	"github.com/kyverno/chainsaw/pkg/engine/outputs"
+	enginecontext "github.com/kyverno/chainsaw/pkg/runner/context"
+)
+
+type portForwardAction struct {
+	op      v1alpha1.PortForward
+	cleaner cleaner.CleanerCollector
+}
+
+func (o portForwardAction) Execute(ctx context.Context, tc enginecontext.TestContext) (outputs.Outputs, error) {
+	contextData := enginecontext.ContextData{
+		Cluster:  o.op.Cluster,
+		Clusters: o.op.Clusters,
+		Timeouts: &v1alpha1.Timeouts{Exec: o.op.Timeout},
+	}
+	if tc, err := enginecontext.SetupContextAndBindings(tc, contextData, o.op.Bindings...); err != nil {
+		return nil, err
+	} else if config, _, err := tc.CurrentClusterClient(); err != nil {
+		return nil, err
+	} else {
+		// the port forward lifetime is tied to the cleaner, not to the operation context
+		op := opportforward.New(
+			tc.Compilers(),
+			config,
+			tc.Namespacer(),
+			o.cleaner,
+			o.op,
+		)
+		ctx, cancel := context.WithTimeout(ctx, tc.Timeouts().Exec)
+		defer cancel()
+		return op.Exec(ctx, tc.Bindings())
+	}
+}
+
+func portForwardOperation(cleaner cleaner.CleanerCollector, op v1alpha1.PortForward) Operation {
+	return portForwardAction{
+		op:      op,
+		cleaner: cleaner,
+	}
+}
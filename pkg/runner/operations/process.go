@@ -0,0 +1,37 @@
+package operations
+
+import (
+	"context"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	opprocess "github.com/kyverno/chainsaw/pkg/engine/operations/process"
+	"github.com/kyverno/chainsaw/pkg/engine/outputs"
+	enginecontext "github.com/kyverno/chainsaw/pkg/runner/context"
+)
+
+type processAction struct {
+	op v1alpha1.Process
+}
+
+func (o processAction) Execute(ctx context.Context, tc enginecontext.TestContext) (outputs.Outputs, error) {
+	contextData := enginecontext.ContextData{
+		Timeouts: &v1alpha1.Timeouts{Exec: o.op.Timeout},
+	}
+	if tc, err := enginecontext.SetupContextAndBindings(tc, contextData, o.op.Bindings...); err != nil {
+		return nil, err
+	} else {
+		op := opprocess.New(
+			tc.Compilers(),
+			o.op,
+		)
+		ctx, cancel := context.WithTimeout(ctx, tc.Timeouts().Exec)
+		defer cancel()
+		return op.Exec(ctx, tc.Bindings())
+	}
+}
+
+func processOperation(op v1alpha1.Process) Operation {
+	return processAction{
+		op: op,
+	}
+}
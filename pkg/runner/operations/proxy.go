@@ -4,8 +4,7 @@ import (
 	"context"
 
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
-	"github.com/kyverno/chainsaw/pkg/engine/kubectl"
-	opcommand "github.com/kyverno/chainsaw/pkg/engine/operations/command"
+	opproxy "github.com/kyverno/chainsaw/pkg/engine/operations/proxy"
 	"github.com/kyverno/chainsaw/pkg/engine/outputs"
 	enginecontext "github.com/kyverno/chainsaw/pkg/runner/context"
 )
@@ -15,10 +14,6 @@ type proxyAction struct {
 }
 
 func (o proxyAction) Execute(ctx context.Context, tc enginecontext.TestContext) (outputs.Outputs, error) {
-	ns := ""
-	if namespacer := tc.Namespacer(); namespacer != nil {
-		ns = namespacer.GetNamespace()
-	}
 	contextData := enginecontext.ContextData{
 		Cluster:  o.op.Cluster,
 		Clusters: o.op.Clusters,
@@ -29,21 +24,12 @@ func (o proxyAction) Execute(ctx context.Context, tc enginecontext.TestContext)
 	} else if config, client, err := tc.CurrentClusterClient(); err != nil {
 		return nil, err
 	} else {
-		entrypoint, args, err := kubectl.Proxy(ctx, tc.Compilers(), client, tc.Bindings(), &o.op)
-		if err != nil {
-			return nil, err
-		}
-		op := opcommand.New(
+		op := opproxy.New(
 			tc.Compilers(),
-			v1alpha1.Command{
-				ActionClusters: o.op.ActionClusters,
-				ActionTimeout:  o.op.ActionTimeout,
-				Entrypoint:     entrypoint,
-				Args:           args,
-			},
-			tc.BasePath(),
-			ns,
+			client,
 			config,
+			tc.Namespacer(),
+			o.op,
 		)
 		ctx, cancel := context.WithTimeout(ctx, tc.Timeouts().Exec)
 		defer cancel()
@@ -4,13 +4,15 @@ import (
 	"context"
 
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/cleanup/cleaner"
 	opscript "github.com/kyverno/chainsaw/pkg/engine/operations/script"
 	"github.com/kyverno/chainsaw/pkg/engine/outputs"
 	enginecontext "github.com/kyverno/chainsaw/pkg/runner/context"
 )
 
 type scriptAction struct {
-	op v1alpha1.Script
+	op      v1alpha1.Script
+	cleaner cleaner.CleanerCollector
 }
 
 func (o scriptAction) Execute(ctx context.Context, tc enginecontext.TestContext) (outputs.Outputs, error) {
@@ -34,6 +36,7 @@ func (o scriptAction) Execute(ctx context.Context, tc enginecontext.TestContext)
 			tc.BasePath(),
 			ns,
 			config,
+			o.cleaner,
 		)
 		ctx, cancel := context.WithTimeout(ctx, tc.Timeouts().Exec)
 		defer cancel()
@@ -41,8 +44,9 @@ func (o scriptAction) Execute(ctx context.Context, tc enginecontext.TestContext)
 	}
 }
 
-func scriptOperation(op v1alpha1.Script) Operation {
+func scriptOperation(cleaner cleaner.CleanerCollector, op v1alpha1.Script) Operation {
 	return scriptAction{
-		op: op,
+		op:      op,
+		cleaner: cleaner,
 	}
 }
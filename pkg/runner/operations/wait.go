@@ -2,14 +2,11 @@ package operations
 
 import (
 	"context"
-	"time"
 
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
-	"github.com/kyverno/chainsaw/pkg/engine/kubectl"
-	opcommand "github.com/kyverno/chainsaw/pkg/engine/operations/command"
+	opwait "github.com/kyverno/chainsaw/pkg/engine/operations/wait"
 	"github.com/kyverno/chainsaw/pkg/engine/outputs"
 	enginecontext "github.com/kyverno/chainsaw/pkg/runner/context"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type waitAction struct {
@@ -17,10 +14,6 @@ type waitAction struct {
 }
 
 func (o waitAction) Execute(ctx context.Context, tc enginecontext.TestContext) (outputs.Outputs, error) {
-	ns := ""
-	if namespacer := tc.Namespacer(); namespacer != nil {
-		ns = namespacer.GetNamespace()
-	}
 	contextData := enginecontext.ContextData{
 		Cluster:  o.op.Cluster,
 		Clusters: o.op.Clusters,
@@ -28,30 +21,16 @@ func (o waitAction) Execute(ctx context.Context, tc enginecontext.TestContext) (
 	}
 	if tc, err := enginecontext.SetupContextAndBindings(tc, contextData); err != nil {
 		return nil, err
-	} else if config, client, err := tc.CurrentClusterClient(); err != nil {
+	} else if _, client, err := tc.CurrentClusterClient(); err != nil {
 		return nil, err
 	} else {
-		// make sure timeout is set to populate the command flag
-		timeout := tc.Timeouts().Exec
-		o.op.Timeout = &metav1.Duration{Duration: timeout}
-		entrypoint, args, err := kubectl.Wait(ctx, tc.Compilers(), client, tc.Bindings(), &o.op)
-		if err != nil {
-			return nil, err
-		}
-		op := opcommand.New(
+		op := opwait.New(
 			tc.Compilers(),
-			v1alpha1.Command{
-				ActionClusters: o.op.ActionClusters,
-				ActionTimeout:  o.op.ActionTimeout,
-				Entrypoint:     entrypoint,
-				Args:           args,
-			},
-			tc.BasePath(),
-			ns,
-			config,
+			client,
+			tc.Namespacer(),
+			o.op,
 		)
-		// shift operation timeout
-		ctx, cancel := context.WithTimeout(ctx, timeout+30*time.Second)
+		ctx, cancel := context.WithTimeout(ctx, tc.Timeouts().Exec)
 		defer cancel()
 		return op.Exec(ctx, tc.Bindings())
 	}
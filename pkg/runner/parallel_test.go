@@ -0,0 +1,143 @@
+package runner
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/logging"
+	fakeLogger "github.com/kyverno/chainsaw/pkg/mocks"
+	"github.com/kyverno/chainsaw/pkg/model"
+	enginecontext "github.com/kyverno/chainsaw/pkg/runner/context"
+	"github.com/kyverno/chainsaw/pkg/runner/mocks"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/clock"
+)
+
+func Test_runner_parallel(t *testing.T) {
+	script := func(content string, outputs ...string) *v1alpha1.Script {
+		var out []v1alpha1.Output
+		for _, name := range outputs {
+			out = append(out, v1alpha1.Output{
+				Binding: v1alpha1.Binding{
+					Name:  v1alpha1.Expression(name),
+					Value: v1alpha1.NewProjection("($stdout)"),
+				},
+			})
+		}
+		return &v1alpha1.Script{
+			ActionOutputs: v1alpha1.ActionOutputs{Outputs: out},
+			Content:       content,
+		}
+	}
+	value := func(t *testing.T, tc enginecontext.TestContext, name string) any {
+		t.Helper()
+		binding, err := tc.Bindings().Get("$" + name)
+		assert.NoError(t, err)
+		if err != nil {
+			return nil
+		}
+		value, err := binding.Value()
+		assert.NoError(t, err)
+		return value
+	}
+	ctx := logging.WithLogger(context.Background(), &fakeLogger.Logger{})
+	tc := enginecontext.MakeContext(clock.RealClock{}, apis.NewBindings(), mocks.Registry{}).
+		WithTimeouts(v1alpha1.Timeouts{Exec: &metav1.Duration{Duration: 5 * time.Second}})
+	t.Run("operations run concurrently", func(t *testing.T) {
+		operation := v1alpha1.Operation{
+			Parallel: []v1alpha1.Operation{
+				{Script: script("sleep 1")},
+				{Script: script("sleep 1")},
+				{Script: script("sleep 1")},
+			},
+		}
+		report := &model.StepReport{}
+		runner := runner{}
+		start := time.Now()
+		_, _, err := runner.runOperation(ctx, tc, operation, 0, nil, report)
+		assert.NoError(t, err)
+		assert.Less(t, time.Since(start), 2500*time.Millisecond)
+		if assert.Len(t, report.Operations, 3) {
+			// reports are named after the operation position in the group
+			assert.Equal(t, "parallel[0]", report.Operations[0].Name)
+			assert.Equal(t, "parallel[1]", report.Operations[1].Name)
+			assert.Equal(t, "parallel[2]", report.Operations[2].Name)
+		}
+	})
+	t.Run("outputs", func(t *testing.T) {
+		operation := v1alpha1.Operation{
+			Parallel: []v1alpha1.Operation{
+				{Script: script("sleep 1; echo -n a", "first", "shared")},
+				{Script: script("echo -n b", "second", "shared")},
+			},
+		}
+		runner := runner{}
+		_, tc, err := runner.runOperation(ctx, tc, operation, 0, nil, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "a", value(t, tc, "first"))
+		assert.Equal(t, "b", value(t, tc, "second"))
+		// the last operation declared wins, regardless of completion order
+		assert.Equal(t, "b", value(t, tc, "shared"))
+	})
+	t.Run("errors", func(t *testing.T) {
+		operation := v1alpha1.Operation{
+			OperationBase: v1alpha1.OperationBase{
+				ContinueOnError: new(true),
+			},
+			Parallel: []v1alpha1.Operation{
+				{Script: script("exit 1")},
+				{Script: script("sleep 1")},
+				{Script: script("exit 2")},
+			},
+		}
+		report := &model.StepReport{}
+		runner := runner{}
+		continueOnError, _, err := runner.runOperation(ctx, tc, operation, 0, nil, report)
+		assert.Error(t, err)
+		assert.True(t, continueOnError)
+		assert.Contains(t, err.Error(), "exit status 1")
+		assert.Contains(t, err.Error(), "exit status 2")
+		assert.Len(t, report.Operations, 3)
+		assert.Error(t, report.Operations[0].Err)
+		assert.NoError(t, report.Operations[1].Err)
+		assert.Error(t, report.Operations[2].Err)
+	})
+	t.Run("condition", func(t *testing.T) {
+		operation := v1alpha1.Operation{
+			Parallel: []v1alpha1.Operation{
+				{Script: script("echo -n a", "first")},
+				{OperationBase: v1alpha1.OperationBase{If: "(`false`)"}, Script: script("exit 1")},
+			},
+		}
+		report := &model.StepReport{}
+		runner := runner{}
+		_, _, err := runner.runOperation(ctx, tc, operation, 0, nil, report)
+		assert.NoError(t, err)
+		assert.Len(t, report.Operations, 2)
+		assert.False(t, report.Operations[0].Skipped)
+		assert.True(t, report.Operations[1].Skipped)
+		assert.Equal(t, model.OperationTypeScript, report.Operations[1].Type)
+		assert.Equal(t, "parallel[1]", report.Operations[1].Name)
+	})
+	t.Run("catch", func(t *testing.T) {
+		operation := v1alpha1.CatchFinally{
+			Parallel: []v1alpha1.CatchFinally{
+				{Script: script("sleep 1; echo -n a", "first", "shared")},
+				{Script: script("sleep 1; echo -n b", "second", "shared")},
+				{Script: script("exit 1")},
+			},
+		}
+		runner := runner{}
+		start := time.Now()
+		tc, err := runner.runCatch(ctx, tc, operation, 0)
+		assert.Error(t, err)
+		assert.Less(t, time.Since(start), 1500*time.Millisecond)
+		assert.Equal(t, "a", value(t, tc, "first"))
+		assert.Equal(t, "b", value(t, tc, "second"))
+		assert.Equal(t, "b", value(t, tc, "shared"))
+	})
+}
@@ -0,0 +1,77 @@
+package runner
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	apibindings "github.com/kyverno/chainsaw/pkg/engine/bindings"
+	"github.com/kyverno/chainsaw/pkg/engine/checks"
+	"github.com/kyverno/chainsaw/pkg/engine/outputs"
+	enginecontext "github.com/kyverno/chainsaw/pkg/runner/context"
+)
+
+const defaultRetryDelay = time.Second
+
+func retryAttempts(retry *v1alpha1.Retry) int {
+	if retry == nil || retry.Attempts < 1 {
+		return 1
+	}
+	return retry.Attempts
+}
+
+// retryDelay returns the delay to wait before the given retry (starting at 1).
+func retryDelay(backoff *v1alpha1.Backoff, retry int) time.Duration {
+	delay := defaultRetryDelay
+	if backoff == nil {
+		return delay
+	}
+	if backoff.Delay != nil {
+		delay = backoff.Delay.Duration
+	}
+	maxDelay := time.Duration(math.MaxInt64)
+	if backoff.MaxDelay != nil {
+		maxDelay = backoff.MaxDelay.Duration
+	}
+	switch backoff.Policy {
+	case v1alpha1.BackoffPolicyLinear:
+		if delay > 0 && delay > maxDelay/time.Duration(retry) {
+			return maxDelay
+		}
+		delay *= time.Duration(retry)
+	case v1alpha1.BackoffPolicyExponential:
+		for i := 1; i < retry && delay < maxDelay; i++ {
+			if delay > maxDelay/2 {
+				return maxDelay
+			}
+			delay *= 2
+		}
+	}
+	return min(delay, maxDelay)
+}
+
+// shouldRetry evaluates the retry condition against a failed attempt.
+func shouldRetry(ctx context.Context, tc enginecontext.TestContext, retry *v1alpha1.Retry, err error, outputs outputs.Outputs) (bool, error) {
+	if retry.RetryOn == nil || retry.RetryOn.IsNil() {
+		return true, nil
+	}
+	bindings := apibindings.RegisterBinding(tc.Bindings(), "error", err.Error())
+	bindings = apibindings.RegisterBinding(bindings, "outputs", outputs)
+	errs, err := checks.Check(ctx, tc.Compilers(), nil, bindings, retry.RetryOn)
+	if err != nil {
+		return false, err
+	}
+	return len(errs) == 0, nil
+}
+
+func waitForRetry(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,260 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/logging"
+	fakeLogger "github.com/kyverno/chainsaw/pkg/mocks"
+	"github.com/kyverno/chainsaw/pkg/model"
+	enginecontext "github.com/kyverno/chainsaw/pkg/runner/context"
+	"github.com/kyverno/chainsaw/pkg/runner/mocks"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/clock"
+)
+
+func Test_retryDelay(t *testing.T) {
+	tests := []struct {
+		name    string
+		backoff *v1alpha1.Backoff
+		retry   int
+		want    time.Duration
+	}{{
+		name:  "default",
+		retry: 3,
+		want:  time.Second,
+	}, {
+		name:    "constant",
+		backoff: &v1alpha1.Backoff{Delay: &metav1.Duration{Duration: 2 * time.Second}},
+		retry:   3,
+		want:    2 * time.Second,
+	}, {
+		name:    "linear",
+		backoff: &v1alpha1.Backoff{Policy: v1alpha1.BackoffPolicyLinear, Delay: &metav1.Duration{Duration: 2 * time.Second}},
+		retry:   3,
+		want:    6 * time.Second,
+	}, {
+		name:    "exponential",
+		backoff: &v1alpha1.Backoff{Policy: v1alpha1.BackoffPolicyExponential, Delay: &metav1.Duration{Duration: 2 * time.Second}},
+		retry:   3,
+		want:    8 * time.Second,
+	}, {
+		name:    "exponential first retry",
+		backoff: &v1alpha1.Backoff{Policy: v1alpha1.BackoffPolicyExponential, Delay: &metav1.Duration{Duration: 2 * time.Second}},
+		retry:   1,
+		want:    2 * time.Second,
+	}, {
+		name: "exponential with max delay",
+		backoff: &v1alpha1.Backoff{
+			Policy:   v1alpha1.BackoffPolicyExponential,
+			Delay:    &metav1.Duration{Duration: 2 * time.Second},
+			MaxDelay: &metav1.Duration{Duration: 5 * time.Second},
+		},
+		retry: 3,
+		want:  5 * time.Second,
+	}, {
+		name:    "exponential overflow",
+		backoff: &v1alpha1.Backoff{Policy: v1alpha1.BackoffPolicyExponential},
+		retry:   100,
+		want:    time.Duration(1<<63 - 1),
+	}, {
+		name: "linear with max delay",
+		backoff: &v1alpha1.Backoff{
+			Policy:   v1alpha1.BackoffPolicyLinear,
+			Delay:    &metav1.Duration{Duration: 2 * time.Second},
+			MaxDelay: &metav1.Duration{Duration: 5 * time.Second},
+		},
+		retry: 3,
+		want:  5 * time.Second,
+	}, {
+		name: "constant with max delay",
+		backoff: &v1alpha1.Backoff{
+			Delay:    &metav1.Duration{Duration: 10 * time.Second},
+			MaxDelay: &metav1.Duration{Duration: 5 * time.Second},
+		},
+		retry: 1,
+		want:  5 * time.Second,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := retryDelay(tt.backoff, tt.retry)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_runner_runOperation_retry(t *testing.T) {
+	noDelay := &v1alpha1.Backoff{Delay: &metav1.Duration{}}
+	tests := []struct {
+		name         string
+		retry        *v1alpha1.Retry
+		wantErr      bool
+		wantAttempts int
+	}{{
+		name:         "no retry",
+		wantErr:      true,
+		wantAttempts: 1,
+	}, {
+		name:         "succeeds after retries",
+		retry:        &v1alpha1.Retry{Attempts: 3, Backoff: noDelay},
+		wantAttempts: 3,
+	}, {
+		name:         "not enough attempts",
+		retry:        &v1alpha1.Retry{Attempts: 2, Backoff: noDelay},
+		wantErr:      true,
+		wantAttempts: 2,
+	}, {
+		name: "retry on matches",
+		retry: &v1alpha1.Retry{
+			Attempts: 3,
+			Backoff:  noDelay,
+			RetryOn:  new(v1alpha1.NewCheck(map[string]any{"($error != null)": true})),
+		},
+		wantAttempts: 3,
+	}, {
+		name: "retry on doesn't match",
+		retry: &v1alpha1.Retry{
+			Attempts: 3,
+			Backoff:  noDelay,
+			RetryOn:  new(v1alpha1.NewCheck(map[string]any{"($error == null)": true})),
+		},
+		wantErr:      true,
+		wantAttempts: 1,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the script fails until it has been executed three times
+			counter := filepath.Join(t.TempDir(), "counter")
+			operation := v1alpha1.Operation{
+				OperationBase: v1alpha1.OperationBase{
+					Retry: tt.retry,
+				},
+				Script: &v1alpha1.Script{
+					Content: fmt.Sprintf("echo -n x >> %[1]s && test $(wc -c < %[1]s) -ge 3", counter),
+				},
+			}
+			ctx := logging.WithLogger(context.Background(), &fakeLogger.Logger{})
+			tc := enginecontext.MakeContext(clock.RealClock{}, apis.NewBindings(), mocks.Registry{}).
+				WithTimeouts(v1alpha1.Timeouts{Exec: &metav1.Duration{Duration: 5 * time.Second}})
+			report := &model.StepReport{}
+			runner := runner{}
+			_, _, err := runner.runOperation(ctx, tc, operation, 0, nil, report)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Len(t, report.Operations, 1)
+			assert.Len(t, report.Operations[0].Attempts, tt.wantAttempts)
+			assert.Equal(t, tt.wantErr, report.Failed())
+		})
+	}
+}
+
+func Test_runner_runStep_retry(t *testing.T) {
+	noDelay := &v1alpha1.Backoff{Delay: &metav1.Duration{}}
+	tests := []struct {
+		name         string
+		retry        *v1alpha1.Retry
+		wantFailed   bool
+		wantAttempts int
+	}{{
+		name:         "no retry",
+		wantFailed:   true,
+		wantAttempts: 1,
+	}, {
+		name:         "succeeds after retries",
+		retry:        &v1alpha1.Retry{Attempts: 3, Backoff: noDelay},
+		wantAttempts: 3,
+	}, {
+		name:         "not enough attempts",
+		retry:        &v1alpha1.Retry{Attempts: 2, Backoff: noDelay},
+		wantFailed:   true,
+		wantAttempts: 2,
+	}, {
+		name: "retry on doesn't match",
+		retry: &v1alpha1.Retry{
+			Attempts: 3,
+			Backoff:  noDelay,
+			RetryOn:  new(v1alpha1.NewCheck(map[string]any{"($error == null)": true})),
+		},
+		wantFailed:   true,
+		wantAttempts: 1,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			// the try block fails until it has been executed three times, catch, finally and cleanup record their executions
+			counter := filepath.Join(dir, "counter")
+			record := func(file string) []v1alpha1.CatchFinally {
+				return []v1alpha1.CatchFinally{{
+					Script: &v1alpha1.Script{
+						Content: fmt.Sprintf("echo -n x >> %s", filepath.Join(dir, file)),
+					},
+				}}
+			}
+			step := v1alpha1.TestStep{
+				TestStepSpec: v1alpha1.TestStepSpec{
+					Retry: tt.retry,
+					Try: []v1alpha1.Operation{{
+						Script: &v1alpha1.Script{
+							Content: fmt.Sprintf("echo -n x >> %[1]s && test $(wc -c < %[1]s) -ge 3", counter),
+						},
+					}},
+					Catch:   record("catch"),
+					Finally: record("finally"),
+					Cleanup: record("cleanup"),
+				},
+			}
+			ctx := logging.WithLogger(context.Background(), &fakeLogger.Logger{})
+			tc := enginecontext.MakeContext(clock.RealClock{}, apis.NewBindings(), mocks.Registry{}).
+				WithTimeouts(v1alpha1.Timeouts{Exec: &metav1.Duration{Duration: 5 * time.Second}})
+			report := &model.TestReport{}
+			var cleanups []func()
+			failed := false
+			runner := runner{}
+			stop := runner.runStep(ctx, func(fn func()) { cleanups = append(cleanups, fn) }, func() { failed = true }, func() bool { return failed }, tc, step, report)
+			assert.Equal(t, tt.wantFailed, stop)
+			assert.Equal(t, tt.wantFailed, failed)
+			executions := func(file string) int {
+				data, _ := os.ReadFile(filepath.Join(dir, file))
+				return len(data)
+			}
+			// catch runs for every failed attempt, finally for every attempt
+			if tt.wantFailed {
+				assert.Equal(t, tt.wantAttempts, executions("catch"))
+			} else {
+				assert.Equal(t, tt.wantAttempts-1, executions("catch"))
+			}
+			assert.Equal(t, tt.wantAttempts, executions("finally"))
+			// cleanup of retried attempts runs before the next attempt, the last one is left to the test
+			assert.Equal(t, tt.wantAttempts-1, executions("cleanup"))
+			assert.Len(t, cleanups, 1)
+			for _, cleanup := range cleanups {
+				cleanup()
+			}
+			assert.Equal(t, tt.wantAttempts, executions("cleanup"))
+			// the step report comes first, followed by the cleanup report of every attempt
+			if assert.Len(t, report.Steps, tt.wantAttempts+1) {
+				for _, cleanup := range report.Steps[1:] {
+					assert.Equal(t, "cleanup (step 1)", cleanup.Name)
+				}
+				step := report.Steps[0]
+				assert.Len(t, step.Attempts, tt.wantAttempts)
+				assert.Equal(t, tt.wantFailed, step.Failed())
+				// operations are the ones of the last attempt
+				assert.Len(t, step.Operations, 1)
+				for i, attempt := range step.Attempts {
+					assert.Equal(t, tt.wantFailed || i < len(step.Attempts)-1, attempt.Err != nil)
+				}
+			}
+		})
+	}
+}
@@ -3,6 +3,11 @@ package runner
 import (
 	"context"
 	"fmt"
+	"maps"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"sync"
 	"testing"
 	"time"
 
@@ -11,6 +16,7 @@ import (
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha2"
 	"github.com/kyverno/chainsaw/pkg/cleanup/cleaner"
 	"github.com/kyverno/chainsaw/pkg/client"
+	"github.com/kyverno/chainsaw/pkg/diagnostics"
 	"github.com/kyverno/chainsaw/pkg/discovery"
 	"github.com/kyverno/chainsaw/pkg/engine/namespacer"
 	"github.com/kyverno/chainsaw/pkg/logging"
@@ -27,7 +33,7 @@ import (
 )
 
 type Runner interface {
-	Run(context.Context, v1alpha2.NamespaceOptions, enginecontext.TestContext, ...discovery.Test) error
+	Run(context.Context, v1alpha2.NamespaceOptions, Hooks, enginecontext.TestContext, ...discovery.Test) error
 }
 
 func New(clock clock.PassiveClock, onFailure func()) Runner {
@@ -43,11 +49,11 @@ type runner struct {
 	deps      *internal.TestDeps
 }
 
-func (r *runner) Run(ctx context.Context, nsOptions v1alpha2.NamespaceOptions, tc enginecontext.TestContext, tests ...discovery.Test) error {
-	return r.run(ctx, nil, nsOptions, tc, tests...)
+func (r *runner) Run(ctx context.Context, nsOptions v1alpha2.NamespaceOptions, hooks Hooks, tc enginecontext.TestContext, tests ...discovery.Test) error {
+	return r.run(ctx, nil, nsOptions, hooks, tc, tests...)
 }
 
-func (r *runner) run(ctx context.Context, m mainstart, nsOptions v1alpha2.NamespaceOptions, tc enginecontext.TestContext, tests ...discovery.Test) error {
+func (r *runner) run(ctx context.Context, m mainstart, nsOptions v1alpha2.NamespaceOptions, hooks Hooks, tc enginecontext.TestContext, tests ...discovery.Test) error {
 	defer func() {
 		tc.EndTime = time.Now()
 	}()
@@ -83,8 +89,45 @@ func (r *runner) run(ctx context.Context, m mainstart, nsOptions v1alpha2.Namesp
 				tc.IncFailed()
 				return
 			}
-			// loop through tests
-			for i := range tests {
+			acquireNamespace(t.Cleanup, tc)
+			// run suite setup, outputs are available to all tests
+			setupFailed := false
+			if hooks.Setup != nil {
+				ctx := logging.WithLogger(ctx, logging.NewLogger(t.Name(), "", "@setup"))
+				tc, setupFailed = r.runHook(ctx, t.Cleanup, t.Fail, tc, "@setup", *hooks.Setup)
+				if setupFailed {
+					tc.IncFailed()
+				}
+			}
+			// run suite teardown once all tests are done
+			if hooks.Teardown != nil {
+				t.Cleanup(func() {
+					ctx := logging.WithLogger(uninterruptible(ctx), logging.NewLogger(t.Name(), "", "@teardown"))
+					if _, failed := r.runHook(ctx, t.Cleanup, t.Fail, tc, "@teardown", *hooks.Teardown); failed {
+						tc.IncFailed()
+					}
+				})
+			}
+			if setupFailed {
+				return
+			}
+			// tests involved in a dependency cycle can't wait for their dependencies
+			cycles := dependencyCycles(tests)
+			var cycleErr error
+			if len(cycles) != 0 {
+				cycleErr = cycleError(tests, cycles)
+			}
+			// the scheduler enforces the concurrency limit, tests don't use the testing framework parallelism
+			// because tests waiting for their dependencies would hold a slot and could prevent their dependencies from running
+			limit := tc.Parallel()
+			if limit <= 0 {
+				limit = runtime.GOMAXPROCS(0)
+			}
+			scheduler := newScheduler(limit, tests)
+			var results testResults
+			// helper to run a test and its scenarios
+			runTests := func(t *testing.T, i int) {
+				t.Helper()
 				test := tests[i]
 				name, err := names.Test(test, tc.FullName())
 				if err != nil {
@@ -92,6 +135,9 @@ func (r *runner) run(ctx context.Context, m mainstart, nsOptions v1alpha2.Namesp
 					tc.IncFailed()
 					logging.Log(ctx, logging.Internal, logging.ErrorStatus, nil, color.BoldRed, logging.ErrSection(err))
 					r.onFail()
+					if test.Test != nil {
+						scheduler.Done(test.Test.Name, max(1, len(test.Test.Spec.Scenarios)), false)
+					}
 				} else {
 					// setup logger
 					size := len("@chainsaw")
@@ -105,9 +151,11 @@ func (r *runner) run(ctx context.Context, m mainstart, nsOptions v1alpha2.Namesp
 						t.Helper()
 						// setup logger sink
 						ctx = logging.WithSink(ctx, newSink(r.clock, tc.Quiet(), t.Log))
-						// setup concurrency
-						if test.Test.Spec.Concurrent == nil || *test.Test.Spec.Concurrent {
-							t.Parallel()
+						// setup concurrency, non concurrent tests are started by the scheduler when no other test runs
+						concurrent := test.Test.Spec.Concurrent == nil || *test.Test.Spec.Concurrent
+						cyclic := slices.Contains(cycles, i)
+						if !cyclic {
+							scheduler.Start(!concurrent, test.Test.Spec.DependsOn...)
 						}
 						// setup reporting
 						report := &model.TestReport{
@@ -130,12 +178,38 @@ func (r *runner) run(ctx context.Context, m mainstart, nsOptions v1alpha2.Namesp
 							} else {
 								tc.IncPassed()
 							}
+							results.Add(test.Test.Name, !t.Skipped() && !t.Failed())
+							scheduler.Done(test.Test.Name, 1, !cyclic)
 						})
+						// dependency cycle check
+						if cyclic {
+							report.Err = cycleErr
+							t.Fail()
+							logging.Log(ctx, logging.Internal, logging.ErrorStatus, nil, color.BoldRed, logging.ErrSection(cycleErr))
+							r.onFail()
+							return
+						}
 						// skip check
 						if test.Test.Spec.Skip != nil && *test.Test.Spec.Skip {
 							t.SkipNow()
 							return
 						}
+						// interruption check
+						if ctx.Err() != nil {
+							report.SkipReason = "interrupted"
+							logging.Log(ctx, logging.Internal, logging.SkippedStatus, nil, color.BoldYellow, logging.Section("INTERRUPTED", "test didn't start"))
+							t.SkipNow()
+							return
+						}
+						// dependencies check
+						if len(test.Test.Spec.DependsOn) != 0 {
+							if reason := results.Check(test.Test.Spec.DependsOn...); reason != "" {
+								report.SkipReason = reason
+								logging.Log(ctx, logging.Internal, logging.SkippedStatus, nil, color.BoldYellow, logging.Section("DEPENDS ON", reason))
+								t.SkipNow()
+								return
+							}
+						}
 						// setup context
 						tc, err := r.setupTestContext(ctx, testId, scenarioId, tc, test, bindings...)
 						if fail(t, err) {
@@ -173,6 +247,21 @@ func (r *runner) run(ctx context.Context, m mainstart, nsOptions v1alpha2.Namesp
 						if fail(t, err) {
 							return
 						}
+						acquireNamespace(t.Cleanup, tc)
+						// setup diagnostics, collected before cleanup happens
+						// the directory is prefixed with the test id, tests from different folders can have the same name
+						if path := tc.DiagnosticsPath(); path != "" {
+							tc = tc.WithDiagnostics(diagnostics.NewCollector())
+							defer func() {
+								if t.Failed() {
+									dir := fmt.Sprintf("%d-%s", testId, test.Test.Name)
+									if scenarioId != 0 {
+										dir = fmt.Sprintf("%s-%d", dir, scenarioId)
+									}
+									r.collectDiagnostics(ctx, tc, filepath.Join(path, dir), report)
+								}
+							}()
+						}
 						// setup bindings
 						tc, err = enginecontext.SetupBindings(tc, test.Test.Spec.Bindings...)
 						if err != nil {
@@ -188,32 +277,65 @@ func (r *runner) run(ctx context.Context, m mainstart, nsOptions v1alpha2.Namesp
 								Id: i + 1,
 							}
 							tc := tc.WithBinding("step", info)
+							if ctx.Err() != nil {
+								t.Fail()
+								report.Interrupted = true
+								logging.Log(ctx, logging.Internal, logging.ErrorStatus, nil, color.BoldRed, logging.Section("INTERRUPTED", "remaining steps didn't run"))
+								return
+							}
 							if stop := r.runStep(ctx, t.Cleanup, t.Fail, t.Failed, tc, step, report); stop {
 								return
 							}
 						}
 					}
-					// run test scenarios
+					// run test scenarios, runs filtered out by the testing framework are done without starting
 					testId := i + 1
 					if len(test.Test.Spec.Scenarios) == 0 {
 						ctx := logging.WithLogger(ctx, logging.NewLogger(test.Test.Name, "", fmt.Sprintf("%-*s", size, "@chainsaw")))
+						started := false
 						t.Run(name, func(t *testing.T) {
 							t.Helper()
+							started = true
 							runTest(ctx, t, testId, 0, "", tc)
 						})
+						if !started {
+							scheduler.Done(test.Test.Name, 1, false)
+						}
 					} else {
 						for s, scenario := range test.Test.Spec.Scenarios {
 							scenarioId := s + 1
 							scnearioName := names.Scenario(scenario, s)
 							ctx := logging.WithLogger(ctx, logging.NewLogger(test.Test.Name, scnearioName, fmt.Sprintf("%-*s", size, "@chainsaw")))
+							started := false
 							t.Run(name, func(t *testing.T) {
 								t.Helper()
+								started = true
 								runTest(ctx, t, testId, scenarioId, scnearioName, tc, test.Test.Spec.Scenarios[s].Bindings...)
 							})
+							if !started {
+								scheduler.Done(test.Test.Name, 1, false)
+							}
 						}
 					}
 				}
 			}
+			// run non concurrent tests without dependencies first, one after the other
+			var others []int
+			for i, test := range tests {
+				if test.Test != nil && test.Test.Spec.Concurrent != nil && !*test.Test.Spec.Concurrent && len(test.Test.Spec.DependsOn) == 0 {
+					runTests(t, i)
+				} else {
+					others = append(others, i)
+				}
+			}
+			// run other tests concurrently, the scheduler starts them when their dependencies are done
+			var wg sync.WaitGroup
+			for _, i := range others {
+				wg.Go(func() {
+					runTests(t, i)
+				})
+			}
+			wg.Wait()
 		},
 	}}
 	deps := r.deps
@@ -244,17 +366,65 @@ func (r *runner) runStep(
 	step v1alpha1.TestStep,
 	testReport *model.TestReport,
 ) bool {
+	if step.ForEach == nil {
+		stop, _ := r.runStepItem(ctx, cleanup, fail, failed, tc, step, step.Name, testReport)
+		return stop
+	}
+	if step.Compiler != nil {
+		tc = tc.WithDefaultCompiler(string(*step.Compiler))
+	}
+	items, err := forEachItems(ctx, tc, *step.ForEach)
+	if err != nil {
+		fail()
+		logging.Log(ctx, logging.Internal, logging.ErrorStatus, nil, color.BoldRed, logging.ErrSection(err))
+		r.onFail()
+		return true
+	}
+	for i, item := range items {
+		name := step.Name
+		if name != "" {
+			name = fmt.Sprintf("%s[%d]", name, i)
+		}
+		if stop, _ := r.runStepItem(ctx, cleanup, fail, failed, withItem(tc, i, item), step, name, testReport); stop {
+			return true
+		}
+	}
+	return false
+}
+
+// runStepItem runs a step, it returns whether the test must stop and the context resulting from the try operations.
+func (r *runner) runStepItem(
+	ctx context.Context,
+	cleanup func(func()),
+	fail func(),
+	failed func() bool,
+	tc enginecontext.TestContext,
+	step v1alpha1.TestStep,
+	name string,
+	testReport *model.TestReport,
+) (bool, enginecontext.TestContext) {
 	report := &model.StepReport{
-		Name:      step.Name,
+		Name:      name,
 		StartTime: time.Now(),
 	}
+	// the report is added first, cleanup reports of retried attempts are named after it
+	testReport.Add(report)
 	defer func() {
 		report.EndTime = time.Now()
-		testReport.Add(report)
 	}()
 	if step.Compiler != nil {
 		tc = tc.WithDefaultCompiler(string(*step.Compiler))
 	}
+	if ok, err := checkCondition(ctx, tc, step.If); err != nil {
+		fail()
+		logging.Log(ctx, logging.Internal, logging.ErrorStatus, nil, color.BoldRed, logging.ErrSection(err))
+		r.onFail()
+		return true, tc
+	} else if !ok {
+		report.Skipped = true
+		logging.Log(ctx, logging.Try, logging.SkippedStatus, nil, color.BoldYellow, logging.Section("IF", step.If))
+		return false, tc
+	}
 	contextData := enginecontext.ContextData{
 		Catch:               step.Catch,
 		Cluster:             step.Cluster,
@@ -269,13 +439,121 @@ func (r *runner) runStep(
 		fail()
 		logging.Log(ctx, logging.Internal, logging.ErrorStatus, nil, color.BoldRed, logging.ErrSection(err))
 		r.onFail()
-		return true
+		return true, tc
+	}
+	attempts := retryAttempts(step.Retry)
+	for attempt := 1; ; attempt++ {
+		if attempt == attempts {
+			attemptReport := &model.StepReport{}
+			startTime := time.Now()
+			stop, tc, err := r.runStepAttempt(ctx, cleanup, fail, failed, tc, step, report.Name, attemptReport, testReport)
+			report.Operations = attemptReport.Operations
+			report.AddAttempt(&model.AttemptReport{StartTime: startTime, EndTime: time.Now(), Err: err})
+			return stop, tc
+		}
+		// attempts that can be retried don't fail the test until their outcome is settled, their cleanup runs before the next attempt
+		var failedAttempt, settled bool
+		var cleanups []func()
+		attemptRunner := &runner{
+			clock: r.clock,
+			deps:  r.deps,
+			onFailure: func() {
+				if settled {
+					r.onFail()
+				}
+			},
+		}
+		attemptFail := func() {
+			if settled {
+				fail()
+			} else {
+				failedAttempt = true
+			}
+		}
+		attemptReport := &model.StepReport{}
+		startTime := time.Now()
+		stop, outputsTc, err := attemptRunner.runStepAttempt(
+			ctx,
+			func(fn func()) { cleanups = append(cleanups, fn) },
+			attemptFail,
+			func() bool { return failedAttempt || failed() },
+			tc,
+			step,
+			report.Name,
+			attemptReport,
+			testReport,
+		)
+		settled = true
+		report.Operations = attemptReport.Operations
+		report.AddAttempt(&model.AttemptReport{StartTime: startTime, EndTime: time.Now(), Err: err})
+		retry := failedAttempt && ctx.Err() == nil
+		if retry {
+			if ok, err := shouldRetry(ctx, tc, step.Retry, err, nil); err != nil {
+				logging.Log(ctx, logging.Retry, logging.ErrorStatus, nil, color.BoldRed, logging.ErrSection(err))
+				retry = false
+			} else {
+				retry = ok
+			}
+		}
+		if retry {
+			delay := retryDelay(step.Retry.Backoff, attempt)
+			logging.Log(ctx, logging.Retry, logging.WarnStatus, nil, color.BoldYellow, logging.Section("RETRY", fmt.Sprintf("step attempt %d/%d failed, retrying in %v", attempt, attempts, delay)))
+			// cleanup runs in reverse order, the same as test cleanup
+			for i := len(cleanups) - 1; i >= 0; i-- {
+				cleanups[i]()
+			}
+			if err := waitForRetry(ctx, delay); err == nil {
+				continue
+			}
+			testReport.Interrupted = true
+			cleanups = nil
+		}
+		if failedAttempt {
+			fail()
+			r.onFail()
+		}
+		for _, fn := range cleanups {
+			cleanup(fn)
+		}
+		return stop, outputsTc
 	}
+}
+
+// runStepAttempt runs the try, catch and finally blocks of a step and registers its cleanup.
+// It returns whether the test must stop, the context resulting from the try operations and the errors of the attempt.
+func (r *runner) runStepAttempt(
+	ctx context.Context,
+	cleanup func(func()),
+	fail func(),
+	failed func() bool,
+	tc enginecontext.TestContext,
+	step v1alpha1.TestStep,
+	name string,
+	report *model.StepReport,
+	testReport *model.TestReport,
+) (_ bool, _ enginecontext.TestContext, _err error) {
+	// errors are collected until deferred catch, finally and release are done
+	var errs []error
+	defer func() {
+		_err = multierr.Combine(errs...)
+	}()
 	cleaner := cleaner.New(tc.Timeouts().Cleanup, true, tc.DelayBeforeCleanup(), tc.DeletionPropagation())
+	// background processes and port forwards don't outlive the step, they are stopped once try, catch and finally are done
+	defer func() {
+		if released := cleaner.Release(uninterruptible(ctx), report, model.OperationTypeCommand, model.OperationTypeScript, model.OperationTypePortForward); len(released) != 0 {
+			fail()
+			for _, err := range released {
+				logging.Log(ctx, logging.Cleanup, logging.ErrorStatus, nil, color.BoldRed, logging.ErrSection(err))
+			}
+			errs = append(errs, released...)
+			r.onFail()
+		}
+	}()
 	cleanup(func() {
+		ctx := uninterruptible(ctx)
 		if !cleaner.Empty() || len(step.Cleanup) != 0 {
 			report := &model.StepReport{
-				Name:      fmt.Sprintf("cleanup (%s)", report.Name),
+				Name:      fmt.Sprintf("cleanup (%s)", name),
 				StartTime: time.Now(),
 			}
 			defer func() {
@@ -309,6 +587,7 @@ func (r *runner) runStep(
 	})
 	if len(step.Finally) != 0 {
 		defer func() {
+			ctx := uninterruptible(ctx)
 			logging.Log(ctx, logging.Finally, logging.BeginStatus, nil, color.BoldFgCyan)
 			defer func() {
 				logging.Log(ctx, logging.Finally, logging.EndStatus, nil, color.BoldFgCyan)
@@ -320,6 +599,7 @@ func (r *runner) runStep(
 				outputsTc, err := r.runCatch(ctx, tc, operation, i)
 				if err != nil {
 					fail()
+					errs = append(errs, err)
 				}
 				tc = outputsTc
 			}
@@ -328,6 +608,7 @@ func (r *runner) runStep(
 	if catch := tc.Catch(); len(catch) != 0 {
 		defer func() {
 			if failed() {
+				ctx := uninterruptible(ctx)
 				logging.Log(ctx, logging.Catch, logging.BeginStatus, nil, color.BoldFgCyan)
 				defer func() {
 					logging.Log(ctx, logging.Catch, logging.EndStatus, nil, color.BoldFgCyan)
@@ -339,6 +620,7 @@ func (r *runner) runStep(
 					outputsTc, err := r.runCatch(ctx, tc, operation, i)
 					if err != nil {
 						fail()
+						errs = append(errs, err)
 					}
 					tc = outputsTc
 				}
@@ -353,13 +635,19 @@ func (r *runner) runStep(
 		continueOnError, outputsTc, err := r.runOperation(ctx, tc, operation, i, cleaner, report)
 		if err != nil {
 			fail()
+			errs = append(errs, err)
+			// the operation was cut short by the interruption
+			if ctx.Err() != nil {
+				testReport.Interrupted = true
+				return true, tc, nil
+			}
 			if !continueOnError {
-				return true
+				return true, tc, nil
 			}
 		}
 		tc = outputsTc
 	}
-	return false
+	return false, tc, nil
 }
 
 func (r *runner) runOperation(
@@ -368,30 +656,213 @@ func (r *runner) runOperation(
 	operation v1alpha1.Operation,
 	operationId int,
 	cleaner cleaner.Cleaner,
-	report *model.StepReport,
+	stepReport *model.StepReport,
 ) (bool, enginecontext.TestContext, error) {
 	if operation.Compiler != nil {
 		tc = tc.WithDefaultCompiler(string(*operation.Compiler))
 	}
+	continueOnError, outputs, err := r.runOperationOutputs(ctx, tc, operation, nil, OperationInfo{Id: operationId + 1}, cleaner, stepReport)
+	for k, v := range outputs {
+		tc = tc.WithBinding(k, v)
+	}
+	return continueOnError, tc, err
+}
+
+// runOperationOutputs runs an operation and returns the outputs it produced.
+// The default retry policy applies when the operation doesn't have its own (operations of a parallel group use the group policy).
+func (r *runner) runOperationOutputs(
+	ctx context.Context,
+	tc enginecontext.TestContext,
+	operation v1alpha1.Operation,
+	defaultRetry *v1alpha1.Retry,
+	info OperationInfo,
+	cleaner cleaner.Cleaner,
+	stepReport *model.StepReport,
+) (bool, map[string]any, error) {
+	if operation.ForEach != nil {
+		return r.runOperationForEach(ctx, tc, operation, defaultRetry, info, cleaner, stepReport)
+	}
+	if ok, err := checkCondition(ctx, tc, operation.If); err != nil {
+		logging.Log(ctx, logging.Try, logging.ErrorStatus, nil, color.BoldRed, logging.ErrSection(err))
+		r.onFail()
+		return false, nil, err
+	} else if !ok {
+		logging.Log(ctx, logging.Try, logging.SkippedStatus, nil, color.BoldYellow, logging.Section("IF", operation.If))
+		if stepReport != nil {
+			now := time.Now()
+			stepReport.Add(&model.OperationReport{
+				Type:      operations.OperationType(operation),
+				StartTime: now,
+				EndTime:   now,
+				Skipped:   true,
+			})
+		}
+		return false, nil, nil
+	}
+	continueOnError := operation.ContinueOnError != nil && *operation.ContinueOnError
+	retry := operation.Retry
+	if retry == nil {
+		retry = defaultRetry
+	}
+	if len(operation.Parallel) != 0 {
+		outputs, err := r.runOperationParallel(ctx, tc, operation.Parallel, retry, info, cleaner, stepReport)
+		return continueOnError, outputs, err
+	}
 	opType, actions, err := operations.TryOperation(ctx, tc, operation, cleaner)
 	if err != nil {
 		logging.Log(ctx, logging.Try, logging.ErrorStatus, nil, color.BoldRed, logging.ErrSection(err))
 		r.onFail()
-		return false, tc, err
+		return false, nil, err
 	}
+	reports := make([]*model.OperationReport, len(actions))
+	if stepReport != nil {
+		for i := range actions {
+			reports[i] = &model.OperationReport{
+				Type: opType,
+			}
+		}
+		defer func() {
+			for _, report := range reports {
+				stepReport.Add(report)
+			}
+		}()
+	}
+	// only actions that failed are executed again on the next attempt
+	pending := make([]int, len(actions))
+	for i := range actions {
+		pending[i] = i
+	}
+	produced := map[string]any{}
+	attempts := retryAttempts(retry)
+	for attempt := 1; ; attempt++ {
+		var failed []int
+		var errs []error
+		for _, i := range pending {
+			outputs, err := r.runAction(ctx, actions[i], info, i, tc, reports[i])
+			if err != nil {
+				if diagnostics := tc.Diagnostics(); diagnostics != nil && (opType == model.OperationTypeAssert || opType == model.OperationTypeError) {
+					diagnostics.AddFailure(err)
+				}
+				if attempt < attempts {
+					if ok, err := shouldRetry(ctx, tc, retry, err, outputs); err != nil {
+						logging.Log(ctx, logging.Retry, logging.ErrorStatus, nil, color.BoldRed, logging.ErrSection(err))
+					} else if ok {
+						failed = append(failed, i)
+					}
+				}
+				errs = append(errs, err)
+			}
+			for k, v := range outputs {
+				tc = tc.WithBinding(k, v)
+				produced[k] = v
+			}
+		}
+		if len(errs) == 0 {
+			return continueOnError, produced, nil
+		}
+		if len(failed) == len(errs) {
+			delay := retryDelay(retry.Backoff, attempt)
+			logging.Log(ctx, logging.Retry, logging.WarnStatus, nil, color.BoldYellow, logging.Section("RETRY", fmt.Sprintf("attempt %d/%d failed, retrying in %v", attempt, attempts, delay)))
+			if err := waitForRetry(ctx, delay); err == nil {
+				pending = failed
+				continue
+			}
+		}
+		for range errs {
+			r.onFail()
+		}
+		return continueOnError, produced, multierr.Combine(errs...)
+	}
+}
+
+// runOperationParallel runs the operations of a parallel group concurrently.
+// Reports, errors and outputs are collected in declaration order once all operations completed,
+// when two operations produce the same output the last one declared wins.
+func (r *runner) runOperationParallel(
+	ctx context.Context,
+	tc enginecontext.TestContext,
+	parallel []v1alpha1.Operation,
+	retry *v1alpha1.Retry,
+	info OperationInfo,
+	cleaner cleaner.Cleaner,
+	stepReport *model.StepReport,
+) (map[string]any, error) {
+	type result struct {
+		report  *model.StepReport
+		outputs map[string]any
+		err     error
+	}
+	results := make([]result, len(parallel))
+	var wg sync.WaitGroup
+	for i, operation := range parallel {
+		if stepReport != nil {
+			results[i].report = &model.StepReport{}
+		}
+		wg.Go(func() {
+			tc := tc
+			if operation.Compiler != nil {
+				tc = tc.WithDefaultCompiler(string(*operation.Compiler))
+			}
+			_, results[i].outputs, results[i].err = r.runOperationOutputs(ctx, tc, operation, retry, info, cleaner, results[i].report)
+		})
+	}
+	wg.Wait()
+	outputs := map[string]any{}
+	var errs []error
+	for i, result := range results {
+		if result.report != nil {
+			stepReport.AddGroup(fmt.Sprintf("parallel[%d]", i), result.report)
+		}
+		if result.err != nil {
+			errs = append(errs, result.err)
+		}
+		maps.Copy(outputs, result.outputs)
+	}
+	return outputs, multierr.Combine(errs...)
+}
+
+// runOperationForEach runs the operation once per item.
+// Iterations run sequentially, outputs produced by an iteration are available to the following iterations and operations.
+func (r *runner) runOperationForEach(
+	ctx context.Context,
+	tc enginecontext.TestContext,
+	operation v1alpha1.Operation,
+	defaultRetry *v1alpha1.Retry,
+	info OperationInfo,
+	cleaner cleaner.Cleaner,
+	stepReport *model.StepReport,
+) (bool, map[string]any, error) {
 	continueOnError := operation.ContinueOnError != nil && *operation.ContinueOnError
+	items, err := forEachItems(ctx, tc, *operation.ForEach)
+	if err != nil {
+		logging.Log(ctx, logging.Try, logging.ErrorStatus, nil, color.BoldRed, logging.ErrSection(err))
+		r.onFail()
+		return false, nil, err
+	}
+	iteration := operation
+	iteration.ForEach = nil
+	outputs := map[string]any{}
 	var errs []error
-	for i, action := range actions {
-		outputs, err := r.runAction(ctx, action, opType, operationId, i, tc, report)
+	for i, item := range items {
+		info := info
+		info.Iteration = i + 1
+		var report *model.StepReport
+		if stepReport != nil {
+			report = &model.StepReport{}
+		}
+		_, produced, err := r.runOperationOutputs(ctx, withItem(tc, i, item), iteration, defaultRetry, info, cleaner, report)
+		if report != nil {
+			stepReport.AddGroup(fmt.Sprintf("forEach[%d]", i), report)
+		}
 		if err != nil {
 			errs = append(errs, err)
-			r.onFail()
 		}
-		for k, v := range outputs {
+		for k, v := range produced {
 			tc = tc.WithBinding(k, v)
+			outputs[k] = v
 		}
 	}
-	return continueOnError, tc, multierr.Combine(errs...)
+	return continueOnError, outputs, multierr.Combine(errs...)
 }
 
 func (r *runner) runCatch(
@@ -403,53 +874,119 @@ func (r *runner) runCatch(
 	if operation.Compiler != nil {
 		tc = tc.WithDefaultCompiler(string(*operation.Compiler))
 	}
+	outputs, err := r.runCatchOutputs(ctx, tc, operation, operationId)
+	for k, v := range outputs {
+		tc = tc.WithBinding(k, v)
+	}
+	return tc, err
+}
+
+// runCatchOutputs runs a catch, finally or cleanup operation and returns the outputs it produced.
+func (r *runner) runCatchOutputs(
+	ctx context.Context,
+	tc enginecontext.TestContext,
+	operation v1alpha1.CatchFinally,
+	operationId int,
+) (map[string]any, error) {
+	if ok, err := checkCondition(ctx, tc, operation.If); err != nil {
+		logging.Log(ctx, logging.Try, logging.ErrorStatus, nil, color.BoldRed, logging.ErrSection(err))
+		r.onFail()
+		return nil, err
+	} else if !ok {
+		logging.Log(ctx, logging.Try, logging.SkippedStatus, nil, color.BoldYellow, logging.Section("IF", operation.If))
+		return nil, nil
+	}
+	if len(operation.Parallel) != 0 {
+		return r.runCatchParallel(ctx, tc, operation.Parallel, operationId)
+	}
 	actions, err := operations.CatchOperation(ctx, tc, operation)
 	if err != nil {
 		logging.Log(ctx, logging.Try, logging.ErrorStatus, nil, color.BoldRed, logging.ErrSection(err))
 		r.onFail()
-		return tc, err
+		return nil, err
 	}
+	produced := map[string]any{}
 	var errs []error
 	for i, action := range actions {
-		outputs, err := r.runAction(ctx, action, "", operationId, i, tc, nil)
+		outputs, err := r.runAction(ctx, action, OperationInfo{Id: operationId + 1}, i, tc, nil)
 		if err != nil {
 			errs = append(errs, err)
 			r.onFail()
 		}
 		for k, v := range outputs {
 			tc = tc.WithBinding(k, v)
+			produced[k] = v
 		}
 	}
-	return tc, multierr.Combine(errs...)
+	return produced, multierr.Combine(errs...)
+}
+
+// runCatchParallel runs the operations of a parallel group concurrently.
+// Errors and outputs are collected in declaration order once all operations completed,
+// when two operations produce the same output the last one declared wins.
+func (r *runner) runCatchParallel(
+	ctx context.Context,
+	tc enginecontext.TestContext,
+	parallel []v1alpha1.CatchFinally,
+	operationId int,
+) (map[string]any, error) {
+	type result struct {
+		outputs map[string]any
+		err     error
+	}
+	results := make([]result, len(parallel))
+	var wg sync.WaitGroup
+	for i, operation := range parallel {
+		wg.Go(func() {
+			tc := tc
+			if operation.Compiler != nil {
+				tc = tc.WithDefaultCompiler(string(*operation.Compiler))
+			}
+			results[i].outputs, results[i].err = r.runCatchOutputs(ctx, tc, operation, operationId)
+		})
+	}
+	wg.Wait()
+	outputs := map[string]any{}
+	var errs []error
+	for _, result := range results {
+		if result.err != nil {
+			errs = append(errs, result.err)
+		}
+		maps.Copy(outputs, result.outputs)
+	}
+	return outputs, multierr.Combine(errs...)
 }
 
 func (*runner) runAction(
 	ctx context.Context,
 	action operations.Operation,
-	opType model.OperationType,
-	operationId int,
+	info OperationInfo,
 	actionId int,
 	tc enginecontext.TestContext,
-	stepReport *model.StepReport,
+	operationReport *model.OperationReport,
 ) (outputs map[string]any, err error) {
-	tc = tc.WithBinding("operation", OperationInfo{
-		Id:         operationId + 1,
-		ResourceId: actionId + 1,
-	})
-	if stepReport != nil {
-		report := &model.OperationReport{
-			Type:      opType,
+	info.ResourceId = actionId + 1
+	tc = tc.WithBinding("operation", info)
+	if operationReport != nil {
+		report := &model.AttemptReport{
 			StartTime: time.Now(),
 		}
 		defer func() {
 			report.EndTime = time.Now()
 			report.Err = err
-			stepReport.Add(report)
+			report.Failures = model.Failures(err)
+			operationReport.Add(report)
 		}()
 	}
 	return action.Execute(ctx, tc)
 }
 
+// uninterruptible returns a context that is not cancelled when the run is interrupted.
+// Catch, finally, cleanup, teardown and diagnostics must run even if tests were interrupted, they are bound by their own timeouts.
+func uninterruptible(ctx context.Context) context.Context {
+	return context.WithoutCancel(ctx)
+}
+
 func (r *runner) onFail() {
 	if r.onFailure != nil {
 		r.onFailure()
@@ -457,6 +994,7 @@ func (r *runner) onFail() {
 }
 
 func (r *runner) cleanup(ctx context.Context, tc enginecontext.TestContext, cleaner cleaner.Cleaner) error {
+	ctx = uninterruptible(ctx)
 	if tc.SkipDelete() {
 		logging.Log(ctx, logging.Cleanup, logging.SkippedStatus, nil, color.BoldYellow)
 		return nil
@@ -516,6 +1054,13 @@ func (r *runner) setupNamespace(ctx context.Context, nsOptions v1alpha2.Namespac
 	return tc, nil
 }
 
+// acquireNamespace marks the namespace of the test context as used until cleanup, informers watching it are released after that.
+func acquireNamespace(cleanup func(func()), tc enginecontext.TestContext) {
+	if informers := tc.Informers(); informers != nil && tc.Namespacer() != nil {
+		cleanup(informers.Acquire(tc.Namespacer().GetNamespace()))
+	}
+}
+
 func (r *runner) setupTestContext(ctx context.Context, testId int, scenarioId int, tc enginecontext.TestContext, test discovery.Test, bindings ...v1alpha1.Binding) (enginecontext.TestContext, error) {
 	tc = tc.WithBinding("test", TestInfo{
 		Id:         testId,
@@ -548,7 +1093,25 @@ func (r *runner) setupTestContext(ctx context.Context, testId int, scenarioId in
 	return tc, err
 }
 
+func (r *runner) collectDiagnostics(ctx context.Context, tc enginecontext.TestContext, dir string, report *model.TestReport) {
+	ctx = uninterruptible(ctx)
+	config, client, err := tc.CurrentClusterClient()
+	if err == nil {
+		namespace := ""
+		if namespacer := tc.Namespacer(); namespacer != nil {
+			namespace = namespacer.GetNamespace()
+		}
+		err = tc.Diagnostics().Write(ctx, dir, config, client, namespace)
+	}
+	if err != nil {
+		logging.Log(ctx, logging.Internal, logging.WarnStatus, nil, color.BoldYellow, logging.Section("DIAGNOSTICS", err.Error()))
+	}
+	report.Diagnostics = dir
+	logging.Log(ctx, logging.Internal, logging.LogStatus, nil, color.BoldFgCyan, logging.Section("DIAGNOSTICS", dir))
+}
+
 func (r *runner) testCleanup(ctx context.Context, tc enginecontext.TestContext, cleaner cleaner.Cleaner, report *model.TestReport) error {
+	ctx = uninterruptible(ctx)
 	if tc.SkipDelete() {
 		logging.Log(ctx, logging.Cleanup, logging.SkippedStatus, nil, color.BoldYellow)
 		return nil
@@ -4,13 +4,18 @@ import (
 	"context"
 	"errors"
 	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/kyverno/chainsaw/pkg/apis"
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha2"
 	"github.com/kyverno/chainsaw/pkg/client"
 	fake "github.com/kyverno/chainsaw/pkg/client/testing"
+	"github.com/kyverno/chainsaw/pkg/diagnostics"
 	"github.com/kyverno/chainsaw/pkg/discovery"
 	"github.com/kyverno/chainsaw/pkg/loaders/config"
 	"github.com/kyverno/chainsaw/pkg/model"
@@ -21,6 +26,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/utils/clock"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -117,6 +123,27 @@ func Test_runner_Run(t *testing.T) {
 			},
 		},
 	}
+	dependentTest := func(name string, script string, dependsOn ...string) discovery.Test {
+		return discovery.Test{
+			Test: &model.Test{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: name,
+				},
+				Spec: v1alpha1.TestSpec{
+					DependsOn: dependsOn,
+					Steps: []v1alpha1.TestStep{{
+						TestStepSpec: v1alpha1.TestStepSpec{
+							Try: []v1alpha1.Operation{{
+								Script: &v1alpha1.Script{
+									Content: script,
+								},
+							}},
+						},
+					}},
+				},
+			},
+		}
+	}
 	mockTC := func(client client.Client) enginecontext.TestContext {
 		registry := mocks.Registry{
 			Client: client,
@@ -138,6 +165,7 @@ func Test_runner_Run(t *testing.T) {
 	tests := []struct {
 		name    string
 		config  model.Configuration
+		hooks   Hooks
 		tc      enginecontext.TestContext
 		tests   []discovery.Test
 		want    *summaryResult
@@ -336,6 +364,202 @@ func Test_runner_Run(t *testing.T) {
 		want: &summaryResult{
 			passed: 1,
 		},
+	}, {
+		name: "With dependencies",
+		config: model.Configuration{
+			Namespace: v1alpha2.NamespaceOptions{
+				Name: "default",
+			},
+		},
+		tc: func() enginecontext.TestContext {
+			client := &fake.FakeClient{
+				GetFn: func(ctx context.Context, call int, key ctrlclient.ObjectKey, obj ctrlclient.Object, opts ...ctrlclient.GetOption) error {
+					return nil
+				},
+			}
+			return mockTC(client)
+		}(),
+		tests: []discovery.Test{
+			dependentTest("c", "echo c", "b"),
+			dependentTest("b", "echo b", "a"),
+			dependentTest("a", "echo a"),
+		},
+		want: &summaryResult{
+			passed: 3,
+		},
+	}, {
+		name: "With failed dependency",
+		config: model.Configuration{
+			Namespace: v1alpha2.NamespaceOptions{
+				Name: "default",
+			},
+		},
+		tc: func() enginecontext.TestContext {
+			client := &fake.FakeClient{
+				GetFn: func(ctx context.Context, call int, key ctrlclient.ObjectKey, obj ctrlclient.Object, opts ...ctrlclient.GetOption) error {
+					return nil
+				},
+			}
+			return mockTC(client)
+		}(),
+		tests: []discovery.Test{
+			dependentTest("a", "exit 1"),
+			dependentTest("b", "echo b", "a"),
+			dependentTest("c", "echo c", "b"),
+			dependentTest("d", "echo d"),
+		},
+		want: &summaryResult{
+			passed:  1,
+			failed:  1,
+			skipped: 2,
+		},
+	}, {
+		name: "With dependency cycle",
+		config: model.Configuration{
+			Namespace: v1alpha2.NamespaceOptions{
+				Name: "default",
+			},
+		},
+		tc: func() enginecontext.TestContext {
+			client := &fake.FakeClient{
+				GetFn: func(ctx context.Context, call int, key ctrlclient.ObjectKey, obj ctrlclient.Object, opts ...ctrlclient.GetOption) error {
+					return nil
+				},
+			}
+			return mockTC(client)
+		}(),
+		tests: []discovery.Test{
+			dependentTest("a", "echo a", "b"),
+			dependentTest("b", "echo b", "a"),
+			dependentTest("c", "echo c"),
+		},
+		want: &summaryResult{
+			passed: 1,
+			failed: 2,
+		},
+	}, {
+		name: "With setup and teardown",
+		config: model.Configuration{
+			Namespace: v1alpha2.NamespaceOptions{
+				Name: "default",
+			},
+		},
+		hooks: Hooks{
+			Setup: &v1alpha1.TestStepSpec{
+				Try: []v1alpha1.Operation{{
+					Script: &v1alpha1.Script{
+						Content: "echo -n bar",
+						ActionOutputs: v1alpha1.ActionOutputs{
+							Outputs: []v1alpha1.Output{{
+								Binding: v1alpha1.Binding{
+									Name:  "foo",
+									Value: v1alpha1.NewProjection("($stdout)"),
+								},
+							}},
+						},
+					},
+				}},
+			},
+			Teardown: &v1alpha1.TestStepSpec{
+				Try: []v1alpha1.Operation{{
+					Script: &v1alpha1.Script{
+						Content: "echo teardown",
+					},
+				}},
+			},
+		},
+		tc: func() enginecontext.TestContext {
+			client := &fake.FakeClient{
+				GetFn: func(ctx context.Context, call int, key ctrlclient.ObjectKey, obj ctrlclient.Object, opts ...ctrlclient.GetOption) error {
+					return nil
+				},
+			}
+			return mockTC(client)
+		}(),
+		tests: []discovery.Test{{
+			Test: &model.Test{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+				},
+				Spec: v1alpha1.TestSpec{
+					Steps: []v1alpha1.TestStep{{
+						TestStepSpec: v1alpha1.TestStepSpec{
+							Try: []v1alpha1.Operation{{
+								Script: &v1alpha1.Script{
+									ActionEnv: v1alpha1.ActionEnv{
+										Env: []v1alpha1.Binding{{
+											Name:  "FOO",
+											Value: v1alpha1.NewProjection("($foo)"),
+										}},
+									},
+									Content: "test \"$FOO\" = bar",
+								},
+							}},
+						},
+					}},
+				},
+			},
+		}},
+		want: &summaryResult{
+			passed: 1,
+		},
+	}, {
+		name: "With failed setup",
+		config: model.Configuration{
+			Namespace: v1alpha2.NamespaceOptions{
+				Name: "default",
+			},
+		},
+		hooks: Hooks{
+			Setup: &v1alpha1.TestStepSpec{
+				Try: []v1alpha1.Operation{{
+					Script: &v1alpha1.Script{
+						Content: "exit 1",
+					},
+				}},
+			},
+		},
+		tc: func() enginecontext.TestContext {
+			client := &fake.FakeClient{
+				GetFn: func(ctx context.Context, call int, key ctrlclient.ObjectKey, obj ctrlclient.Object, opts ...ctrlclient.GetOption) error {
+					return nil
+				},
+			}
+			return mockTC(client)
+		}(),
+		tests: []discovery.Test{echoTest},
+		want: &summaryResult{
+			failed: 1,
+		},
+	}, {
+		name: "With failed teardown",
+		config: model.Configuration{
+			Namespace: v1alpha2.NamespaceOptions{
+				Name: "default",
+			},
+		},
+		hooks: Hooks{
+			Teardown: &v1alpha1.TestStepSpec{
+				Try: []v1alpha1.Operation{{
+					Script: &v1alpha1.Script{
+						Content: "exit 1",
+					},
+				}},
+			},
+		},
+		tc: func() enginecontext.TestContext {
+			client := &fake.FakeClient{
+				GetFn: func(ctx context.Context, call int, key ctrlclient.ObjectKey, obj ctrlclient.Object, opts ...ctrlclient.GetOption) error {
+					return nil
+				},
+			}
+			return mockTC(client)
+		}(),
+		tests: []discovery.Test{echoTest},
+		want: &summaryResult{
+			passed: 1,
+			failed: 1,
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -346,7 +570,7 @@ func Test_runner_Run(t *testing.T) {
 			// to allow unit tests to work
 			assert.NoError(t, flags.SetupFlags(tt.config))
 			assert.NoError(t, flag.Set("test.testlogfile", ""))
-			err := r.Run(context.TODO(), tt.config.Namespace, tt.tc, tt.tests...)
+			err := r.Run(context.TODO(), tt.config.Namespace, tt.hooks, tt.tc, tt.tests...)
 			if tt.wantErr {
 				assert.Error(t, err)
 			} else {
@@ -407,7 +631,7 @@ func Test_runner_run(t *testing.T) {
 			r := &runner{
 				clock: clock.RealClock{},
 			}
-			err := r.run(context.TODO(), tt.m, v1alpha2.NamespaceOptions{}, enginecontext.EmptyContext(clock.RealClock{}), tt.tests...)
+			err := r.run(context.TODO(), tt.m, v1alpha2.NamespaceOptions{}, Hooks{}, enginecontext.EmptyContext(clock.RealClock{}), tt.tests...)
 			if tt.wantErr {
 				assert.Error(t, err)
 			} else {
@@ -436,3 +660,337 @@ func Test_runner_onFail(t *testing.T) {
 		assert.True(t, called)
 	}
 }
+
+func Test_runner_Run_diagnostics(t *testing.T) {
+	config, err := config.DefaultConfiguration()
+	assert.NoError(t, err)
+	dir := t.TempDir()
+	client := &fake.FakeClient{
+		GetFn: func(ctx context.Context, call int, key ctrlclient.ObjectKey, obj ctrlclient.Object, opts ...ctrlclient.GetOption) error {
+			return nil
+		},
+		ListFn: func(ctx context.Context, call int, list ctrlclient.ObjectList, opts ...ctrlclient.ListOption) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			var event unstructured.Unstructured
+			event.SetAPIVersion("v1")
+			event.SetKind("Event")
+			event.SetName("event")
+			list.(*unstructured.UnstructuredList).Items = []unstructured.Unstructured{event}
+			return nil
+		},
+	}
+	tc := enginecontext.MakeContext(clock.RealClock{}, apis.NewBindings(), mocks.Registry{Client: client}).
+		WithParallel(4).
+		WithTimeouts(v1alpha1.Timeouts{Exec: &config.Spec.Timeouts.Exec}).
+		WithDiagnosticsPath(dir)
+	// tests run in their folder
+	folders := t.TempDir()
+	test := func(folder string, name string, script string) discovery.Test {
+		basePath := filepath.Join(folders, folder)
+		assert.NoError(t, os.MkdirAll(basePath, 0o755))
+		return discovery.Test{
+			BasePath: basePath,
+			Test: &model.Test{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: name,
+				},
+				Spec: v1alpha1.TestSpec{
+					Steps: []v1alpha1.TestStep{{
+						TestStepSpec: v1alpha1.TestStepSpec{
+							Try: []v1alpha1.Operation{{
+								Script: &v1alpha1.Script{
+									Content: script,
+								},
+							}},
+						},
+					}},
+				},
+			},
+		}
+	}
+	r := &runner{
+		clock: clock.RealClock{},
+		deps:  &internal.TestDeps{Test: true},
+	}
+	assert.NoError(t, flags.SetupFlags(config.Spec))
+	assert.NoError(t, flag.Set("test.testlogfile", ""))
+	// the test interrupted while running is failed
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	time.AfterFunc(500*time.Millisecond, cancel)
+	tests := []discovery.Test{
+		test("foo", "failing", "exit 1"),
+		test("bar", "failing", "exit 1"),
+		test("bar", "passing", "echo hello"),
+		test("baz", "interrupted", "exec sleep 10"),
+	}
+	err = r.Run(ctx, v1alpha2.NamespaceOptions{Name: "default"}, Hooks{}, tc, tests...)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), tc.Failed())
+	// tests with the same name don't share diagnostics
+	assert.DirExists(t, filepath.Join(dir, "1-failing"))
+	assert.DirExists(t, filepath.Join(dir, "2-failing"))
+	assert.NoDirExists(t, filepath.Join(dir, "3-passing"))
+	// diagnostics are collected after the interruption
+	assert.FileExists(t, filepath.Join(dir, "4-interrupted", diagnostics.EventsFile))
+	for _, report := range tc.Report.Tests {
+		switch filepath.Join(filepath.Base(report.BasePath), report.Name) {
+		case "foo/failing":
+			assert.Equal(t, filepath.Join(dir, "1-failing"), report.Diagnostics)
+		case "bar/failing":
+			assert.Equal(t, filepath.Join(dir, "2-failing"), report.Diagnostics)
+		case "baz/interrupted":
+			assert.Equal(t, filepath.Join(dir, "4-interrupted"), report.Diagnostics)
+		default:
+			assert.Empty(t, report.Diagnostics)
+		}
+	}
+}
+
+func Test_runner_Run_interrupted(t *testing.T) {
+	config, err := config.DefaultConfiguration()
+	assert.NoError(t, err)
+	client := &fake.FakeClient{
+		GetFn: func(ctx context.Context, call int, key ctrlclient.ObjectKey, obj ctrlclient.Object, opts ...ctrlclient.GetOption) error {
+			return nil
+		},
+	}
+	tc := enginecontext.MakeContext(clock.RealClock{}, apis.NewBindings(), mocks.Registry{Client: client}).
+		WithTimeouts(v1alpha1.Timeouts{Exec: &config.Spec.Timeouts.Exec})
+	test := discovery.Test{
+		Test: &model.Test{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "interrupted",
+			},
+			Spec: v1alpha1.TestSpec{
+				Steps: []v1alpha1.TestStep{{
+					TestStepSpec: v1alpha1.TestStepSpec{
+						Try: []v1alpha1.Operation{{
+							Script: &v1alpha1.Script{
+								Content: "echo hello",
+							},
+						}},
+					},
+				}},
+			},
+		},
+	}
+	r := &runner{
+		clock: clock.RealClock{},
+		deps:  &internal.TestDeps{Test: true},
+	}
+	assert.NoError(t, flags.SetupFlags(config.Spec))
+	assert.NoError(t, flag.Set("test.testlogfile", ""))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = r.Run(ctx, v1alpha2.NamespaceOptions{Name: "default"}, Hooks{}, tc, test)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), tc.Skipped())
+	assert.Len(t, tc.Report.Tests, 1)
+	assert.True(t, tc.Report.Tests[0].Skipped)
+	assert.Equal(t, "interrupted", tc.Report.Tests[0].SkipReason)
+}
+
+func Test_runner_Run_interrupted_running(t *testing.T) {
+	config, err := config.DefaultConfiguration()
+	assert.NoError(t, err)
+	client := &fake.FakeClient{
+		GetFn: func(ctx context.Context, call int, key ctrlclient.ObjectKey, obj ctrlclient.Object, opts ...ctrlclient.GetOption) error {
+			return nil
+		},
+	}
+	tc := enginecontext.MakeContext(clock.RealClock{}, apis.NewBindings(), mocks.Registry{Client: client}).
+		WithParallel(2).
+		WithTimeouts(v1alpha1.Timeouts{Exec: &config.Spec.Timeouts.Exec})
+	finally := filepath.Join(t.TempDir(), "finally")
+	tests := []discovery.Test{{
+		Test: &model.Test{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "passed",
+			},
+			Spec: v1alpha1.TestSpec{
+				Steps: []v1alpha1.TestStep{{
+					TestStepSpec: v1alpha1.TestStepSpec{
+						Try: []v1alpha1.Operation{{
+							Script: &v1alpha1.Script{
+								Content: "echo hello",
+							},
+						}},
+					},
+				}},
+			},
+		},
+	}, {
+		Test: &model.Test{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "interrupted",
+			},
+			Spec: v1alpha1.TestSpec{
+				Steps: []v1alpha1.TestStep{{
+					TestStepSpec: v1alpha1.TestStepSpec{
+						Try: []v1alpha1.Operation{{
+							Script: &v1alpha1.Script{
+								Content: "exec sleep 10",
+							},
+						}},
+						// finally runs after the interruption
+						Finally: []v1alpha1.CatchFinally{{
+							Script: &v1alpha1.Script{
+								Content: fmt.Sprintf("touch %s", finally),
+							},
+						}},
+					},
+				}},
+			},
+		},
+	}}
+	r := &runner{
+		clock: clock.RealClock{},
+		deps:  &internal.TestDeps{Test: true},
+	}
+	assert.NoError(t, flags.SetupFlags(config.Spec))
+	assert.NoError(t, flag.Set("test.testlogfile", ""))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	time.AfterFunc(500*time.Millisecond, cancel)
+	err = r.Run(ctx, v1alpha2.NamespaceOptions{Name: "default"}, Hooks{}, tc, tests...)
+	assert.NoError(t, err)
+	// the test that completed before the interruption passed
+	assert.Equal(t, int32(1), tc.Passed())
+	assert.Equal(t, int32(1), tc.Failed())
+	assert.FileExists(t, finally)
+	for _, report := range tc.Report.Tests {
+		assert.Equal(t, report.Name == "interrupted", report.Interrupted, report.Name)
+		assert.Equal(t, report.Name == "interrupted", report.Failed(), report.Name)
+	}
+}
+
+func Test_runner_Run_background(t *testing.T) {
+	config, err := config.DefaultConfiguration()
+	assert.NoError(t, err)
+	client := &fake.FakeClient{
+		GetFn: func(ctx context.Context, call int, key ctrlclient.ObjectKey, obj ctrlclient.Object, opts ...ctrlclient.GetOption) error {
+			return nil
+		},
+	}
+	tc := enginecontext.MakeContext(clock.RealClock{}, apis.NewBindings(), mocks.Registry{Client: client}).
+		WithTimeouts(v1alpha1.Timeouts{Exec: &config.Spec.Timeouts.Exec, Cleanup: &config.Spec.Timeouts.Cleanup})
+	pid := filepath.Join(t.TempDir(), "pid")
+	test := discovery.Test{
+		Test: &model.Test{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "background",
+			},
+			Spec: v1alpha1.TestSpec{
+				Steps: []v1alpha1.TestStep{{
+					TestStepSpec: v1alpha1.TestStepSpec{
+						Try: []v1alpha1.Operation{{
+							Script: &v1alpha1.Script{
+								Content:    fmt.Sprintf("echo $$ > %s; exec sleep 30", pid),
+								Background: true,
+							},
+						}, {
+							Script: &v1alpha1.Script{
+								Content: fmt.Sprintf("until [ -s %s ]; do sleep 0.1; done", pid),
+							},
+						}},
+					},
+				}, {
+					TestStepSpec: v1alpha1.TestStepSpec{
+						Try: []v1alpha1.Operation{{
+							// the process started by the previous step must be gone
+							Script: &v1alpha1.Script{
+								Content: fmt.Sprintf("if kill -0 $(cat %s); then exit 1; fi", pid),
+							},
+						}},
+					},
+				}},
+			},
+		},
+	}
+	r := &runner{
+		clock: clock.RealClock{},
+		deps:  &internal.TestDeps{Test: true},
+	}
+	assert.NoError(t, flags.SetupFlags(config.Spec))
+	assert.NoError(t, flag.Set("test.testlogfile", ""))
+	err = r.Run(context.TODO(), v1alpha2.NamespaceOptions{Name: "default"}, Hooks{}, tc, test)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(0), tc.Failed())
+	assert.Equal(t, int32(1), tc.Passed())
+	if assert.Len(t, tc.Report.Tests, 1) && assert.Len(t, tc.Report.Tests[0].Steps, 2) {
+		// the process is released as part of the step that started it
+		operations := tc.Report.Tests[0].Steps[0].Operations
+		assert.Equal(t, model.OperationTypeScript, operations[len(operations)-1].Type)
+	}
+}
+
+func Test_runner_Run_dependencies(t *testing.T) {
+	config, err := config.DefaultConfiguration()
+	assert.NoError(t, err)
+	client := &fake.FakeClient{
+		GetFn: func(ctx context.Context, call int, key ctrlclient.ObjectKey, obj ctrlclient.Object, opts ...ctrlclient.GetOption) error {
+			return nil
+		},
+	}
+	tc := enginecontext.MakeContext(clock.RealClock{}, apis.NewBindings(), mocks.Registry{Client: client}).
+		WithParallel(2).
+		WithTimeouts(v1alpha1.Timeouts{Exec: &config.Spec.Timeouts.Exec})
+	test := func(name string, script string, dependsOn ...string) discovery.Test {
+		return discovery.Test{
+			Test: &model.Test{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: name,
+				},
+				Spec: v1alpha1.TestSpec{
+					DependsOn: dependsOn,
+					Steps: []v1alpha1.TestStep{{
+						TestStepSpec: v1alpha1.TestStepSpec{
+							Try: []v1alpha1.Operation{{
+								Script: &v1alpha1.Script{
+									Content: script,
+								},
+							}},
+						},
+					}},
+				},
+			},
+		}
+	}
+	done := filepath.Join(t.TempDir(), "done")
+	tests := []discovery.Test{
+		// waits for a test that depends on another test, it must not block it
+		test("a", fmt.Sprintf("for i in $(seq 100); do [ -f %s ] && exit 0; sleep 0.1; done; exit 1", done)),
+		test("b", "echo b"),
+		test("c", fmt.Sprintf("touch %s", done), "b"),
+		test("d", "echo d", "e"),
+		test("e", "echo e", "d"),
+	}
+	r := &runner{
+		clock: clock.RealClock{},
+		deps:  &internal.TestDeps{Test: true},
+	}
+	assert.NoError(t, flags.SetupFlags(config.Spec))
+	assert.NoError(t, flag.Set("test.testlogfile", ""))
+	err = r.Run(context.TODO(), v1alpha2.NamespaceOptions{Name: "default"}, Hooks{}, tc, tests...)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), tc.Passed())
+	assert.Equal(t, int32(2), tc.Failed())
+	reports := map[string]*model.TestReport{}
+	for _, report := range tc.Report.Tests {
+		reports[report.Name] = report
+	}
+	assert.Len(t, reports, 5)
+	for _, name := range []string{"a", "b", "c"} {
+		if assert.Contains(t, reports, name) {
+			assert.False(t, reports[name].Failed())
+		}
+	}
+	for _, name := range []string{"d", "e"} {
+		if assert.Contains(t, reports, name) {
+			assert.EqualError(t, reports[name].Err, "dependency cycle detected: d, e")
+		}
+	}
+}
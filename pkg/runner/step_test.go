@@ -56,6 +56,68 @@ func TestStepProcessor_Run(t *testing.T) {
 				Finally:  []v1alpha1.CatchFinally{},
 			},
 		},
+	}, {
+		name:       "step skipped by condition",
+		client:     &fake.FakeClient{},
+		namespacer: &fakeNamespacer.FakeNamespacer{},
+		basePath:   testData,
+		stepSpec: v1alpha1.TestStep{
+			If: "(`false`)",
+			TestStepSpec: v1alpha1.TestStepSpec{
+				Timeouts: &v1alpha1.Timeouts{},
+				Try: []v1alpha1.Operation{{
+					Command: &v1alpha1.Command{
+						Entrypoint: "false",
+					},
+				}},
+			},
+		},
+	}, {
+		name:       "step with invalid condition",
+		client:     &fake.FakeClient{},
+		namespacer: &fakeNamespacer.FakeNamespacer{},
+		basePath:   testData,
+		stepSpec: v1alpha1.TestStep{
+			If: "(`42`)",
+			TestStepSpec: v1alpha1.TestStepSpec{
+				Timeouts: &v1alpha1.Timeouts{},
+				Try: []v1alpha1.Operation{{
+					Command: &v1alpha1.Command{
+						Entrypoint: "true",
+					},
+				}},
+			},
+		},
+		want:         true,
+		expectedFail: true,
+	}, {
+		name:       "operations skipped by condition",
+		client:     &fake.FakeClient{},
+		namespacer: &fakeNamespacer.FakeNamespacer{},
+		basePath:   testData,
+		stepSpec: v1alpha1.TestStep{
+			TestStepSpec: v1alpha1.TestStepSpec{
+				Timeouts: &v1alpha1.Timeouts{},
+				Bindings: []v1alpha1.Binding{{
+					Name:  "enabled",
+					Value: v1alpha1.NewProjection(false),
+				}},
+				Try: []v1alpha1.Operation{{
+					OperationBase: v1alpha1.OperationBase{
+						If: "($enabled)",
+					},
+					Command: &v1alpha1.Command{
+						Entrypoint: "false",
+					},
+				}},
+				Finally: []v1alpha1.CatchFinally{{
+					If: "($enabled)",
+					Command: &v1alpha1.Command{
+						Entrypoint: "false",
+					},
+				}},
+			},
+		},
 	}, {
 		name: "try operation with apply handler",
 		client: &fake.FakeClient{
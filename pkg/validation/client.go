@@ -0,0 +1,35 @@
+package validation
+
+import (
+	"os"
+
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/openapi"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/kubectl-validate/pkg/openapiclient"
+)
+
+// DefaultKubernetesVersion is the most recent Kubernetes version with embedded builtin schemas.
+var DefaultKubernetesVersion = openapiclient.HardcodedBuiltinVersions[len(openapiclient.HardcodedBuiltinVersions)-1]
+
+// NewClient returns an OpenAPI client serving the schemas of the CRDs found in the given folders.
+// Builtin schemas are discovered from the cluster when a config is provided, embedded builtin schemas are used otherwise.
+func NewClient(config *rest.Config, crdDirs ...string) (openapi.Client, error) {
+	var clients []openapi.Client
+	if config != nil {
+		client, err := discovery.NewDiscoveryClientForConfig(config)
+		if err != nil {
+			return nil, err
+		}
+		clients = append(clients, client.OpenAPIV3())
+	} else {
+		clients = append(clients, openapiclient.NewHardcodedBuiltins(DefaultKubernetesVersion))
+	}
+	for _, dir := range crdDirs {
+		if _, err := os.Stat(dir); err != nil {
+			return nil, err
+		}
+		clients = append(clients, openapiclient.NewLocalCRDFiles(os.DirFS(dir)))
+	}
+	return openapiclient.NewComposite(clients...), nil
+}
@@ -0,0 +1,201 @@
+package validation
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/loaders/resource"
+	"github.com/kyverno/chainsaw/pkg/loaders/steptemplate"
+)
+
+// Failure is a violation found in a resource referenced by a test.
+type Failure struct {
+	// Source is the file the resource was loaded from or the location of the resource in the test.
+	Source string
+	Violation
+}
+
+func (f Failure) String() string {
+	return fmt.Sprintf("%s: %s", f.Source, f.Violation)
+}
+
+// ValidateTest validates the resources referenced by the apply, assert, create, delete, error, patch and update operations of a test.
+// Every operation list is walked, including catch, finally and cleanup blocks and the operations of the step templates used by the test.
+// Files and step templates are resolved relative to the base path, the ones that can't be loaded are ignored and reported when the test runs.
+func ValidateTest(validator *Validator, basePath string, test *v1alpha1.Test) ([]Failure, error) {
+	var failures []Failure
+	add := func(f []Failure, err error) error {
+		failures = append(failures, f...)
+		return err
+	}
+	templates := map[string]struct{}{}
+	for i, step := range test.Spec.Steps {
+		location := fmt.Sprintf("spec.steps[%d]", i)
+		if err := add(validateSteps(validator, basePath, location, step.TestStepSpec)); err != nil {
+			return nil, err
+		}
+		if step.Use == nil {
+			continue
+		}
+		path := filepath.Join(basePath, step.Use.Template)
+		if _, ok := templates[path]; ok {
+			continue
+		}
+		templates[path] = struct{}{}
+		// templates that can't be loaded are reported when the test runs
+		if steptpl, err := steptemplate.Load(path, true); err == nil && len(steptpl) == 1 {
+			spec := steptpl[0].Spec
+			location := fmt.Sprintf("%s: spec", path)
+			if err := add(validateSteps(validator, basePath, location, v1alpha1.TestStepSpec{Try: spec.Try, Catch: spec.Catch, Finally: spec.Finally, Cleanup: spec.Cleanup})); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := add(validateCatchFinallies(validator, basePath, "spec.catch", test.Spec.Catch)); err != nil {
+		return nil, err
+	}
+	return failures, nil
+}
+
+func validateSteps(validator *Validator, basePath string, location string, step v1alpha1.TestStepSpec) ([]Failure, error) {
+	var failures []Failure
+	for i := range step.Try {
+		f, err := validateOperation(validator, basePath, fmt.Sprintf("%s.try[%d]", location, i), step.Try[i])
+		if err != nil {
+			return nil, err
+		}
+		failures = append(failures, f...)
+	}
+	for _, block := range []struct {
+		key        string
+		operations []v1alpha1.CatchFinally
+	}{{"catch", step.Catch}, {"finally", step.Finally}, {"cleanup", step.Cleanup}} {
+		f, err := validateCatchFinallies(validator, basePath, fmt.Sprintf("%s.%s", location, block.key), block.operations)
+		if err != nil {
+			return nil, err
+		}
+		failures = append(failures, f...)
+	}
+	return failures, nil
+}
+
+func validateCatchFinallies(validator *Validator, basePath string, location string, operations []v1alpha1.CatchFinally) ([]Failure, error) {
+	var failures []Failure
+	for i := range operations {
+		f, err := validateCatchFinally(validator, basePath, fmt.Sprintf("%s[%d]", location, i), operations[i])
+		if err != nil {
+			return nil, err
+		}
+		failures = append(failures, f...)
+	}
+	return failures, nil
+}
+
+func validateCatchFinally(validator *Validator, basePath string, location string, operation v1alpha1.CatchFinally) ([]Failure, error) {
+	if len(operation.Parallel) != 0 {
+		return validateCatchFinallies(validator, basePath, location+".parallel", operation.Parallel)
+	}
+	if operation.Delete != nil {
+		return validateRef(validator, basePath, location+".delete.resource", operation.Delete.File, nil)
+	}
+	return nil, nil
+}
+
+func validateOperation(validator *Validator, basePath string, location string, operation v1alpha1.Operation) ([]Failure, error) {
+	var failures []Failure
+	for i := range operation.Parallel {
+		f, err := validateOperation(validator, basePath, fmt.Sprintf("%s.parallel[%d]", location, i), operation.Parallel[i])
+		if err != nil {
+			return nil, err
+		}
+		failures = append(failures, f...)
+	}
+	validate := func(action string, file v1alpha1.Expression, resource any) error {
+		f, err := validateRef(validator, basePath, fmt.Sprintf("%s.%s.resource", location, action), file, resource)
+		failures = append(failures, f...)
+		return err
+	}
+	var err error
+	switch {
+	case operation.Apply != nil:
+		err = validate("apply", operation.Apply.File, objectOf(operation.Apply.ActionResourceRef))
+	case operation.Assert != nil:
+		err = validate("assert", operation.Assert.File, valueOf(operation.Assert.Check))
+	case operation.Create != nil:
+		err = validate("create", operation.Create.File, objectOf(operation.Create.ActionResourceRef))
+	case operation.Delete != nil:
+		err = validate("delete", operation.Delete.File, nil)
+	case operation.Error != nil:
+		err = validate("error", operation.Error.File, valueOf(operation.Error.Check))
+	case operation.Patch != nil:
+		err = validate("patch", operation.Patch.File, objectOf(operation.Patch.ActionResourceRef))
+	case operation.Update != nil:
+		err = validate("update", operation.Update.File, objectOf(operation.Update.ActionResourceRef))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return failures, nil
+}
+
+func validateRef(validator *Validator, basePath string, location string, file v1alpha1.Expression, value any) ([]Failure, error) {
+	if object, ok := value.(map[string]any); ok {
+		violations, err := validator.Validate(object)
+		if err != nil {
+			return nil, err
+		}
+		var failures []Failure
+		for _, violation := range violations {
+			failures = append(failures, Failure{Source: location, Violation: violation})
+		}
+		return failures, nil
+	}
+	path := string(file)
+	if path == "" || isExpression(path) {
+		return nil, nil
+	}
+	if _, err := url.ParseRequestURI(path); err == nil {
+		return nil, nil
+	}
+	matches, err := filepath.Glob(filepath.Join(basePath, path))
+	if err != nil {
+		return nil, nil
+	}
+	var failures []Failure
+	for _, match := range matches {
+		resources, err := resource.Load(match, false)
+		if err != nil {
+			continue
+		}
+		for i := range resources {
+			violations, err := validator.Validate(resources[i].Object)
+			if err != nil {
+				return nil, err
+			}
+			source := match
+			if len(resources) > 1 {
+				source = fmt.Sprintf("%s[%d]", match, i)
+			}
+			for _, violation := range violations {
+				failures = append(failures, Failure{Source: source, Violation: violation})
+			}
+		}
+	}
+	return failures, nil
+}
+
+func objectOf(ref v1alpha1.ActionResourceRef) any {
+	if ref.Resource == nil {
+		return nil
+	}
+	return ref.Resource.Object
+}
+
+func valueOf(projection *v1alpha1.Projection) any {
+	if projection == nil {
+		return nil
+	}
+	return projection.Value()
+}
@@ -0,0 +1,38 @@
+package validation
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/kyverno/chainsaw/pkg/loaders/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateTest(t *testing.T) {
+	basePath := filepath.Join("..", "..", "testdata", "validation", "test")
+	client, err := NewClient(nil, filepath.Join("..", "..", "testdata", "validation", "crds"))
+	require.NoError(t, err)
+	tests, err := test.Load(filepath.Join(basePath, "chainsaw-test.yaml"), false)
+	require.NoError(t, err)
+	require.Len(t, tests, 1)
+	failures, err := ValidateTest(New(client), basePath, tests[0])
+	assert.NoError(t, err)
+	var got []string
+	for _, failure := range failures {
+		got = append(got, failure.String())
+	}
+	deployment := filepath.Join(basePath, "deployment.yaml")
+	configMap := filepath.Join(basePath, "configmap.yaml")
+	template := filepath.Join(basePath, "step-template.yaml")
+	service := filepath.Join(basePath, "service.yaml")
+	assert.Equal(t, []string{
+		deployment + ": spec.replicas: invalid type, expected integer but got string",
+		deployment + `: spec.template.spec.containers[0].port: unknown field "port"`,
+		`spec.steps[0].try[1].assert.resource: spec.replcas: unknown field "replcas"`,
+		"spec.steps[0].try[2].parallel[0].apply.resource: spec.size: invalid type, expected integer but got string",
+		configMap + `: datas: unknown field "datas"`,
+		template + ": spec.try[0].create.resource: data: invalid type, expected object but got string",
+		service + ": spec.ports: invalid type, expected array but got integer",
+	}, got)
+}
@@ -0,0 +1,279 @@
+package validation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/kyverno/chainsaw/pkg/expressions"
+	"github.com/kyverno/kyverno-json/pkg/core/expression"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/openapi"
+	"k8s.io/kube-openapi/pkg/spec3"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"sigs.k8s.io/kubectl-validate/pkg/utils"
+)
+
+// Violation is a field of a resource that doesn't match the schema.
+type Violation struct {
+	// Path contains the keys (string) and indices (int) leading to the field.
+	Path    []any
+	Message string
+}
+
+// Field returns the path of the field using the dotted notation.
+func (v Violation) Field() string {
+	var b strings.Builder
+	for _, element := range v.Path {
+		switch element := element.(type) {
+		case int:
+			fmt.Fprintf(&b, "[%d]", element)
+		default:
+			if b.Len() != 0 {
+				b.WriteString(".")
+			}
+			fmt.Fprint(&b, element)
+		}
+	}
+	return b.String()
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%s: %s", v.Field(), v.Message)
+}
+
+// Validator validates resources against the OpenAPI schemas served by a client.
+type Validator struct {
+	client    openapi.Client
+	lock      sync.Mutex
+	paths     map[string]openapi.GroupVersion
+	documents map[string]*spec3.OpenAPI
+}
+
+func New(client openapi.Client) *Validator {
+	return &Validator{
+		client:    client,
+		documents: map[string]*spec3.OpenAPI{},
+	}
+}
+
+// Validate checks the resource fields against the schema of the resource kind.
+// Unknown fields and type mismatches are reported, expression keys and values are not checked.
+// No violation is returned when the resource kind has no known schema.
+func (v *Validator) Validate(resource map[string]any) ([]Violation, error) {
+	apiVersion, _ := resource["apiVersion"].(string)
+	kind, _ := resource["kind"].(string)
+	if apiVersion == "" || kind == "" || isExpression(apiVersion) || isExpression(kind) {
+		return nil, nil
+	}
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return nil, nil
+	}
+	document, err := v.document(gv)
+	if err != nil || document == nil {
+		return nil, err
+	}
+	gvk := gv.WithKind(kind)
+	for _, name := range sortedKeys(document.Components.Schemas) {
+		definition := document.Components.Schemas[name]
+		if slices.Contains(utils.ExtractExtensionGVKs(definition.Extensions), gvk) {
+			w := walker{components: document.Components.Schemas}
+			w.walk(nil, resource, definition)
+			return w.violations, nil
+		}
+	}
+	return nil, nil
+}
+
+func (v *Validator) document(gv schema.GroupVersion) (*spec3.OpenAPI, error) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	if v.paths == nil {
+		paths, err := v.client.Paths()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load OpenAPI schemas: %w", err)
+		}
+		v.paths = paths
+	}
+	gvPath := "apis/" + gv.Group + "/" + gv.Version
+	if gv.Group == "" {
+		gvPath = "api/" + gv.Version
+	}
+	if document, ok := v.documents[gvPath]; ok {
+		return document, nil
+	}
+	var document *spec3.OpenAPI
+	if groupVersion, ok := v.paths[gvPath]; ok {
+		content, err := groupVersion.Schema("application/json")
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch OpenAPI schema for %s: %w", gv, err)
+		}
+		document = &spec3.OpenAPI{}
+		if err := json.Unmarshal(content, document); err != nil {
+			return nil, fmt.Errorf("failed to parse OpenAPI schema for %s: %w", gv, err)
+		}
+		if document.Components == nil {
+			document = nil
+		}
+	}
+	v.documents[gvPath] = document
+	return document, nil
+}
+
+type walker struct {
+	components map[string]*spec.Schema
+	violations []Violation
+}
+
+func (w *walker) report(path []any, format string, args ...any) {
+	w.violations = append(w.violations, Violation{
+		Path:    slices.Clone(path),
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// resolve follows references until a concrete schema is found.
+func (w *walker) resolve(s *spec.Schema) *spec.Schema {
+	// bound the number of hops to protect against reference cycles
+	for range 10 {
+		if s == nil {
+			return nil
+		}
+		ref := s.Ref.String()
+		if ref == "" && len(s.AllOf) == 1 {
+			ref = s.AllOf[0].Ref.String()
+		}
+		if ref == "" {
+			return s
+		}
+		s = w.components[path.Base(ref)]
+	}
+	return nil
+}
+
+func (w *walker) walk(path []any, value any, s *spec.Schema) {
+	s = w.resolve(s)
+	if s == nil || value == nil {
+		return
+	}
+	if str, ok := value.(string); ok && isExpression(str) {
+		return
+	}
+	if intOrString, _ := s.Extensions.GetBool("x-kubernetes-int-or-string"); intOrString || s.Format == "int-or-string" {
+		if _, ok := value.(string); !ok && !isInteger(value) {
+			w.report(path, "invalid type, expected integer or string but got %s", typeOf(value))
+		}
+		return
+	}
+	var expected string
+	if len(s.Type) == 1 {
+		expected = s.Type[0]
+	}
+	switch expected {
+	case "", "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			if expected != "" {
+				w.report(path, "invalid type, expected object but got %s", typeOf(value))
+			}
+			return
+		}
+		w.object(path, object, s)
+	case "array":
+		array, ok := value.([]any)
+		if !ok {
+			w.report(path, "invalid type, expected array but got %s", typeOf(value))
+			return
+		}
+		if s.Items != nil && s.Items.Schema != nil {
+			for i, item := range array {
+				w.walk(append(path, i), item, s.Items.Schema)
+			}
+		}
+	case "string":
+		if _, ok := value.(string); !ok {
+			w.report(path, "invalid type, expected string but got %s", typeOf(value))
+		}
+	case "integer":
+		if !isInteger(value) {
+			w.report(path, "invalid type, expected integer but got %s", typeOf(value))
+		}
+	case "number":
+		if typeOf(value) != "integer" && typeOf(value) != "number" {
+			w.report(path, "invalid type, expected number but got %s", typeOf(value))
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			w.report(path, "invalid type, expected boolean but got %s", typeOf(value))
+		}
+	}
+}
+
+func (w *walker) object(path []any, object map[string]any, s *spec.Schema) {
+	preserveUnknownFields, _ := s.Extensions.GetBool("x-kubernetes-preserve-unknown-fields")
+	for _, key := range sortedKeys(object) {
+		// expression and foreach keys are evaluated at runtime, bindings (key->name) still designate a field
+		parsed := expression.Parse(key)
+		if parsed.Compiler != "" || parsed.Foreach {
+			continue
+		}
+		if property, ok := s.Properties[parsed.Statement]; ok {
+			w.walk(append(path, key), object[key], &property)
+		} else if s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil {
+			w.walk(append(path, key), object[key], s.AdditionalProperties.Schema)
+		} else if len(s.Properties) != 0 && !preserveUnknownFields && (s.AdditionalProperties == nil || !s.AdditionalProperties.Allows) {
+			w.report(append(path, key), "unknown field %q", parsed.Statement)
+		}
+	}
+}
+
+func isExpression(value string) bool {
+	parsed := expressions.Parse(context.TODO(), value)
+	return parsed != nil && parsed.Engine != ""
+}
+
+func isInteger(value any) bool {
+	switch value := value.(type) {
+	case int, int32, int64, uint, uint32, uint64:
+		return true
+	case float64:
+		return value == math.Trunc(value)
+	}
+	return false
+}
+
+func typeOf(value any) string {
+	switch value := value.(type) {
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case float32, float64:
+		if isInteger(value) {
+			return "integer"
+		}
+		return "number"
+	case int, int32, int64, uint, uint32, uint64:
+		return "integer"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,153 @@
+package validation
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/openapi"
+)
+
+type errClient struct{}
+
+func (errClient) Paths() (map[string]openapi.GroupVersion, error) {
+	return nil, errors.New("dummy")
+}
+
+func TestViolation_Field(t *testing.T) {
+	violation := Violation{Path: []any{"spec", "containers", 0, "ports", 1, "port"}, Message: "dummy"}
+	assert.Equal(t, "spec.containers[0].ports[1].port", violation.Field())
+	assert.Equal(t, "spec.containers[0].ports[1].port: dummy", violation.String())
+}
+
+func TestValidator_Validate(t *testing.T) {
+	client, err := NewClient(nil, filepath.Join("..", "..", "testdata", "validation", "crds"))
+	require.NoError(t, err)
+	validator := New(client)
+	tests := []struct {
+		name     string
+		resource map[string]any
+		want     []string
+	}{{
+		name: "valid",
+		resource: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]any{"name": "foo", "labels": map[string]any{"app": "foo"}},
+			"data":       map[string]any{"key": "value"},
+		},
+	}, {
+		name: "unknown field",
+		resource: map[string]any{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"spec":       map[string]any{"replcas": int64(1)},
+		},
+		want: []string{`spec.replcas: unknown field "replcas"`},
+	}, {
+		name: "type mismatch",
+		resource: map[string]any{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"spec": map[string]any{
+				"replicas": "1",
+				"template": map[string]any{
+					"spec": map[string]any{
+						"containers": []any{map[string]any{"name": "nginx", "ports": []any{map[string]any{"containerPort": 1.5}}}},
+					},
+				},
+			},
+		},
+		want: []string{
+			"spec.replicas: invalid type, expected integer but got string",
+			"spec.template.spec.containers[0].ports[0].containerPort: invalid type, expected integer but got number",
+		},
+	}, {
+		name: "int or string",
+		resource: map[string]any{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"spec": map[string]any{
+				"strategy": map[string]any{"rollingUpdate": map[string]any{"maxSurge": "25%", "maxUnavailable": true}},
+			},
+		},
+		want: []string{"spec.strategy.rollingUpdate.maxUnavailable: invalid type, expected integer or string but got boolean"},
+	}, {
+		name: "expressions",
+		resource: map[string]any{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata":   map[string]any{"name": "($name)"},
+			"spec": map[string]any{
+				"(replicas > `1`)": true,
+				"replicas":         "($replicas)",
+				"~.(template.spec.containers)": map[string]any{
+					"foo": "bar",
+				},
+				"selector->selector": map[string]any{"foo": "bar"},
+			},
+		},
+		want: []string{`spec.selector->selector.foo: unknown field "foo"`},
+	}, {
+		name: "crd",
+		resource: map[string]any{
+			"apiVersion": "example.com/v1",
+			"kind":       "Widget",
+			"metadata":   map[string]any{"name": "foo"},
+			"spec": map[string]any{
+				"size":     "large",
+				"colour":   "red",
+				"settings": map[string]any{"anything": "goes"},
+			},
+		},
+		want: []string{
+			`spec.colour: unknown field "colour"`,
+			"spec.size: invalid type, expected integer but got string",
+		},
+	}, {
+		name: "unknown kind",
+		resource: map[string]any{
+			"apiVersion": "example.com/v1",
+			"kind":       "Gadget",
+			"spec":       map[string]any{"foo": "bar"},
+		},
+	}, {
+		name: "unknown group version",
+		resource: map[string]any{
+			"apiVersion": "unknown.example.com/v1",
+			"kind":       "Widget",
+			"spec":       map[string]any{"foo": "bar"},
+		},
+	}, {
+		name: "expression kind",
+		resource: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "($kind)",
+			"spec":       map[string]any{"foo": "bar"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations, err := validator.Validate(tt.resource)
+			assert.NoError(t, err)
+			var got []string
+			for _, violation := range violations {
+				got = append(got, violation.String())
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestValidator_ValidateError(t *testing.T) {
+	validator := New(errClient{})
+	_, err := validator.Validate(map[string]any{"apiVersion": "v1", "kind": "ConfigMap"})
+	assert.Error(t, err)
+}
+
+func TestNewClient(t *testing.T) {
+	_, err := NewClient(nil, filepath.Join("..", "..", "testdata", "validation", "not-found"))
+	assert.Error(t, err)
+}